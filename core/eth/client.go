@@ -10,6 +10,9 @@ import (
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
 )
 
 //go:generate mockery -name Client -output ../internal/mocks/ -case=underscore
@@ -20,8 +23,10 @@ type Client interface {
 	LogSubscriber
 	GetNonce(address common.Address) (uint64, error)
 	GetEthBalance(address common.Address) (*assets.Eth, error)
+	BalanceAt(address common.Address, block *big.Int) (*big.Int, error)
 	GetERC20Balance(address common.Address, contractAddress common.Address) (*big.Int, error)
 	SendRawTx(bytes []byte) (common.Hash, error)
+	SendRawTransaction(tx *types.Transaction) (common.Hash, error)
 	GetTxReceipt(hash common.Hash) (*TxReceipt, error)
 	GetBlockHeight() (uint64, error)
 	GetLatestBlock() (Block, error)
@@ -60,9 +65,11 @@ var _ Client = (*CallerSubscriberClient)(nil)
 
 // CallerSubscriber implements the Call and Subscribe functions. Call performs
 // a JSON-RPC call with the given arguments and Subscribe registers a subscription,
-// using an open stream to receive updates from ethereum node.
+// using an open stream to receive updates from ethereum node. CallContext is
+// Call, abandoned with ctx's error if ctx is done before the call completes.
 type CallerSubscriber interface {
 	Call(result interface{}, method string, args ...interface{}) error
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
 	Subscribe(context.Context, interface{}, ...interface{}) (Subscription, error)
 }
 
@@ -88,6 +95,21 @@ func (client *CallerSubscriberClient) GetEthBalance(address common.Address) (*as
 	return amount, nil
 }
 
+// BalanceAt returns the balance in wei of the given address as of the given
+// block, or as of the latest block if block is nil.
+func (client *CallerSubscriberClient) BalanceAt(address common.Address, block *big.Int) (*big.Int, error) {
+	blockArg := "latest"
+	if block != nil {
+		blockArg = hexutil.EncodeBig(block)
+	}
+	var result hexutil.Big
+	err := client.Call(&result, "eth_getBalance", address.Hex(), blockArg)
+	if err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), nil
+}
+
 // CallArgs represents the data used to call the balance method of an ERC
 // contract. "To" is the address of the ERC contract. "Data" is the message sent
 // to the contract.
@@ -121,6 +143,15 @@ func (client *CallerSubscriberClient) SendRawTx(bytes []byte) (common.Hash, erro
 	return result, err
 }
 
+// SendRawTransaction sends a signed transaction to the transaction pool.
+func (client *CallerSubscriberClient) SendRawTransaction(tx *types.Transaction) (common.Hash, error) {
+	bytes, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "while encoding transaction")
+	}
+	return client.SendRawTx(bytes)
+}
+
 // GetTxReceipt returns the transaction receipt for the given transaction hash.
 func (client *CallerSubscriberClient) GetTxReceipt(hash common.Hash) (*TxReceipt, error) {
 	receipt := TxReceipt{}
@@ -14,6 +14,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -122,6 +124,28 @@ func TestCallerSubscriberClient_SendRawTx(t *testing.T) {
 	assert.Equal(t, result, returnedHash)
 }
 
+func TestCallerSubscriberClient_SendRawTransaction(t *testing.T) {
+	t.Parallel()
+
+	ethClientMock := new(mocks.CallerSubscriber)
+	ethClient := &eth.CallerSubscriberClient{CallerSubscriber: ethClientMock}
+	tx := types.NewTransaction(7, cltest.NewAddress(), big.NewInt(1), 21000, big.NewInt(2), []byte{0xde, 0xad})
+	txData, err := rlp.EncodeToBytes(tx)
+	require.NoError(t, err)
+	returnedHash := cltest.NewHash()
+
+	ethClientMock.On("Call", mock.Anything, "eth_sendRawTransaction", hexutil.Encode(txData)).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			res := args.Get(0).(*common.Hash)
+			*res = returnedHash
+		})
+
+	result, err := ethClient.SendRawTransaction(tx)
+	assert.NoError(t, err)
+	assert.Equal(t, result, returnedHash)
+}
+
 func TestCallerSubscriberClient_GetEthBalance(t *testing.T) {
 	t.Parallel()
 
@@ -154,6 +178,42 @@ func TestCallerSubscriberClient_GetEthBalance(t *testing.T) {
 	}
 }
 
+func TestCallerSubscriberClient_BalanceAt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		block    *big.Int
+		blockArg string
+	}{
+		{"latest block", nil, "latest"},
+		{"specific block", big.NewInt(42), "0x2a"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			ethClientMock := new(mocks.CallerSubscriber)
+			ethClient := &eth.CallerSubscriberClient{CallerSubscriber: ethClientMock}
+
+			address := cltest.NewAddress()
+			expected, ok := new(big.Int).SetString("100000000000000000000000000000000000000", 10)
+			require.True(t, ok)
+			ethClientMock.On("Call", mock.Anything, "eth_getBalance", address.Hex(), test.blockArg).
+				Return(nil).
+				Run(func(args mock.Arguments) {
+					res := args.Get(0).(*hexutil.Big)
+					*res = hexutil.Big(*expected)
+				})
+
+			result, err := ethClient.BalanceAt(address, test.block)
+			require.NoError(t, err)
+			assert.Equal(t, expected, result)
+			ethClientMock.AssertExpectations(t)
+		})
+	}
+}
+
 func TestCallerSubscriberClient_GetERC20Balance(t *testing.T) {
 	t.Parallel()
 
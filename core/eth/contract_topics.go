@@ -0,0 +1,132 @@
+package eth
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// ContractABIVersion identifies the set of compiled contract ABIs a contract
+// was built from, e.g. the solidity v0.6 contracts.
+type ContractABIVersion string
+
+const (
+	// ContractABIVersionV4 is the version of the contracts in evm-contracts/abi/v0.4
+	ContractABIVersionV4 ContractABIVersion = "v0.4"
+	// ContractABIVersionV6 is the version of the contracts in evm-contracts/abi/v0.6
+	ContractABIVersionV6 ContractABIVersion = "v0.6"
+)
+
+// ContractEventTopics maps the names of a contract's events to their topic IDs
+type ContractEventTopics map[string]common.Hash
+
+// Topics returns the topic IDs of every event, ordered by event name, e.g. for
+// use in a log filter.
+func (t ContractEventTopics) Topics() []common.Hash {
+	names := make([]string, 0, len(t))
+	for name := range t {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	topics := make([]common.Hash, len(names))
+	for i, name := range names {
+		topics[i] = t[name]
+	}
+	return topics
+}
+
+type contractVersion struct {
+	name    string
+	version ContractABIVersion
+}
+
+// contractTopics is the registry of event topics, keyed by contract name and
+// ABI version
+var contractTopics = struct {
+	sync.RWMutex
+	topics map[contractVersion]ContractEventTopics
+}{topics: make(map[contractVersion]ContractEventTopics)}
+
+func getVersionedContractCodec(name string, version ContractABIVersion) (ContractCodec, error) {
+	switch version {
+	case ContractABIVersionV4:
+		return GetContractCodec(name)
+	case ContractABIVersionV6:
+		return GetV6ContractCodec(name)
+	default:
+		return nil, fmt.Errorf("unknown contract ABI version %s", version)
+	}
+}
+
+// RegisterContractEventTopics looks up the given events in the ABI of the
+// named contract at the given version, records their topic IDs in the
+// registry, and returns every topic registered for that contract version.
+func RegisterContractEventTopics(
+	name string,
+	version ContractABIVersion,
+	eventNames ...string,
+) (ContractEventTopics, error) {
+	codec, err := getVersionedContractCodec(name, version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to find contract %s %s", name, version)
+	}
+
+	registered := make(ContractEventTopics, len(eventNames))
+	for _, eventName := range eventNames {
+		event, found := codec.ABI().Events[eventName]
+		if !found {
+			return nil, fmt.Errorf("unable to find event %s for contract %s %s", eventName, name, version)
+		}
+		registered[eventName] = event.ID()
+	}
+
+	contractTopics.Lock()
+	defer contractTopics.Unlock()
+	key := contractVersion{name, version}
+	if contractTopics.topics[key] == nil {
+		contractTopics.topics[key] = make(ContractEventTopics)
+	}
+	for eventName, topic := range registered {
+		contractTopics.topics[key][eventName] = topic
+	}
+	return copyContractEventTopics(contractTopics.topics[key]), nil
+}
+
+// MustRegisterContractEventTopics is RegisterContractEventTopics, but panics
+// if the contract or any of the events can't be found.
+func MustRegisterContractEventTopics(
+	name string,
+	version ContractABIVersion,
+	eventNames ...string,
+) ContractEventTopics {
+	topics, err := RegisterContractEventTopics(name, version, eventNames...)
+	if err != nil {
+		logger.Panic(err)
+	}
+	return topics
+}
+
+// GetContractEventTopics returns the topics registered for the named contract
+// at the given version, and false if none have been registered.
+func GetContractEventTopics(name string, version ContractABIVersion) (ContractEventTopics, bool) {
+	contractTopics.RLock()
+	defer contractTopics.RUnlock()
+	topics, ok := contractTopics.topics[contractVersion{name, version}]
+	if !ok {
+		return nil, false
+	}
+	return copyContractEventTopics(topics), true
+}
+
+func copyContractEventTopics(topics ContractEventTopics) ContractEventTopics {
+	rv := make(ContractEventTopics, len(topics))
+	for eventName, topic := range topics {
+		rv[eventName] = topic
+	}
+	return rv
+}
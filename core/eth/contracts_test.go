@@ -85,3 +85,28 @@ func TestContractCodec_EncodeMessageCall_errors(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterContractEventTopics(t *testing.T) {
+	t.Parallel()
+
+	topics, err := RegisterContractEventTopics("Oracle", ContractABIVersionV4, "OracleRequest")
+	require.NoError(t, err)
+	oracle, err := GetContractCodec("Oracle")
+	require.NoError(t, err)
+	assert.Equal(t, oracle.ABI().Events["OracleRequest"].ID(), topics["OracleRequest"])
+
+	registered, ok := GetContractEventTopics("Oracle", ContractABIVersionV4)
+	require.True(t, ok)
+	assert.Equal(t, topics, registered)
+	assert.Equal(t, []common.Hash{topics["OracleRequest"]}, registered.Topics())
+
+	_, ok = GetContractEventTopics("Oracle", ContractABIVersionV6)
+	assert.False(t, ok)
+
+	_, err = RegisterContractEventTopics("Oracle", ContractABIVersionV4, "not-an-event")
+	assert.Error(t, err)
+	_, err = RegisterContractEventTopics("not-a-contract", ContractABIVersionV4)
+	assert.Error(t, err)
+	_, err = RegisterContractEventTopics("Oracle", "v0.0")
+	assert.Error(t, err)
+}
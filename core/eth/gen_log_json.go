@@ -5,6 +5,7 @@ package eth
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -21,8 +22,8 @@ func (l Log) MarshalJSON() ([]byte, error) {
 		BlockNumber hexutil.Uint64 `json:"blockNumber"`
 		TxHash      common.Hash    `json:"transactionHash"`
 		TxIndex     hexutil.Uint   `json:"transactionIndex"`
-		BlockHash   common.Hash    `json:"blockHash"`
-		Index       hexutil.Uint   `json:"logIndex"`
+		BlockHash   common.Hash    `json:"blockHash" gencodec:"required"`
+		Index       hexutil.Uint   `json:"logIndex" gencodec:"required"`
 		Removed     bool           `json:"removed"`
 	}
 	var enc Log
@@ -42,13 +43,13 @@ func (l Log) MarshalJSON() ([]byte, error) {
 func (l *Log) UnmarshalJSON(input []byte) error {
 	type Log struct {
 		Address     *common.Address `json:"address" gencodec:"required"`
-		Topics      []common.Hash   `json:"topics" gencodec:"required"`
+		Topics      []hexutil.Bytes `json:"topics" gencodec:"required"`
 		Data        *hexutil.Bytes  `json:"data" gencodec:"required"`
 		BlockNumber *hexutil.Uint64 `json:"blockNumber"`
 		TxHash      *common.Hash    `json:"transactionHash"`
 		TxIndex     *hexutil.Uint   `json:"transactionIndex"`
-		BlockHash   *common.Hash    `json:"blockHash"`
-		Index       *hexutil.Uint   `json:"logIndex"`
+		BlockHash   *common.Hash    `json:"blockHash" gencodec:"required"`
+		Index       *hexutil.Uint   `json:"logIndex" gencodec:"required"`
 		Removed     *bool           `json:"removed"`
 	}
 	var dec Log
@@ -62,7 +63,13 @@ func (l *Log) UnmarshalJSON(input []byte) error {
 	if dec.Topics == nil {
 		return errors.New("missing required field 'topics' for Log")
 	}
-	l.Topics = dec.Topics
+	l.Topics = make([]common.Hash, len(dec.Topics))
+	for i, topic := range dec.Topics {
+		if len(topic) != common.HashLength {
+			return fmt.Errorf("invalid topic #%d for Log: %d bytes, want %d", i, len(topic), common.HashLength)
+		}
+		l.Topics[i] = common.BytesToHash(topic)
+	}
 	if dec.Data == nil {
 		return errors.New("missing required field 'data' for Log")
 	}
@@ -76,12 +83,14 @@ func (l *Log) UnmarshalJSON(input []byte) error {
 	if dec.TxIndex != nil {
 		l.TxIndex = uint(*dec.TxIndex)
 	}
-	if dec.BlockHash != nil {
-		l.BlockHash = *dec.BlockHash
+	if dec.BlockHash == nil {
+		return errors.New("missing required field 'blockHash' for Log")
 	}
-	if dec.Index != nil {
-		l.Index = uint(*dec.Index)
+	l.BlockHash = *dec.BlockHash
+	if dec.Index == nil {
+		return errors.New("missing required field 'logIndex' for Log")
 	}
+	l.Index = uint(*dec.Index)
 	if dec.Removed != nil {
 		l.Removed = *dec.Removed
 	}
@@ -15,6 +15,9 @@ import (
 // UnpackLog is taken from the go-ethereum codebase:
 // https://github.com/ethereum/go-ethereum/blob/v1.9.11/accounts/abi/bind/base.go#L328
 func gethUnpackLog(codec *contractCodec, out interface{}, event string, log Log) error {
+	if len(log.Topics) == 0 {
+		return errors.New("log has no topics")
+	}
 	if len(log.Data) > 0 {
 		if err := codec.abi.Unpack(out, event, log.Data); err != nil {
 			return err
@@ -0,0 +1,94 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/pkg/errors"
+)
+
+// StreamLogs fetches the logs matching q in pages of at most pageSize blocks,
+// and emits them in ascending (BlockNumber, Index) order, so that a long
+// history can be processed without first holding all of it in memory.  q's
+// FromBlock defaults to the genesis block, and its ToBlock to the head when
+// StreamLogs is called.
+//
+// The logs channel is closed once every log has been emitted, or on the first
+// error, which is then sent on the error channel.  The error channel is closed
+// after the logs channel, so a caller can drain the logs and then receive
+// from it, getting nil if the stream completed.  Cancelling ctx stops the
+// stream with ctx's error.
+func StreamLogs(ctx context.Context, client Client, q ethereum.FilterQuery, pageSize uint64) (<-chan Log, <-chan error) {
+	chLogs := make(chan Log)
+	chErr := make(chan error, 1)
+	go func() {
+		defer close(chErr)
+		defer close(chLogs)
+		if err := streamLogs(ctx, client, q, pageSize, chLogs); err != nil {
+			chErr <- err
+		}
+	}()
+	return chLogs, chErr
+}
+
+func streamLogs(ctx context.Context, client Client, q ethereum.FilterQuery, pageSize uint64, chLogs chan<- Log) error {
+	if pageSize == 0 {
+		return errors.New("page size must be positive")
+	}
+	if q.BlockHash != nil {
+		return errors.New("can't stream logs by block hash")
+	}
+
+	var fromBlock, toBlock uint64
+	if q.FromBlock != nil {
+		fromBlock = q.FromBlock.Uint64()
+	}
+	if q.ToBlock != nil {
+		toBlock = q.ToBlock.Uint64()
+	} else {
+		head, err := client.GetBlockHeight()
+		if err != nil {
+			return errors.Wrap(err, "while fetching the head to stream logs up to")
+		}
+		toBlock = head
+	}
+
+	for fromBlock <= toBlock {
+		pageEnd := fromBlock + pageSize - 1
+		if pageEnd > toBlock || pageEnd < fromBlock {
+			pageEnd = toBlock // Overflow protection
+		}
+
+		page := q
+		page.FromBlock = new(big.Int).SetUint64(fromBlock)
+		page.ToBlock = new(big.Int).SetUint64(pageEnd)
+		logs, err := client.GetLogs(page)
+		if err != nil {
+			return errors.Wrapf(err, "while fetching logs from block %d to %d", fromBlock, pageEnd)
+		}
+		sort.SliceStable(logs, func(i, j int) bool {
+			if logs[i].BlockNumber != logs[j].BlockNumber {
+				return logs[i].BlockNumber < logs[j].BlockNumber
+			}
+			return logs[i].Index < logs[j].Index
+		})
+		for _, log := range logs {
+			select {
+			case chLogs <- log:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if pageEnd == toBlock {
+			break
+		}
+		fromBlock = pageEnd + 1
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
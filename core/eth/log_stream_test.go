@@ -0,0 +1,99 @@
+package eth_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamLogs_EmitsAllLogsInBlockOrder(t *testing.T) {
+	const pageSize = 100
+
+	simulatedClient := cltest.NewSimulatedEthClient()
+	addr := cltest.NewAddress()
+	var expected int
+	for block := 1; block <= 2500; block++ {
+		var logs []eth.Log
+		for i := 0; i < block%4; i++ {
+			logs = append(logs, eth.Log{Address: addr})
+		}
+		// Logs from other contracts are filtered out by the query
+		logs = append(logs, eth.Log{Address: cltest.NewAddress()})
+		expected += len(logs) - 1
+		simulatedClient.PushBlock(logs...)
+	}
+	ethClient := cltest.NewRecordingClient(simulatedClient)
+
+	q := ethereum.FilterQuery{FromBlock: big.NewInt(1), Addresses: []common.Address{addr}}
+	chLogs, chErr := eth.StreamLogs(context.Background(), ethClient, q, pageSize)
+
+	var streamed int
+	var last eth.Log
+	for log := range chLogs {
+		require.Equal(t, addr, log.Address)
+		if streamed > 0 {
+			require.True(t,
+				log.BlockNumber > last.BlockNumber ||
+					(log.BlockNumber == last.BlockNumber && log.Index > last.Index),
+				"logs must be emitted in block order")
+		}
+		last = log
+		streamed++
+	}
+	require.NoError(t, <-chErr)
+	_, open := <-chErr
+	assert.False(t, open, "error channel is closed once the stream completes")
+	assert.Equal(t, expected, streamed)
+
+	calls := ethClient.Calls("GetLogs")
+	assert.Len(t, calls, 25)
+	for _, call := range calls {
+		page := call.Args[0].(ethereum.FilterQuery)
+		assert.LessOrEqual(t, page.ToBlock.Uint64()-page.FromBlock.Uint64()+1, uint64(pageSize))
+	}
+}
+
+func TestStreamLogs_ReportsErrors(t *testing.T) {
+	ethClient := new(mocks.Client)
+	ethClient.On("GetLogs", mock.Anything).Return([]eth.Log{{BlockNumber: 1}}, nil).Once()
+	ethClient.On("GetLogs", mock.Anything).Return(nil, errors.New("node unavailable")).Once()
+
+	q := ethereum.FilterQuery{FromBlock: big.NewInt(0), ToBlock: big.NewInt(20)}
+	chLogs, chErr := eth.StreamLogs(context.Background(), ethClient, q, 10)
+
+	var streamed []eth.Log
+	for log := range chLogs {
+		streamed = append(streamed, log)
+	}
+	assert.Len(t, streamed, 1)
+	err := <-chErr
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "node unavailable")
+	ethClient.AssertExpectations(t)
+}
+
+func TestStreamLogs_StopsWhenContextIsCancelled(t *testing.T) {
+	simulatedClient := cltest.NewSimulatedEthClient()
+	for block := 0; block < 10; block++ {
+		simulatedClient.PushBlock(eth.Log{})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	chLogs, chErr := eth.StreamLogs(ctx, simulatedClient, ethereum.FilterQuery{}, 1)
+	<-chLogs
+	cancel()
+	for range chLogs {
+	}
+	assert.Equal(t, context.Canceled, <-chErr)
+}
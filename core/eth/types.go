@@ -45,9 +45,11 @@ type Log struct {
 	// index of the transaction in the block
 	TxIndex uint `json:"transactionIndex"`
 	// hash of the block in which the transaction was included
-	BlockHash common.Hash `json:"blockHash"`
-	// index of the log in the receipt
-	Index uint `json:"logIndex"`
+	BlockHash common.Hash `json:"blockHash" gencodec:"required"`
+	// index of the log in the block.  It's required, as the zero value
+	// couldn't tell a log at index 0 from one whose index is missing, and
+	// logs are told apart by their block hash and index.
+	Index uint `json:"logIndex" gencodec:"required"`
 
 	// The Removed field is true if this log was reverted due to a chain reorganisation.
 	// You must pay attention to this field if you receive logs through a filter query.
@@ -80,6 +82,39 @@ func (log Log) GetTopic(idx uint) (common.Hash, error) {
 	return log.Topics[idx], nil
 }
 
+// TopicAsAddress returns the topic at the passed index, decoded as an indexed
+// address event argument, or an error if there is no such topic or it is not
+// a left-padded address.
+func (log Log) TopicAsAddress(idx int) (common.Address, error) {
+	topic, err := log.topic(idx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	for _, b := range topic[:common.HashLength-common.AddressLength] {
+		if b != 0 {
+			return common.Address{}, fmt.Errorf("Log: topic #%v is not an address: %x", idx, topic)
+		}
+	}
+	return common.BytesToAddress(topic.Bytes()), nil
+}
+
+// TopicAsBigInt returns the topic at the passed index, decoded as an indexed
+// uint256 event argument, or an error if there is no such topic.
+func (log Log) TopicAsBigInt(idx int) (*big.Int, error) {
+	topic, err := log.topic(idx)
+	if err != nil {
+		return nil, err
+	}
+	return topic.Big(), nil
+}
+
+func (log Log) topic(idx int) (common.Hash, error) {
+	if idx < 0 {
+		return common.Hash{}, fmt.Errorf("Log: Unable to get topic #%v for %v", idx, log)
+	}
+	return log.GetTopic(uint(idx))
+}
+
 // Copy creates a deep copy of a log.  The LogBroadcaster creates a single websocket
 // subscription for all log events that we're interested in and distributes them to
 // the relevant subscribers elsewhere in the codebase.  If a given log needs to be
@@ -111,7 +146,9 @@ func (log Log) Copy() Log {
 // generated by the above "//go:generate gencodec" command, which is currently
 // broken. (It seems as though the problem might be that gencodec doesn't work
 // with modules-based packages, in which case it could probably be run outside
-// chainlink. https://github.com/fjl/gencodec/issues/10)
+// chainlink. https://github.com/fjl/gencodec/issues/10)  Its UnmarshalJSON also
+// checks that each topic is a 32-byte hash, reporting which one isn't, which
+// must be kept if it's regenerated.
 type logMarshaling struct {
 	Data        hexutil.Bytes
 	BlockNumber hexutil.Uint64
@@ -170,6 +207,9 @@ type TxReceipt struct {
 	BlockHash   *common.Hash `json:"blockHash"`
 	Hash        common.Hash  `json:"transactionHash"`
 	Logs        []Log        `json:"logs"`
+	// Status is 1 if the transaction succeeded and 0 if it reverted.  It is nil
+	// for receipts from before the Byzantium fork, which don't report it.
+	Status *hexutil.Uint64 `json:"status,omitempty"`
 }
 
 // Unconfirmed returns true if the transaction is not confirmed.
@@ -177,6 +217,46 @@ func (txr *TxReceipt) Unconfirmed() bool {
 	return txr.Hash == emptyHash || txr.BlockNumber == nil
 }
 
+// Reverted returns true if the receipt reports that the transaction failed
+func (txr *TxReceipt) Reverted() bool {
+	return txr.Status != nil && *txr.Status == 0
+}
+
+// FindLogs returns the receipt's logs for the named event of the contract
+// described by codec, in the order they were emitted
+func (txr *TxReceipt) FindLogs(codec ContractCodec, eventName string) ([]Log, error) {
+	event, found := codec.ABI().Events[eventName]
+	if !found {
+		return nil, fmt.Errorf("unable to find event %s in contract ABI", eventName)
+	}
+	var logs []Log
+	for _, log := range txr.Logs {
+		if len(log.Topics) > 0 && log.Topics[0] == event.ID() {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+// UnpackLogs decodes the receipt's logs for the named event of the contract
+// described by codec into outs, in the order they were emitted.  There must be
+// exactly one out for each such log.
+func (txr *TxReceipt) UnpackLogs(codec ContractCodec, eventName string, outs ...interface{}) error {
+	logs, err := txr.FindLogs(codec, eventName)
+	if err != nil {
+		return err
+	}
+	if len(logs) != len(outs) {
+		return fmt.Errorf("receipt has %d %s logs, but %d were expected", len(logs), eventName, len(outs))
+	}
+	for i, log := range logs {
+		if err := codec.UnpackLog(outs[i], eventName, log); err != nil {
+			return fmt.Errorf("unable to unpack %s log %d: %v", eventName, i, err)
+		}
+	}
+	return nil
+}
+
 // ChainlinkFulfilledTopic is the signature for the event emitted after calling
 // ChainlinkClient.validateChainlinkCallback(requestId). See
 // ../../evm-contracts/src/v0.6/ChainlinkClient.sol
@@ -2,11 +2,15 @@ package eth_test
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/core/eth"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,6 +37,104 @@ func TestLog_UnmarshalEmptyTxHash(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestLog_UnmarshalJSON_RejectsMalformedLogs(t *testing.T) {
+	t.Parallel()
+
+	topic := `"0xc3c45d1924f55369653f407ee9f095309d1e687b2c0011b1f709042d4f457e17"`
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"missing address",
+			`{"topics": [` + topic + `], "data": "0x", "blockHash": ` + topic + `}`,
+			"missing required field 'address'"},
+		{"missing block hash",
+			`{"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "topics": [` + topic + `], "data": "0x"}`,
+			"missing required field 'blockHash'"},
+		{"null block hash",
+			`{"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "topics": [], "data": "0x", "blockHash": null}`,
+			"missing required field 'blockHash'"},
+		{"missing topics",
+			`{"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "data": "0x", "blockHash": ` + topic + `}`,
+			"missing required field 'topics'"},
+		{"missing log index",
+			`{"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "topics": [], "data": "0x", "blockHash": ` + topic + `}`,
+			"missing required field 'logIndex'"},
+		{"null log index",
+			`{"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "topics": [], "data": "0x", "blockHash": ` + topic + `, "logIndex": null}`,
+			"missing required field 'logIndex'"},
+		{"long topic",
+			`{"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "topics": [` + topic + `, "0x` + strings.Repeat("00", 33) + `"], "data": "0x", "blockHash": ` + topic + `}`,
+			"invalid topic #1 for Log: 33 bytes, want 32"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var log eth.Log
+			err := json.Unmarshal([]byte(test.input), &log)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.want)
+		})
+	}
+
+	t.Run("fixture with a short topic", func(t *testing.T) {
+		input, err := ioutil.ReadFile("testdata/malformedTopicLog.json")
+		require.NoError(t, err)
+		var log eth.Log
+		err = json.Unmarshal(input, &log)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid topic #2 for Log: 31 bytes, want 32")
+	})
+}
+
+func TestLog_UnmarshalJSON_LogIndexZero(t *testing.T) {
+	t.Parallel()
+
+	input := `{
+		"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42",
+		"topics": ["0xc3c45d1924f55369653f407ee9f095309d1e687b2c0011b1f709042d4f457e17"],
+		"data": "0x",
+		"blockHash": "0xdb777676330c067e3c3a6dbfc2d51282cac5bcc1b7a884dd8d85ba72ca1f147e",
+		"logIndex": "0x0"
+	}`
+
+	var log eth.Log
+	require.NoError(t, json.Unmarshal([]byte(input), &log))
+	assert.Equal(t, uint(0), log.Index)
+}
+
+func TestLog_RoundTripsJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, index := range []uint{0, 1, 255} {
+		cltest.AssertLogRoundTripsJSON(t, eth.Log{
+			Address:     cltest.NewAddress(),
+			Topics:      []common.Hash{cltest.NewHash()},
+			Data:        eth.UntrustedBytes{0xde, 0xad},
+			BlockNumber: 1,
+			TxHash:      cltest.NewHash(),
+			TxIndex:     2,
+			BlockHash:   cltest.NewHash(),
+			Index:       index,
+		})
+	}
+}
+
+func TestContractCodec_UnpackLog_RejectsLogWithoutTopics(t *testing.T) {
+	t.Parallel()
+
+	codec, err := eth.GetV6ContractCodec("FluxAggregator")
+	require.NoError(t, err)
+	var newRound struct {
+		RoundId   *big.Int
+		StartedBy common.Address
+		StartedAt *big.Int
+	}
+	assert.Error(t, codec.UnpackLog(&newRound, "NewRound", eth.Log{}))
+}
+
 func TestReceipt_UnmarshalEmptyBlockHash(t *testing.T) {
 	t.Parallel()
 
@@ -47,6 +149,34 @@ func TestReceipt_UnmarshalEmptyBlockHash(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestReceipt_FindLogsAndUnpackLogs(t *testing.T) {
+	t.Parallel()
+
+	receipt := cltest.TxReceiptFromFixture(t, "testdata/fluxAggregatorSubmitReceipt.json")
+	codec, err := eth.GetV6ContractCodec("FluxAggregator")
+	require.NoError(t, err)
+
+	logs, err := receipt.FindLogs(codec, "NewRound")
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, uint(0), logs[0].Index)
+	assert.Equal(t, codec.ABI().Events["NewRound"].ID(), logs[0].Topics[0])
+
+	var newRound struct {
+		RoundId   *big.Int
+		StartedBy common.Address
+		StartedAt *big.Int
+	}
+	require.NoError(t, receipt.UnpackLogs(codec, "NewRound", &newRound))
+	assert.Equal(t, big.NewInt(1), newRound.RoundId)
+	assert.Equal(t, common.HexToAddress("0xf17f52151ebef6c7334fad080c5704d77216b732"), newRound.StartedBy)
+	assert.Equal(t, big.NewInt(15), newRound.StartedAt)
+
+	assert.Error(t, receipt.UnpackLogs(codec, "NewRound"))
+	_, err = receipt.FindLogs(codec, "NoSuchEvent")
+	assert.Error(t, err)
+}
+
 func TestModels_HexToFunctionSelector(t *testing.T) {
 	t.Parallel()
 	fid := eth.HexToFunctionSelector("0xb3f98adc")
@@ -228,3 +358,31 @@ func TestBlock_Unmarshal(t *testing.T) {
 
 	assert.Len(t, block.Transactions, 2)
 }
+
+func TestLog_TopicAsAddressAndBigInt(t *testing.T) {
+	t.Parallel()
+
+	log := cltest.LogFromFixture(t, "../services/testdata/new_round_log.json")
+
+	roundID, err := log.TopicAsBigInt(1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), roundID.Int64())
+
+	startedBy, err := log.TopicAsAddress(2)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress("0xf17f52151ebef6c7334fad080c5704d77216b732"), startedBy)
+
+	// The event ID is a full 32-byte hash, so can't be an address
+	_, err = log.TopicAsAddress(0)
+	assert.Error(t, err)
+	eventID, err := log.TopicAsBigInt(0)
+	require.NoError(t, err)
+	assert.Equal(t, log.Topics[0].Big(), eventID)
+
+	for _, idx := range []int{-1, 3, 100} {
+		_, err = log.TopicAsAddress(idx)
+		assert.Error(t, err, "index %d", idx)
+		_, err = log.TopicAsBigInt(idx)
+		assert.Error(t, err, "index %d", idx)
+	}
+}
@@ -1049,6 +1049,19 @@ func NormalizedJSON(t testing.TB, input []byte) string {
 	return normalized
 }
 
+// AssertLogRoundTripsJSON asserts that log is unchanged by marshaling it to
+// JSON and back, as it is by a round trip through the JSON-RPC API.  Its
+// Topics and Data must be non-nil, as an unmarshaled log's always are.
+func AssertLogRoundTripsJSON(t testing.TB, log eth.Log) {
+	t.Helper()
+
+	b, err := json.Marshal(log)
+	require.NoError(t, err)
+	var decoded eth.Log
+	require.NoError(t, json.Unmarshal(b, &decoded), "unmarshaling %s", b)
+	assert.Equal(t, log, decoded)
+}
+
 func AssertError(t testing.TB, want bool, err error) {
 	t.Helper()
 
@@ -1206,3 +1219,15 @@ func MakeRoundStateReturnData(
 	data = append(data, utils.EVMWordUint64(paymentAmount)...)
 	return hexutil.Encode(data)
 }
+
+// MakeGetOraclesReturnData returns the ABI encoding of the given addresses, as
+// returned by FluxAggregator.getOracles()
+func MakeGetOraclesReturnData(oracles ...common.Address) string {
+	var data []byte
+	data = append(data, utils.EVMWordUint64(32)...)
+	data = append(data, utils.EVMWordUint64(uint64(len(oracles)))...)
+	for _, oracle := range oracles {
+		data = append(data, common.LeftPadBytes(oracle.Bytes(), 32)...)
+	}
+	return hexutil.Encode(data)
+}
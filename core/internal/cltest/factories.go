@@ -7,10 +7,13 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"hash/fnv"
 	"math/big"
+	mathrand "math/rand"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -311,9 +314,63 @@ func NewAddress() common.Address {
 	return common.BytesToAddress(randomBytes(20))
 }
 
+// NewHashSeeded returns a Keccak256-sized hash derived deterministically from
+// seed
+func NewHashSeeded(seed int64) common.Hash {
+	return common.BytesToHash(seededBytes(seed, 32))
+}
+
+// NewAddressSeeded returns an address derived deterministically from seed
+func NewAddressSeeded(seed int64) common.Address {
+	return common.BytesToAddress(seededBytes(seed, 20))
+}
+
+var (
+	testRandMutex sync.Mutex
+	testRand      *mathrand.Rand
+)
+
+// SeedTestRandomness makes NewHash and NewAddress draw from a pseudo-random
+// source seeded from the name of the test, so that the values a test
+// generates are the same on every run. The seed is logged, and the returned
+// function restores the random defaults:
+//
+//	defer cltest.SeedTestRandomness(t)()
+//
+// The source is shared by the whole package, so the sequence is only
+// reproducible for tests which don't run in parallel.
+func SeedTestRandomness(t testing.TB) func() {
+	t.Helper()
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(t.Name()))
+	seed := int64(h.Sum64())
+	t.Logf("cltest: seeding NewHash and NewAddress with %d", seed)
+
+	testRandMutex.Lock()
+	defer testRandMutex.Unlock()
+	testRand = mathrand.New(mathrand.NewSource(seed))
+	return func() {
+		testRandMutex.Lock()
+		defer testRandMutex.Unlock()
+		testRand = nil
+	}
+}
+
 func randomBytes(n int) []byte {
 	b := make([]byte, n)
-	rand.Read(b)
+	testRandMutex.Lock()
+	defer testRandMutex.Unlock()
+	if testRand != nil {
+		testRand.Read(b)
+	} else {
+		rand.Read(b)
+	}
+	return b
+}
+
+func seededBytes(seed int64, n int) []byte {
+	b := make([]byte, n)
+	mathrand.New(mathrand.NewSource(seed)).Read(b)
 	return b
 }
 
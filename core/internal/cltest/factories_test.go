@@ -15,3 +15,23 @@ func TestBigHexInt(t *testing.T) {
 	newBig := BigHexInt(x)
 	assert.Equal(t, (*big.Int)(&newBig).Uint64(), x)
 }
+
+func TestNewAddressSeeded_Deterministic(t *testing.T) {
+	assert.Equal(t, NewAddressSeeded(42), NewAddressSeeded(42))
+	assert.Equal(t, NewHashSeeded(42), NewHashSeeded(42))
+	assert.NotEqual(t, NewAddressSeeded(42), NewAddressSeeded(43))
+	assert.NotEqual(t, NewHashSeeded(42), NewHashSeeded(43))
+}
+
+func TestSeedTestRandomness(t *testing.T) {
+	restore := SeedTestRandomness(t)
+	address, hash := NewAddress(), NewHash()
+	restore()
+
+	restore = SeedTestRandomness(t)
+	assert.Equal(t, address, NewAddress())
+	assert.Equal(t, hash, NewHash())
+	restore()
+
+	assert.NotEqual(t, address, NewAddress())
+}
@@ -27,13 +27,27 @@ func JSONFromFixture(t *testing.T, path string) models.JSON {
 
 // JSONResultFromFixture create model.JSON with params.result found in the given file path
 func JSONResultFromFixture(t *testing.T, path string) models.JSON {
-	res := gjson.Get(string(MustReadFile(t, path)), "params.result")
+	return JSONResultFromFixtureAtPath(t, path, "params.result")
+}
+
+// JSONResultFromFixtureAtPath create model.JSON with the value at the gjson
+// path jsonPath in the given file path
+func JSONResultFromFixtureAtPath(t *testing.T, path, jsonPath string) models.JSON {
+	res := gjson.Get(string(MustReadFile(t, path)), jsonPath)
+	require.True(t, res.Exists(), "no value at %s in fixture %s", jsonPath, path)
 	return JSONFromString(t, res.String())
 }
 
 // LogFromFixture create ethtypes.log from file path
 func LogFromFixture(t *testing.T, path string) eth.Log {
-	value := gjson.Get(string(MustReadFile(t, path)), "params.result")
+	return LogFromFixtureAtPath(t, path, "params.result")
+}
+
+// LogFromFixtureAtPath create ethtypes.log from the value at the gjson path
+// jsonPath in the given file path, e.g. "result.0" for an eth_getLogs response
+func LogFromFixtureAtPath(t *testing.T, path, jsonPath string) eth.Log {
+	value := gjson.Get(string(MustReadFile(t, path)), jsonPath)
+	require.True(t, value.Exists(), "no value at %s in fixture %s", jsonPath, path)
 	var el eth.Log
 	require.NoError(t, json.Unmarshal([]byte(value.String()), &el))
 
@@ -42,10 +56,17 @@ func LogFromFixture(t *testing.T, path string) eth.Log {
 
 // TxReceiptFromFixture create ethtypes.log from file path
 func TxReceiptFromFixture(t *testing.T, path string) eth.TxReceipt {
-	jsonStr := JSONFromFixture(t, path).Get("result").String()
+	return TxReceiptFromFixtureAtPath(t, path, "result")
+}
+
+// TxReceiptFromFixtureAtPath create eth.TxReceipt from the value at the gjson
+// path jsonPath in the given file path
+func TxReceiptFromFixtureAtPath(t *testing.T, path, jsonPath string) eth.TxReceipt {
+	value := JSONFromFixture(t, path).Get(jsonPath)
+	require.True(t, value.Exists(), "no value at %s in fixture %s", jsonPath, path)
 
 	var receipt eth.TxReceipt
-	err := json.Unmarshal([]byte(jsonStr), &receipt)
+	err := json.Unmarshal([]byte(value.String()), &receipt)
 	require.NoError(t, err)
 
 	return receipt
@@ -0,0 +1,26 @@
+package cltest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogFromFixtureAtPath(t *testing.T) {
+	const fixture = "../../eth/testdata/runlogReceipt.json"
+	receipt := TxReceiptFromFixture(t, fixture)
+
+	log := LogFromFixtureAtPath(t, fixture, "result.logs.0")
+	assert.Equal(t, receipt.Logs[0], log)
+	assert.Equal(t, uint64(8), log.BlockNumber)
+}
+
+func TestFixtureLoaders_DefaultPaths(t *testing.T) {
+	const fixture = "../../services/testdata/new_round_log.json"
+
+	assert.Equal(t, LogFromFixtureAtPath(t, fixture, "params.result"), LogFromFixture(t, fixture))
+	assert.Equal(t,
+		JSONResultFromFixtureAtPath(t, fixture, "params.result"),
+		JSONResultFromFixture(t, fixture))
+	assert.Equal(t, "0xa", JSONResultFromFixtureAtPath(t, fixture, "params").Get("result.blockNumber").String())
+}
@@ -275,6 +275,14 @@ func channelFromSubscriptionName(name string) interface{} {
 	}
 }
 
+// CallContext is Call, but fails with ctx's error if ctx is already done
+func (mock *EthMock) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mock.Call(result, method, args...)
+}
+
 // Subscribe registers a subscription to the channel
 func (mock *EthMock) Subscribe(
 	ctx context.Context,
@@ -0,0 +1,163 @@
+package cltest
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/eth"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// RecordedCall is a single invocation of an eth.Client method, as captured by
+// a RecordingClient
+type RecordedCall struct {
+	Method string
+	Args   []interface{}
+}
+
+// RecordingClient wraps an eth.Client, forwarding every call to it and
+// recording a timeline of the methods invoked and their arguments, so that
+// tests can make assertions about them after the fact.
+type RecordingClient struct {
+	client eth.Client
+	mutex  sync.Mutex
+	calls  []RecordedCall
+}
+
+var _ eth.Client = (*RecordingClient)(nil)
+
+// NewRecordingClient returns a RecordingClient wrapping the given client
+func NewRecordingClient(client eth.Client) *RecordingClient {
+	return &RecordingClient{client: client}
+}
+
+func (c *RecordingClient) record(method string, args ...interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls = append(c.calls, RecordedCall{Method: method, Args: args})
+}
+
+// AllCalls returns every recorded call, in the order they were made
+func (c *RecordingClient) AllCalls() []RecordedCall {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]RecordedCall{}, c.calls...)
+}
+
+// Calls returns the recorded calls to the given method, in the order they
+// were made
+func (c *RecordingClient) Calls(method string) []RecordedCall {
+	var calls []RecordedCall
+	for _, call := range c.AllCalls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Methods returns the names of the methods called, in the order they were made
+func (c *RecordingClient) Methods() []string {
+	var methods []string
+	for _, call := range c.AllCalls() {
+		methods = append(methods, call.Method)
+	}
+	return methods
+}
+
+// Reset discards all recorded calls
+func (c *RecordingClient) Reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls = nil
+}
+
+func (c *RecordingClient) Call(result interface{}, method string, args ...interface{}) error {
+	c.record("Call", append([]interface{}{method}, args...)...)
+	return c.client.Call(result, method, args...)
+}
+
+func (c *RecordingClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	c.record("CallContext", append([]interface{}{method}, args...)...)
+	return c.client.CallContext(ctx, result, method, args...)
+}
+
+func (c *RecordingClient) Subscribe(ctx context.Context, channel interface{}, args ...interface{}) (eth.Subscription, error) {
+	c.record("Subscribe", args...)
+	return c.client.Subscribe(ctx, channel, args...)
+}
+
+func (c *RecordingClient) GetLogs(q ethereum.FilterQuery) ([]eth.Log, error) {
+	c.record("GetLogs", q)
+	return c.client.GetLogs(q)
+}
+
+func (c *RecordingClient) SubscribeToLogs(ctx context.Context, channel chan<- eth.Log, q ethereum.FilterQuery) (eth.Subscription, error) {
+	c.record("SubscribeToLogs", q)
+	return c.client.SubscribeToLogs(ctx, channel, q)
+}
+
+func (c *RecordingClient) GetNonce(address common.Address) (uint64, error) {
+	c.record("GetNonce", address)
+	return c.client.GetNonce(address)
+}
+
+func (c *RecordingClient) GetEthBalance(address common.Address) (*assets.Eth, error) {
+	c.record("GetEthBalance", address)
+	return c.client.GetEthBalance(address)
+}
+
+func (c *RecordingClient) BalanceAt(address common.Address, block *big.Int) (*big.Int, error) {
+	c.record("BalanceAt", address, block)
+	return c.client.BalanceAt(address, block)
+}
+
+func (c *RecordingClient) GetERC20Balance(address common.Address, contractAddress common.Address) (*big.Int, error) {
+	c.record("GetERC20Balance", address, contractAddress)
+	return c.client.GetERC20Balance(address, contractAddress)
+}
+
+func (c *RecordingClient) SendRawTx(bytes []byte) (common.Hash, error) {
+	c.record("SendRawTx", bytes)
+	return c.client.SendRawTx(bytes)
+}
+
+func (c *RecordingClient) SendRawTransaction(tx *types.Transaction) (common.Hash, error) {
+	c.record("SendRawTransaction", tx)
+	return c.client.SendRawTransaction(tx)
+}
+
+func (c *RecordingClient) GetTxReceipt(hash common.Hash) (*eth.TxReceipt, error) {
+	c.record("GetTxReceipt", hash)
+	return c.client.GetTxReceipt(hash)
+}
+
+func (c *RecordingClient) GetBlockHeight() (uint64, error) {
+	c.record("GetBlockHeight")
+	return c.client.GetBlockHeight()
+}
+
+func (c *RecordingClient) GetLatestBlock() (eth.Block, error) {
+	c.record("GetLatestBlock")
+	return c.client.GetLatestBlock()
+}
+
+func (c *RecordingClient) GetBlockByNumber(hex string) (eth.Block, error) {
+	c.record("GetBlockByNumber", hex)
+	return c.client.GetBlockByNumber(hex)
+}
+
+func (c *RecordingClient) GetChainID() (*big.Int, error) {
+	c.record("GetChainID")
+	return c.client.GetChainID()
+}
+
+func (c *RecordingClient) SubscribeToNewHeads(ctx context.Context, channel chan<- eth.BlockHeader) (eth.Subscription, error) {
+	c.record("SubscribeToNewHeads")
+	return c.client.SubscribeToNewHeads(ctx, channel)
+}
@@ -0,0 +1,392 @@
+package cltest
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// ErrNotSimulated is returned by the SimulatedEthClient methods which don't
+// model any chain state
+var ErrNotSimulated = errors.New("not supported by SimulatedEthClient")
+
+// SimulatedEthClient is an in-memory eth.Client backed by a simulated chain.
+// Tests push blocks of logs onto the chain, and the client serves them through
+// GetLatestBlock, GetLogs, SubscribeToLogs and SubscribeToNewHeads, honoring
+// the filter queries in the same way as an Ethereum node.  Methods unrelated
+// to blocks and logs return ErrNotSimulated.
+type SimulatedEthClient struct {
+	mutex             sync.Mutex
+	height            uint64
+	reorgs            uint64
+	logs              []eth.Log
+	subscriptions     map[*simulatedLogSubscription]struct{}
+	headSubscriptions map[*simulatedHeadSubscription]struct{}
+}
+
+var _ eth.Client = (*SimulatedEthClient)(nil)
+
+// NewSimulatedEthClient returns a SimulatedEthClient whose chain starts with
+// the genesis block, at height 0
+func NewSimulatedEthClient() *SimulatedEthClient {
+	return &SimulatedEthClient{
+		subscriptions:     make(map[*simulatedLogSubscription]struct{}),
+		headSubscriptions: make(map[*simulatedHeadSubscription]struct{}),
+	}
+}
+
+// Height returns the number of the latest block
+func (c *SimulatedEthClient) Height() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.height
+}
+
+// PushBlock mines a new block containing the given logs, and returns its
+// number.  The logs' BlockNumber, BlockHash and Index fields are overwritten.
+// Matching logs are delivered to the log subscriptions, and then the new head
+// to the head subscriptions, before PushBlock returns.
+func (c *SimulatedEthClient) PushBlock(logs ...eth.Log) uint64 {
+	c.mutex.Lock()
+	c.height++
+	blockHash := c.blockHash(c.height)
+	var mined []eth.Log
+	for idx, log := range logs {
+		log = log.Copy()
+		log.BlockNumber = c.height
+		log.BlockHash = blockHash
+		log.Index = uint(idx)
+		log.Removed = false
+		mined = append(mined, log)
+	}
+	c.logs = append(c.logs, mined...)
+	height := c.height
+	subscriptions := c.subscriptionsLocked()
+	headSubscriptions := c.headSubscriptionsLocked()
+	c.mutex.Unlock()
+
+	deliver(subscriptions, mined)
+	head := eth.BlockHeader{Number: hexutil.Big(*new(big.Int).SetUint64(height)), ParityHash: blockHash}
+	for _, sub := range headSubscriptions {
+		sub.send(head)
+	}
+	return height
+}
+
+// Reorg removes the latest depth blocks from the chain.  Their logs are
+// delivered to the subscriptions again with Removed set, as an Ethereum node
+// does when they are reorged out.  Blocks pushed afterwards have different
+// hashes from the ones they replace.
+func (c *SimulatedEthClient) Reorg(depth uint64) {
+	c.mutex.Lock()
+	if depth > c.height {
+		depth = c.height
+	}
+	c.height -= depth
+	c.reorgs++
+	var kept, removed []eth.Log
+	for _, log := range c.logs {
+		if log.BlockNumber > c.height {
+			log.Removed = true
+			removed = append(removed, log)
+		} else {
+			kept = append(kept, log)
+		}
+	}
+	c.logs = kept
+	subscriptions := c.subscriptionsLocked()
+	c.mutex.Unlock()
+
+	deliver(subscriptions, removed)
+}
+
+// LogSubscriptionCount returns the number of active log subscriptions
+func (c *SimulatedEthClient) LogSubscriptionCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.subscriptions)
+}
+
+// HeadSubscriptionCount returns the number of active new heads subscriptions
+func (c *SimulatedEthClient) HeadSubscriptionCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.headSubscriptions)
+}
+
+func (c *SimulatedEthClient) blockHash(number uint64) common.Hash {
+	return utils.MustHash(fmt.Sprintf("simulated block %d, reorg %d", number, c.reorgs))
+}
+
+func (c *SimulatedEthClient) subscriptionsLocked() []*simulatedLogSubscription {
+	var subscriptions []*simulatedLogSubscription
+	for sub := range c.subscriptions {
+		subscriptions = append(subscriptions, sub)
+	}
+	return subscriptions
+}
+
+func (c *SimulatedEthClient) headSubscriptionsLocked() []*simulatedHeadSubscription {
+	var subscriptions []*simulatedHeadSubscription
+	for sub := range c.headSubscriptions {
+		subscriptions = append(subscriptions, sub)
+	}
+	return subscriptions
+}
+
+func deliver(subscriptions []*simulatedLogSubscription, logs []eth.Log) {
+	for _, sub := range subscriptions {
+		for _, log := range logs {
+			if logMatchesQuery(log, sub.query) {
+				sub.send(log)
+			}
+		}
+	}
+}
+
+// logMatchesQuery is true iff log satisfies q's address and topic filters.
+// Block ranges are ignored, as subscriptions only deliver new logs.
+func logMatchesQuery(log eth.Log, q ethereum.FilterQuery) bool {
+	if len(q.Addresses) > 0 {
+		var found bool
+		for _, address := range q.Addresses {
+			if address == log.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(q.Topics) > len(log.Topics) {
+		return false
+	}
+	for idx, alternatives := range q.Topics {
+		if len(alternatives) == 0 {
+			continue // Wildcard
+		}
+		var found bool
+		for _, topic := range alternatives {
+			if topic == log.Topics[idx] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// GetLogs returns the logs on the simulated chain which satisfy q.  A nil
+// FromBlock or ToBlock means the genesis or latest block, respectively.
+func (c *SimulatedEthClient) GetLogs(q ethereum.FilterQuery) ([]eth.Log, error) {
+	if q.BlockHash != nil {
+		return nil, errors.Wrap(ErrNotSimulated, "filtering by block hash")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	fromBlock, toBlock := uint64(0), c.height
+	if q.FromBlock != nil {
+		fromBlock = q.FromBlock.Uint64()
+	}
+	if q.ToBlock != nil {
+		toBlock = q.ToBlock.Uint64()
+	}
+
+	logs := []eth.Log{}
+	for _, log := range c.logs {
+		if log.BlockNumber >= fromBlock && log.BlockNumber <= toBlock && logMatchesQuery(log, q) {
+			logs = append(logs, log.Copy())
+		}
+	}
+	return logs, nil
+}
+
+// SubscribeToLogs delivers each subsequently pushed log which satisfies q to
+// channel, until the subscription is unsubscribed
+func (c *SimulatedEthClient) SubscribeToLogs(ctx context.Context, channel chan<- eth.Log, q ethereum.FilterQuery) (eth.Subscription, error) {
+	sub := &simulatedLogSubscription{channel: channel, query: q}
+	sub.simulatedSubscription = newSimulatedSubscription(func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		delete(c.subscriptions, sub)
+	})
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.subscriptions[sub] = struct{}{}
+	return sub, nil
+}
+
+func (c *SimulatedEthClient) GetLatestBlock() (eth.Block, error) {
+	return eth.Block{Number: hexutil.Uint64(c.Height())}, nil
+}
+
+func (c *SimulatedEthClient) GetBlockHeight() (uint64, error) {
+	return c.Height(), nil
+}
+
+func (c *SimulatedEthClient) GetBlockByNumber(hex string) (eth.Block, error) {
+	number, err := hexutil.DecodeUint64(hex)
+	if err != nil {
+		return eth.Block{}, err
+	}
+	if number > c.Height() {
+		return eth.Block{}, fmt.Errorf("block %d is beyond the latest block", number)
+	}
+	return eth.Block{Number: hexutil.Uint64(number)}, nil
+}
+
+func (c *SimulatedEthClient) Call(result interface{}, method string, args ...interface{}) error {
+	return errors.Wrap(ErrNotSimulated, method)
+}
+
+func (c *SimulatedEthClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return errors.Wrap(ErrNotSimulated, method)
+}
+
+func (c *SimulatedEthClient) Subscribe(ctx context.Context, channel interface{}, args ...interface{}) (eth.Subscription, error) {
+	return nil, errors.Wrap(ErrNotSimulated, "Subscribe")
+}
+
+func (c *SimulatedEthClient) GetNonce(address common.Address) (uint64, error) {
+	return 0, errors.Wrap(ErrNotSimulated, "GetNonce")
+}
+
+func (c *SimulatedEthClient) GetEthBalance(address common.Address) (*assets.Eth, error) {
+	return nil, errors.Wrap(ErrNotSimulated, "GetEthBalance")
+}
+
+func (c *SimulatedEthClient) BalanceAt(address common.Address, block *big.Int) (*big.Int, error) {
+	return nil, errors.Wrap(ErrNotSimulated, "BalanceAt")
+}
+
+func (c *SimulatedEthClient) GetERC20Balance(address common.Address, contractAddress common.Address) (*big.Int, error) {
+	return nil, errors.Wrap(ErrNotSimulated, "GetERC20Balance")
+}
+
+func (c *SimulatedEthClient) SendRawTx(bytes []byte) (common.Hash, error) {
+	return common.Hash{}, errors.Wrap(ErrNotSimulated, "SendRawTx")
+}
+
+func (c *SimulatedEthClient) SendRawTransaction(tx *types.Transaction) (common.Hash, error) {
+	return common.Hash{}, errors.Wrap(ErrNotSimulated, "SendRawTransaction")
+}
+
+func (c *SimulatedEthClient) GetTxReceipt(hash common.Hash) (*eth.TxReceipt, error) {
+	return nil, errors.Wrap(ErrNotSimulated, "GetTxReceipt")
+}
+
+func (c *SimulatedEthClient) GetChainID() (*big.Int, error) {
+	return nil, errors.Wrap(ErrNotSimulated, "GetChainID")
+}
+
+// SubscribeToNewHeads delivers the header of each subsequently pushed block to
+// channel, until the subscription is unsubscribed.  Only the Number and
+// ParityHash fields are set.
+func (c *SimulatedEthClient) SubscribeToNewHeads(ctx context.Context, channel chan<- eth.BlockHeader) (eth.Subscription, error) {
+	sub := &simulatedHeadSubscription{channel: channel}
+	sub.simulatedSubscription = newSimulatedSubscription(func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		delete(c.headSubscriptions, sub)
+	})
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.headSubscriptions[sub] = struct{}{}
+	return sub, nil
+}
+
+// simulatedSubscription implements eth.Subscription for the simulated
+// subscriptions.  onUnsubscribe removes the subscription from the client.
+type simulatedSubscription struct {
+	onUnsubscribe  func()
+	chErr          chan error
+	chUnsubscribed chan struct{}
+	unsubscribe    sync.Once
+
+	// sendMutex ensures no send is in progress once Unsubscribe returns, as
+	// subscribers may close the channel at that point
+	sendMutex    sync.Mutex
+	unsubscribed bool
+}
+
+func newSimulatedSubscription(onUnsubscribe func()) simulatedSubscription {
+	return simulatedSubscription{
+		onUnsubscribe:  onUnsubscribe,
+		chErr:          make(chan error),
+		chUnsubscribed: make(chan struct{}),
+	}
+}
+
+// send calls deliver unless the subscription is unsubscribed.  deliver must
+// block until its value is received or chUnsubscribed is closed.
+func (sub *simulatedSubscription) send(deliver func()) {
+	sub.sendMutex.Lock()
+	defer sub.sendMutex.Unlock()
+	if sub.unsubscribed {
+		return
+	}
+	deliver()
+}
+
+func (sub *simulatedSubscription) Err() <-chan error {
+	return sub.chErr
+}
+
+func (sub *simulatedSubscription) Unsubscribe() {
+	sub.unsubscribe.Do(func() {
+		sub.onUnsubscribe()
+
+		close(sub.chUnsubscribed)
+		sub.sendMutex.Lock()
+		sub.unsubscribed = true
+		sub.sendMutex.Unlock()
+	})
+}
+
+type simulatedLogSubscription struct {
+	simulatedSubscription
+	channel chan<- eth.Log
+	query   ethereum.FilterQuery
+}
+
+// send blocks until the log is received or the subscription is unsubscribed
+func (sub *simulatedLogSubscription) send(log eth.Log) {
+	sub.simulatedSubscription.send(func() {
+		select {
+		case sub.channel <- log.Copy():
+		case <-sub.chUnsubscribed:
+		}
+	})
+}
+
+type simulatedHeadSubscription struct {
+	simulatedSubscription
+	channel chan<- eth.BlockHeader
+}
+
+// send blocks until the head is received or the subscription is unsubscribed
+func (sub *simulatedHeadSubscription) send(head eth.BlockHeader) {
+	sub.simulatedSubscription.send(func() {
+		select {
+		case sub.channel <- head:
+		case <-sub.chUnsubscribed:
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package cltest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/eth"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulatedEthClient_GetLogsRespectsFilterQuery(t *testing.T) {
+	client := NewSimulatedEthClient()
+	addr1, addr2 := NewAddress(), NewAddress()
+	topic1, topic2 := NewHash(), NewHash()
+
+	client.PushBlock(eth.Log{Address: addr1, Topics: []common.Hash{topic1}})
+	client.PushBlock(eth.Log{Address: addr2, Topics: []common.Hash{topic2}})
+	client.PushBlock(eth.Log{Address: addr1, Topics: []common.Hash{topic2}})
+
+	blockNumbers := func(q ethereum.FilterQuery) []uint64 {
+		logs, err := client.GetLogs(q)
+		require.NoError(t, err)
+		numbers := []uint64{}
+		for _, log := range logs {
+			numbers = append(numbers, log.BlockNumber)
+		}
+		return numbers
+	}
+
+	assert.Equal(t, []uint64{1, 2, 3}, blockNumbers(ethereum.FilterQuery{}))
+	assert.Equal(t, []uint64{2, 3}, blockNumbers(ethereum.FilterQuery{FromBlock: i(2)}))
+	assert.Equal(t, []uint64{1, 2}, blockNumbers(ethereum.FilterQuery{ToBlock: i(2)}))
+	assert.Equal(t, []uint64{1, 3}, blockNumbers(ethereum.FilterQuery{Addresses: []common.Address{addr1}}))
+	assert.Equal(t, []uint64{2, 3}, blockNumbers(ethereum.FilterQuery{Topics: [][]common.Hash{{topic2}}}))
+	assert.Equal(t, []uint64{3}, blockNumbers(ethereum.FilterQuery{
+		Addresses: []common.Address{addr1},
+		Topics:    [][]common.Hash{{topic2}},
+	}))
+	// As with geth, logs with fewer topics than the query never match
+	assert.Equal(t, []uint64{}, blockNumbers(ethereum.FilterQuery{
+		Topics: [][]common.Hash{{}, {}},
+	}))
+}
+
+func TestSimulatedEthClient_SubscribeToLogsDeliversPushesAndReorgs(t *testing.T) {
+	client := NewSimulatedEthClient()
+	addr := NewAddress()
+
+	chLogs := make(chan eth.Log, 10)
+	sub, err := client.SubscribeToLogs(context.Background(), chLogs,
+		ethereum.FilterQuery{Addresses: []common.Address{addr}})
+	require.NoError(t, err)
+
+	client.PushBlock(eth.Log{Address: NewAddress()})
+	client.PushBlock(eth.Log{Address: addr})
+	log := <-chLogs
+	assert.Equal(t, uint64(2), log.BlockNumber)
+	assert.False(t, log.Removed)
+
+	client.Reorg(1)
+	removed := <-chLogs
+	assert.True(t, removed.Removed)
+	assert.Equal(t, log.BlockHash, removed.BlockHash)
+	assert.Equal(t, uint64(1), client.Height())
+
+	client.PushBlock(eth.Log{Address: addr})
+	replacement := <-chLogs
+	assert.Equal(t, uint64(2), replacement.BlockNumber)
+	assert.NotEqual(t, log.BlockHash, replacement.BlockHash)
+
+	sub.Unsubscribe()
+	assert.Equal(t, 0, client.LogSubscriptionCount())
+	client.PushBlock(eth.Log{Address: addr})
+	assert.Len(t, chLogs, 0)
+}
+
+func TestSimulatedEthClient_SubscribeToNewHeadsDeliversPushes(t *testing.T) {
+	client := NewSimulatedEthClient()
+
+	chHeads := make(chan eth.BlockHeader, 10)
+	sub, err := client.SubscribeToNewHeads(context.Background(), chHeads)
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.HeadSubscriptionCount())
+
+	client.PushBlock()
+	client.PushBlock(eth.Log{Address: NewAddress()})
+	for _, expected := range []int64{1, 2} {
+		head := <-chHeads
+		assert.Equal(t, expected, head.Number.ToInt().Int64())
+	}
+
+	sub.Unsubscribe()
+	assert.Equal(t, 0, client.HeadSubscriptionCount())
+	client.PushBlock()
+	assert.Len(t, chHeads, 0)
+}
@@ -879,7 +879,14 @@ func TestIntegration_FluxMonitor_Deviation(t *testing.T) {
 	require.NoError(t, app.StartAndConnect())
 	eth.EventuallyAllCalled(t)
 
-	// Configure fake Eth Node to return 10,000 cents when FM initiates price.
+	// Configure fake Eth Node to authorize the node's account as an oracle, and
+	// return 10,000 cents when FM initiates price.
+	eth.Context("Flux Monitor checks oracle is authorized", func(mock *cltest.EthMock) {
+		mock.Register("eth_call", cltest.MakeGetOraclesReturnData(cltest.GetAccountAddress(t, app.Store)))
+	})
+	eth.Context("Flux Monitor reads FluxAggregator.LatestAnswer()", func(mock *cltest.EthMock) {
+		mock.Register("eth_call", hexutil.Encode(utils.EVMWordUint64(10000)))
+	})
 	eth.Context("Flux Monitor initializes price", func(mock *cltest.EthMock) {
 		hex := cltest.MakeRoundStateReturnData(2, true, 10000, 7, 0, availableFunds, minPayment, 1)
 		mock.Register("eth_call", hex)
@@ -957,7 +964,14 @@ func TestIntegration_FluxMonitor_NewRound(t *testing.T) {
 	require.NoError(t, app.StartAndConnect())
 	eth.EventuallyAllCalled(t)
 
-	// Configure fake Eth Node to return 10,000 cents when FM initiates price.
+	// Configure fake Eth Node to authorize the node's account as an oracle, and
+	// return 10,000 cents when FM initiates price.
+	eth.Context("Flux Monitor checks oracle is authorized", func(mock *cltest.EthMock) {
+		mock.Register("eth_call", cltest.MakeGetOraclesReturnData(cltest.GetAccountAddress(t, app.Store)))
+	})
+	eth.Context("Flux Monitor reads FluxAggregator.LatestAnswer()", func(mock *cltest.EthMock) {
+		mock.Register("eth_call", hexutil.Encode(utils.EVMWordUint64(10000)))
+	})
 	eth.Context("Flux Monitor queries FluxAggregator.RoundState()", func(mock *cltest.EthMock) {
 		hex := cltest.MakeRoundStateReturnData(2, true, 10000, 7, 0, availableFunds, minPayment, 1)
 		mock.Register("eth_call", hex)
@@ -31,6 +31,23 @@ func (_m *CallerSubscriber) Call(result interface{}, method string, args ...inte
 	return r0
 }
 
+// CallContext provides a mock function with given fields: ctx, result, method, args
+func (_m *CallerSubscriber) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, result, method)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, string, ...interface{}) error); ok {
+		r0 = rf(ctx, result, method, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Subscribe provides a mock function with given fields: _a0, _a1, _a2
 func (_m *CallerSubscriber) Subscribe(_a0 context.Context, _a1 interface{}, _a2 ...interface{}) (eth.Subscription, error) {
 	var _ca []interface{}
@@ -16,6 +16,8 @@ import (
 	ethereum "github.com/ethereum/go-ethereum"
 
 	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/ethereum/go-ethereum/core/types"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -23,6 +25,29 @@ type Client struct {
 	mock.Mock
 }
 
+// BalanceAt provides a mock function with given fields: address, block
+func (_m *Client) BalanceAt(address common.Address, block *big.Int) (*big.Int, error) {
+	ret := _m.Called(address, block)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func(common.Address, *big.Int) *big.Int); ok {
+		r0 = rf(address, block)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, *big.Int) error); ok {
+		r1 = rf(address, block)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Call provides a mock function with given fields: result, method, args
 func (_m *Client) Call(result interface{}, method string, args ...interface{}) error {
 	var _ca []interface{}
@@ -40,6 +65,23 @@ func (_m *Client) Call(result interface{}, method string, args ...interface{}) e
 	return r0
 }
 
+// CallContext provides a mock function with given fields: ctx, result, method, args
+func (_m *Client) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, result, method)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, string, ...interface{}) error); ok {
+		r0 = rf(ctx, result, method, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetBlockByNumber provides a mock function with given fields: hex
 func (_m *Client) GetBlockByNumber(hex string) (eth.Block, error) {
 	ret := _m.Called(hex)
@@ -239,6 +281,29 @@ func (_m *Client) GetTxReceipt(hash common.Hash) (*eth.TxReceipt, error) {
 	return r0, r1
 }
 
+// SendRawTransaction provides a mock function with given fields: tx
+func (_m *Client) SendRawTransaction(tx *types.Transaction) (common.Hash, error) {
+	ret := _m.Called(tx)
+
+	var r0 common.Hash
+	if rf, ok := ret.Get(0).(func(*types.Transaction) common.Hash); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Transaction) error); ok {
+		r1 = rf(tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendRawTx provides a mock function with given fields: bytes
 func (_m *Client) SendRawTx(bytes []byte) (common.Hash, error) {
 	ret := _m.Called(bytes)
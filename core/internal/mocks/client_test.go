@@ -0,0 +1,84 @@
+package mocks_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockedMethods returns the signatures of the methods of mockType, other than
+// those promoted from mock.Mock, keyed by name
+func mockedMethods(mockType reflect.Type) map[string]reflect.Type {
+	promoted := make(map[string]bool)
+	mockMockType := reflect.TypeOf(&mock.Mock{})
+	for i := 0; i < mockMockType.NumMethod(); i++ {
+		promoted[mockMockType.Method(i).Name] = true
+	}
+
+	methods := make(map[string]reflect.Type)
+	for i := 0; i < mockType.NumMethod(); i++ {
+		method := mockType.Method(i)
+		if promoted[method.Name] {
+			continue
+		}
+		// Drop the receiver, to compare with the interface's method
+		in := make([]reflect.Type, method.Type.NumIn()-1)
+		for j := range in {
+			in[j] = method.Type.In(j + 1)
+		}
+		out := make([]reflect.Type, method.Type.NumOut())
+		for j := range out {
+			out[j] = method.Type.Out(j)
+		}
+		methods[method.Name] = reflect.FuncOf(in, out, method.Type.IsVariadic())
+	}
+	return methods
+}
+
+func TestMocks_MatchTheirInterfaces(t *testing.T) {
+	tests := []struct {
+		name      string
+		iface     reflect.Type
+		mockValue interface{}
+	}{
+		{"Client", reflect.TypeOf((*eth.Client)(nil)).Elem(), new(mocks.Client)},
+		{"Subscription", reflect.TypeOf((*eth.Subscription)(nil)).Elem(), new(mocks.Subscription)},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			mocked := mockedMethods(reflect.TypeOf(test.mockValue))
+
+			var missing, mismatched []string
+			for i := 0; i < test.iface.NumMethod(); i++ {
+				method := test.iface.Method(i)
+				signature, ok := mocked[method.Name]
+				delete(mocked, method.Name)
+				switch {
+				case !ok:
+					missing = append(missing, method.Name)
+				case signature != method.Type:
+					mismatched = append(mismatched, method.Name+": mock has "+signature.String()+
+						", interface has "+method.Type.String())
+				}
+			}
+			var extra []string
+			for name := range mocked {
+				extra = append(extra, name)
+			}
+			sort.Strings(extra)
+
+			const regenerate = "regenerate the mock with `go generate`"
+			assert.Empty(t, missing, "mock is missing methods of the interface; "+regenerate)
+			assert.Empty(t, mismatched, "mock's method signatures differ from the interface's; "+regenerate)
+			assert.Empty(t, extra, "mock has methods which the interface doesn't; "+regenerate)
+		})
+	}
+}
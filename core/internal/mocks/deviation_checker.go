@@ -2,13 +2,30 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	fluxmonitor "github.com/smartcontractkit/chainlink/core/services/fluxmonitor"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // DeviationChecker is an autogenerated mock type for the DeviationChecker type
 type DeviationChecker struct {
 	mock.Mock
 }
 
+// Metrics provides a mock function with given fields:
+func (_m *DeviationChecker) Metrics() fluxmonitor.FluxMonitorJobMetrics {
+	ret := _m.Called()
+
+	var r0 fluxmonitor.FluxMonitorJobMetrics
+	if rf, ok := ret.Get(0).(func() fluxmonitor.FluxMonitorJobMetrics); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(fluxmonitor.FluxMonitorJobMetrics)
+	}
+
+	return r0
+}
+
 // Start provides a mock function with given fields:
 func (_m *DeviationChecker) Start() {
 	_m.Called()
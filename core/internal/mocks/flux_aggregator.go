@@ -3,6 +3,10 @@
 package mocks
 
 import (
+	big "math/big"
+
+	context "context"
+
 	abi "github.com/ethereum/go-ethereum/accounts/abi"
 	common "github.com/ethereum/go-ethereum/common"
 
@@ -53,6 +57,86 @@ func (_m *FluxAggregator) Call(result interface{}, methodName string, args ...in
 	return r0
 }
 
+// CallContext provides a mock function with given fields: ctx, result, methodName, args
+func (_m *FluxAggregator) CallContext(ctx context.Context, result interface{}, methodName string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, result, methodName)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, string, ...interface{}) error); ok {
+		r0 = rf(ctx, result, methodName, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Decimals provides a mock function with given fields:
+func (_m *FluxAggregator) Decimals() (uint8, error) {
+	ret := _m.Called()
+
+	var r0 uint8
+	if rf, ok := ret.Get(0).(func() uint8); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint8)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Description provides a mock function with given fields:
+func (_m *FluxAggregator) Description() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EligibleToSubmit provides a mock function with given fields: oracle
+func (_m *FluxAggregator) EligibleToSubmit(oracle common.Address) (bool, error) {
+	ret := _m.Called(oracle)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(common.Address) bool); ok {
+		r0 = rf(oracle)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
+		r1 = rf(oracle)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EncodeMessageCall provides a mock function with given fields: method, args
 func (_m *FluxAggregator) EncodeMessageCall(method string, args ...interface{}) ([]byte, error) {
 	var _ca []interface{}
@@ -102,6 +186,73 @@ func (_m *FluxAggregator) GetMethodID(method string) ([]byte, error) {
 	return r0, r1
 }
 
+// GetOracles provides a mock function with given fields:
+func (_m *FluxAggregator) GetOracles() ([]common.Address, error) {
+	ret := _m.Called()
+
+	var r0 []common.Address
+	if rf, ok := ret.Get(0).(func() []common.Address); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Address)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LatestAnswer provides a mock function with given fields:
+func (_m *FluxAggregator) LatestAnswer() (*big.Int, error) {
+	ret := _m.Called()
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func() *big.Int); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OracleCount provides a mock function with given fields:
+func (_m *FluxAggregator) OracleCount() (uint32, error) {
+	ret := _m.Called()
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RoundState provides a mock function with given fields: oracle
 func (_m *FluxAggregator) RoundState(oracle common.Address) (contracts.FluxAggregatorRoundState, error) {
 	ret := _m.Called(oracle)
@@ -123,6 +274,27 @@ func (_m *FluxAggregator) RoundState(oracle common.Address) (contracts.FluxAggre
 	return r0, r1
 }
 
+// RoundStateWithContext provides a mock function with given fields: ctx, oracle
+func (_m *FluxAggregator) RoundStateWithContext(ctx context.Context, oracle common.Address) (contracts.FluxAggregatorRoundState, error) {
+	ret := _m.Called(ctx, oracle)
+
+	var r0 contracts.FluxAggregatorRoundState
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) contracts.FluxAggregatorRoundState); ok {
+		r0 = rf(ctx, oracle)
+	} else {
+		r0 = ret.Get(0).(contracts.FluxAggregatorRoundState)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = rf(ctx, oracle)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubscribeToLogs provides a mock function with given fields: listener
 func (_m *FluxAggregator) SubscribeToLogs(listener eth.LogListener) (bool, eth.UnsubscribeFunc) {
 	ret := _m.Called(listener)
@@ -146,6 +318,29 @@ func (_m *FluxAggregator) SubscribeToLogs(listener eth.LogListener) (bool, eth.U
 	return r0, r1
 }
 
+// SubscribeWithHistory provides a mock function with given fields: listener, fromBlock
+func (_m *FluxAggregator) SubscribeWithHistory(listener eth.LogListener, fromBlock uint64) (eth.UnsubscribeFunc, error) {
+	ret := _m.Called(listener, fromBlock)
+
+	var r0 eth.UnsubscribeFunc
+	if rf, ok := ret.Get(0).(func(eth.LogListener, uint64) eth.UnsubscribeFunc); ok {
+		r0 = rf(listener, fromBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(eth.UnsubscribeFunc)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(eth.LogListener, uint64) error); ok {
+		r1 = rf(listener, fromBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnpackLog provides a mock function with given fields: out, event, log
 func (_m *FluxAggregator) UnpackLog(out interface{}, event string, log coreeth.Log) error {
 	ret := _m.Called(out, event, log)
@@ -159,3 +354,24 @@ func (_m *FluxAggregator) UnpackLog(out interface{}, event string, log coreeth.L
 
 	return r0
 }
+
+// WaitMined provides a mock function with given fields: ctx, txHash
+func (_m *FluxAggregator) WaitMined(ctx context.Context, txHash common.Hash) (coreeth.TxReceipt, error) {
+	ret := _m.Called(ctx, txHash)
+
+	var r0 coreeth.TxReceipt
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) coreeth.TxReceipt); ok {
+		r0 = rf(ctx, txHash)
+	} else {
+		r0 = ret.Get(0).(coreeth.TxReceipt)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, txHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
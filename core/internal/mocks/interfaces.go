@@ -0,0 +1,14 @@
+package mocks
+
+import (
+	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
+)
+
+// The mocks are generated by mockery, so aren't updated when the interfaces
+// they mock change.  These assertions make a stale mock fail to compile.
+var (
+	_ eth.Client       = (*Client)(nil)
+	_ eth.Subscription = (*Subscription)(nil)
+	_ contracts.Flags  = (*Flags)(nil)
+)
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	common "github.com/ethereum/go-ethereum/common"
 	eth "github.com/smartcontractkit/chainlink/core/services/eth"
 	mock "github.com/stretchr/testify/mock"
@@ -39,6 +41,50 @@ func (_m *LogBroadcaster) DependentReady() {
 	_m.Called()
 }
 
+// HealthReport provides a mock function with given fields:
+func (_m *LogBroadcaster) HealthReport() eth.LogBroadcasterHealth {
+	ret := _m.Called()
+
+	var r0 eth.LogBroadcasterHealth
+	if rf, ok := ret.Get(0).(func() eth.LogBroadcasterHealth); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(eth.LogBroadcasterHealth)
+	}
+
+	return r0
+}
+
+// PendingDependents provides a mock function with given fields:
+func (_m *LogBroadcaster) PendingDependents() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// RecentDeliveries provides a mock function with given fields:
+func (_m *LogBroadcaster) RecentDeliveries() []eth.DeliveryRecord {
+	ret := _m.Called()
+
+	var r0 []eth.DeliveryRecord
+	if rf, ok := ret.Get(0).(func() []eth.DeliveryRecord); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]eth.DeliveryRecord)
+		}
+	}
+
+	return r0
+}
+
 // Register provides a mock function with given fields: address, listener
 func (_m *LogBroadcaster) Register(address common.Address, listener eth.LogListener) bool {
 	ret := _m.Called(address, listener)
@@ -53,9 +99,141 @@ func (_m *LogBroadcaster) Register(address common.Address, listener eth.LogListe
 	return r0
 }
 
+// RegisterAndWaitForConnect provides a mock function with given fields: ctx, address, listener
+func (_m *LogBroadcaster) RegisterAndWaitForConnect(ctx context.Context, address common.Address, listener eth.LogListener) error {
+	ret := _m.Called(ctx, address, listener)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, eth.LogListener) error); ok {
+		r0 = rf(ctx, address, listener)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegisterFromBlock provides a mock function with given fields: address, listener, fromBlock
+func (_m *LogBroadcaster) RegisterFromBlock(address common.Address, listener eth.LogListener, fromBlock uint64) bool {
+	ret := _m.Called(address, listener, fromBlock)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(common.Address, eth.LogListener, uint64) bool); ok {
+		r0 = rf(address, listener, fromBlock)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// RegisterWithFilter provides a mock function with given fields: address, listener, filter
+func (_m *LogBroadcaster) RegisterWithFilter(address common.Address, listener eth.LogListener, filter eth.LogFilter) bool {
+	ret := _m.Called(address, listener, filter)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(common.Address, eth.LogListener, eth.LogFilter) bool); ok {
+		r0 = rf(address, listener, filter)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ReconciliationReport provides a mock function with given fields:
+func (_m *LogBroadcaster) ReconciliationReport() (uint64, uint64, []eth.LogKey) {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func() uint64); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 []eth.LogKey
+	if rf, ok := ret.Get(2).(func() []eth.LogKey); ok {
+		r2 = rf()
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).([]eth.LogKey)
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// ReorgHistory provides a mock function with given fields:
+func (_m *LogBroadcaster) ReorgHistory() []eth.ReorgEvent {
+	ret := _m.Called()
+
+	var r0 []eth.ReorgEvent
+	if rf, ok := ret.Get(0).(func() []eth.ReorgEvent); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]eth.ReorgEvent)
+		}
+	}
+
+	return r0
+}
+
+// ReplayFromBlock provides a mock function with given fields: listener, fromBlock
+func (_m *LogBroadcaster) ReplayFromBlock(listener eth.LogListener, fromBlock uint64) error {
+	ret := _m.Called(listener, fromBlock)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(eth.LogListener, uint64) error); ok {
+		r0 = rf(listener, fromBlock)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestoreRegistrations provides a mock function with given fields: registrations
+func (_m *LogBroadcaster) RestoreRegistrations(registrations []eth.Registration) {
+	_m.Called(registrations)
+}
+
+// SnapshotRegistrations provides a mock function with given fields:
+func (_m *LogBroadcaster) SnapshotRegistrations() []eth.Registration {
+	ret := _m.Called()
+
+	var r0 []eth.Registration
+	if rf, ok := ret.Get(0).(func() []eth.Registration); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]eth.Registration)
+		}
+	}
+
+	return r0
+}
+
 // Start provides a mock function with given fields:
-func (_m *LogBroadcaster) Start() {
-	_m.Called()
+func (_m *LogBroadcaster) Start() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // Stop provides a mock function with given fields:
@@ -3,8 +3,10 @@
 package mocks
 
 import (
-	models "github.com/smartcontractkit/chainlink/core/store/models"
+	fluxmonitor "github.com/smartcontractkit/chainlink/core/services/fluxmonitor"
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/smartcontractkit/chainlink/core/store/models"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -26,6 +28,27 @@ func (_m *Service) AddJob(_a0 models.JobSpec) error {
 	return r0
 }
 
+// JobMetrics provides a mock function with given fields: _a0
+func (_m *Service) JobMetrics(_a0 models.ID) (fluxmonitor.FluxMonitorJobMetrics, bool) {
+	ret := _m.Called(_a0)
+
+	var r0 fluxmonitor.FluxMonitorJobMetrics
+	if rf, ok := ret.Get(0).(func(models.ID) fluxmonitor.FluxMonitorJobMetrics); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(fluxmonitor.FluxMonitorJobMetrics)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(models.ID) bool); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // RemoveJob provides a mock function with given fields: _a0
 func (_m *Service) RemoveJob(_a0 *models.ID) {
 	_m.Called(_a0)
@@ -18,6 +18,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	types "github.com/ethereum/go-ethereum/core/types"
+
 	models "github.com/smartcontractkit/chainlink/core/store/models"
 
 	null "gopkg.in/guregu/null.v3"
@@ -30,6 +32,29 @@ type TxManager struct {
 	mock.Mock
 }
 
+// BalanceAt provides a mock function with given fields: address, block
+func (_m *TxManager) BalanceAt(address common.Address, block *big.Int) (*big.Int, error) {
+	ret := _m.Called(address, block)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func(common.Address, *big.Int) *big.Int); ok {
+		r0 = rf(address, block)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, *big.Int) error); ok {
+		r1 = rf(address, block)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BumpGasUntilSafe provides a mock function with given fields: hash
 func (_m *TxManager) BumpGasUntilSafe(hash common.Hash) (*eth.TxReceipt, store.AttemptState, error) {
 	ret := _m.Called(hash)
@@ -77,6 +102,23 @@ func (_m *TxManager) Call(result interface{}, method string, args ...interface{}
 	return r0
 }
 
+// CallContext provides a mock function with given fields: ctx, result, method, args
+func (_m *TxManager) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, result, method)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, string, ...interface{}) error); ok {
+		r0 = rf(ctx, result, method, args...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CheckAttempt provides a mock function with given fields: txAttempt, blockHeight
 func (_m *TxManager) CheckAttempt(txAttempt *models.TxAttempt, blockHeight uint64) (*eth.TxReceipt, store.AttemptState, error) {
 	ret := _m.Called(txAttempt, blockHeight)
@@ -478,6 +520,29 @@ func (_m *TxManager) Register(_a0 []accounts.Account) {
 	_m.Called(_a0)
 }
 
+// SendRawTransaction provides a mock function with given fields: tx
+func (_m *TxManager) SendRawTransaction(tx *types.Transaction) (common.Hash, error) {
+	ret := _m.Called(tx)
+
+	var r0 common.Hash
+	if rf, ok := ret.Get(0).(func(*types.Transaction) common.Hash); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Transaction) error); ok {
+		r1 = rf(tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendRawTx provides a mock function with given fields: bytes
 func (_m *TxManager) SendRawTx(bytes []byte) (common.Hash, error) {
 	ret := _m.Called(bytes)
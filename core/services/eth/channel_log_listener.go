@@ -0,0 +1,68 @@
+package eth
+
+import (
+	"sync/atomic"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// ChannelLogListener is a LogListener which forwards each log broadcast to a
+// buffered channel, for consumers which would rather select on a channel than
+// implement the LogListener callbacks.
+//
+// HandleLog never blocks the LogBroadcaster: if the channel is full, the
+// broadcast is dropped and counted instead.  Broadcasts received with an error
+// are logged and not forwarded.  The channel is never closed, since the
+// broadcaster may still be delivering to the listener after it's unregistered.
+type ChannelLogListener struct {
+	consumer models.LogConsumer
+	chLogs   chan LogBroadcast
+	dropped  uint64
+}
+
+var _ LogListener = (*ChannelLogListener)(nil)
+
+// NewChannelLogListener returns a ChannelLogListener identified by consumer,
+// and the channel it forwards broadcasts to, which buffers up to buffer of
+// them
+func NewChannelLogListener(consumer models.LogConsumer, buffer int) (LogListener, <-chan LogBroadcast) {
+	listener := &ChannelLogListener{
+		consumer: consumer,
+		chLogs:   make(chan LogBroadcast, buffer),
+	}
+	return listener, listener.chLogs
+}
+
+// HandleLog forwards lb to the channel, unless it's full
+func (l *ChannelLogListener) HandleLog(lb LogBroadcast, err error) {
+	if err != nil {
+		logger.Errorw("ChannelLogListener received error from log broadcaster",
+			"consumer", l.consumer,
+			"error", err,
+		)
+		return
+	}
+	select {
+	case l.chLogs <- lb:
+	default:
+		dropped := atomic.AddUint64(&l.dropped, 1)
+		logger.Warnw("ChannelLogListener channel is full, dropping log",
+			"consumer", l.consumer,
+			"dropped", dropped,
+		)
+	}
+}
+
+// OnConnect does nothing
+func (l *ChannelLogListener) OnConnect() {}
+
+// OnDisconnect does nothing
+func (l *ChannelLogListener) OnDisconnect() {}
+
+// Consumer returns the consumer the listener was created with
+func (l *ChannelLogListener) Consumer() models.LogConsumer { return l.consumer }
+
+// Dropped returns the number of broadcasts dropped because the channel was
+// full
+func (l *ChannelLogListener) Dropped() uint64 { return atomic.LoadUint64(&l.dropped) }
@@ -1,8 +1,16 @@
 package eth
 
 import (
+	"bytes"
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
 	"github.com/smartcontractkit/chainlink/core/eth"
 
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
@@ -11,7 +19,10 @@ import (
 type ConnectedContract interface {
 	eth.ContractCodec
 	Call(result interface{}, methodName string, args ...interface{}) error
+	CallContext(ctx context.Context, result interface{}, methodName string, args ...interface{}) error
 	SubscribeToLogs(listener LogListener) (connected bool, _ UnsubscribeFunc)
+	SubscribeWithHistory(listener LogListener, fromBlock uint64) (UnsubscribeFunc, error)
+	WaitMined(ctx context.Context, txHash common.Hash) (eth.TxReceipt, error)
 }
 
 type connectedContract struct {
@@ -33,6 +44,20 @@ func NewConnectedContract(
 }
 
 func (contract *connectedContract) Call(result interface{}, methodName string, args ...interface{}) error {
+	return contract.call(contract.ethClient.Call, result, methodName, args...)
+}
+
+// CallContext is Call, with ctx bounding the eth_call made to the client
+func (contract *connectedContract) CallContext(ctx context.Context, result interface{}, methodName string, args ...interface{}) error {
+	callContext := func(result interface{}, method string, args ...interface{}) error {
+		return contract.ethClient.CallContext(ctx, result, method, args...)
+	}
+	return contract.call(callContext, result, methodName, args...)
+}
+
+type rpcCaller func(result interface{}, method string, args ...interface{}) error
+
+func (contract *connectedContract) call(ethCall rpcCaller, result interface{}, methodName string, args ...interface{}) error {
 	data, err := contract.EncodeMessageCall(methodName, args...)
 	if err != nil {
 		return errors.Wrap(err, "unable to encode message call")
@@ -40,17 +65,210 @@ func (contract *connectedContract) Call(result interface{}, methodName string, a
 
 	var rawResult hexutil.Bytes
 	callArgs := eth.CallArgs{To: contract.address, Data: data}
-	err = contract.ethClient.Call(&rawResult, "eth_call", callArgs, "latest")
+	err = ethCall(&rawResult, "eth_call", callArgs, "latest")
 	if err != nil {
 		return errors.Wrap(err, "unable to call client")
 	}
 
+	if reason, reverted := decodeRevertReason(rawResult); reverted {
+		return errors.Wrapf(ErrCallReverted, "%s reverted with reason %q", methodName, reason)
+	}
+
 	err = contract.ABI().Unpack(result, methodName, rawResult)
 	return errors.Wrap(err, "unable to unpack values")
 }
 
+// ErrCallReverted is the cause of the error returned by ConnectedContract.Call
+// when the contract reverts with a reason string
+var ErrCallReverted = errors.New("contract call reverted")
+
+// revertReasonSelector is the selector of Error(string), which solidity uses
+// to encode the reason given to require or revert
+var revertReasonSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+var revertReasonArguments = func() abi.Arguments {
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return abi.Arguments{{Type: stringType}}
+}()
+
+// decodeRevertReason returns the reason string encoded in data, and whether
+// data is an Error(string) revert at all
+func decodeRevertReason(data []byte) (reason string, reverted bool) {
+	if len(data) < len(revertReasonSelector) || !bytes.Equal(data[:len(revertReasonSelector)], revertReasonSelector) {
+		return "", false
+	}
+	if err := revertReasonArguments.Unpack(&reason, data[len(revertReasonSelector):]); err != nil {
+		return "", false
+	}
+	return reason, true
+}
+
+// ErrTxReverted is the cause of the error returned by
+// ConnectedContract.WaitMined when the mined transaction failed
+var ErrTxReverted = errors.New("transaction reverted")
+
+// waitMinedPollInterval is how often WaitMined polls for the receipt
+var waitMinedPollInterval = 1 * time.Second
+
+// WaitMined polls for the receipt of the transaction txHash until it has been
+// mined, and returns it.  If the transaction reverted, the receipt is returned
+// along with an error whose cause is ErrTxReverted.  Errors fetching the
+// receipt are retried, and if ctx is done first, its error is returned,
+// wrapped with the last such error, if any.
+func (contract *connectedContract) WaitMined(ctx context.Context, txHash common.Hash) (eth.TxReceipt, error) {
+	ticker := time.NewTicker(waitMinedPollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		receipt, err := contract.ethClient.GetTxReceipt(txHash)
+		if err != nil {
+			lastErr = err
+		} else if receipt != nil && !receipt.Unconfirmed() {
+			if receipt.Reverted() {
+				return *receipt, errors.Wrapf(ErrTxReverted, "transaction %s reverted", txHash.Hex())
+			}
+			return *receipt, nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			if lastErr != nil {
+				return eth.TxReceipt{}, errors.Wrapf(ctx.Err(), "waiting for transaction %s to be mined, last error %v", txHash.Hex(), lastErr)
+			}
+			return eth.TxReceipt{}, errors.Wrapf(ctx.Err(), "waiting for transaction %s to be mined", txHash.Hex())
+		}
+	}
+}
+
 func (contract *connectedContract) SubscribeToLogs(listener LogListener) (connected bool, _ UnsubscribeFunc) {
 	connected = contract.logBroadcaster.Register(contract.address, listener)
 	unsub := func() { contract.logBroadcaster.Unregister(contract.address, listener) }
 	return connected, unsub
 }
+
+// SubscribeWithHistory catches listener up on the logs the contract has
+// emitted since fromBlock, then streams its live logs to it.  listener is
+// registered with the broadcaster first, so that no log falls between the two,
+// and the live logs which arrive while the historical ones are being delivered
+// are held until they all have been.  Live logs which were already delivered
+// from the history, or which precede fromBlock, e.g. because the broadcaster
+// backfilled them, are dropped, so listener sees each log once, in order.
+//
+// Historical logs aren't tracked by the broadcaster, so they are always
+// reported as unconsumed, and marking them consumed does nothing: fromBlock
+// should be chosen from the listener's own progress.  If the history can't be
+// fetched, listener is unregistered and the error returned.
+func (contract *connectedContract) SubscribeWithHistory(listener LogListener, fromBlock uint64) (UnsubscribeFunc, error) {
+	historyListener := &historyLogListener{
+		LogListener: listener,
+		fromBlock:   fromBlock,
+		catchingUp:  true,
+		delivered:   make(map[logKey]struct{}),
+	}
+	contract.logBroadcaster.Register(contract.address, historyListener)
+	unsub := func() { contract.logBroadcaster.Unregister(contract.address, historyListener) }
+
+	logs, err := contract.ethClient.GetLogs(ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		Addresses: []common.Address{contract.address},
+	})
+	if err != nil {
+		unsub()
+		return nil, errors.Wrapf(err, "unable to fetch logs from block %d", fromBlock)
+	}
+	historyListener.catchUp(logs)
+	return unsub, nil
+}
+
+// historyLogListener delivers a contract's historical logs to the LogListener
+// it wraps, followed by its live logs, as SubscribeWithHistory describes
+type historyLogListener struct {
+	LogListener
+	fromBlock uint64
+
+	mutex      sync.Mutex
+	catchingUp bool
+	pending    []pendingBroadcast // live broadcasts held while catching up
+	// delivered holds the historical logs, which is only written before
+	// catchingUp is cleared
+	delivered map[logKey]struct{}
+}
+
+type pendingBroadcast struct {
+	lb  LogBroadcast
+	err error
+}
+
+func (l *historyLogListener) HandleLog(lb LogBroadcast, err error) {
+	l.mutex.Lock()
+	if l.catchingUp {
+		l.pending = append(l.pending, pendingBroadcast{lb, err})
+		l.mutex.Unlock()
+		return
+	}
+	l.mutex.Unlock()
+	l.handleLiveLog(lb, err)
+}
+
+// catchUp delivers logs to the wrapped listener, in order, then the live
+// broadcasts held meanwhile
+func (l *historyLogListener) catchUp(logs []eth.Log) {
+	sortLogs(logs)
+	for _, rawLog := range logs {
+		if rawLog.Removed {
+			continue
+		}
+		key := logKey{rawLog.BlockHash, rawLog.Index}
+		if _, delivered := l.delivered[key]; delivered {
+			continue
+		}
+		l.delivered[key] = struct{}{}
+		historicalLog := rawLog.Copy()
+		l.LogListener.HandleLog(&historicalLogBroadcast{log: &historicalLog}, nil)
+	}
+	for {
+		l.mutex.Lock()
+		pending := l.pending
+		l.pending = nil
+		if len(pending) == 0 {
+			l.catchingUp = false
+			l.mutex.Unlock()
+			return
+		}
+		l.mutex.Unlock()
+		for _, p := range pending {
+			l.handleLiveLog(p.lb, p.err)
+		}
+	}
+}
+
+func (l *historyLogListener) handleLiveLog(lb LogBroadcast, err error) {
+	if err == nil {
+		if rawLog, is := lb.Log().(*eth.Log); is {
+			if _, delivered := l.delivered[logKey{rawLog.BlockHash, rawLog.Index}]; delivered {
+				return
+			} else if rawLog.BlockNumber < l.fromBlock {
+				return
+			}
+		}
+	}
+	l.LogListener.HandleLog(lb, err)
+}
+
+// historicalLogBroadcast is the LogBroadcast of a log fetched by
+// SubscribeWithHistory, whose consumption isn't tracked
+type historicalLogBroadcast struct {
+	log eth.RawLog
+}
+
+var _ LogBroadcast = (*historicalLogBroadcast)(nil)
+
+func (lb *historicalLogBroadcast) Log() interface{}                  { return lb.log }
+func (lb *historicalLogBroadcast) UpdateLog(newLog eth.RawLog)       { lb.log = newLog }
+func (lb *historicalLogBroadcast) WasAlreadyConsumed() (bool, error) { return false, nil }
+func (lb *historicalLogBroadcast) MarkConsumed() error               { return nil }
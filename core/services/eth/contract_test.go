@@ -0,0 +1,192 @@
+package eth_test
+
+import (
+	"context"
+	"encoding"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	ethsvc "github.com/smartcontractkit/chainlink/core/services/eth"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectedContract_Call_DecodesRevertReason(t *testing.T) {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	encodedReason, err := abi.Arguments{{Type: stringType}}.Pack("not enabled oracle")
+	require.NoError(t, err)
+	revertData := append(hexutil.MustDecode("0x08c379a0"), encodedReason...)
+
+	tests := []struct {
+		name           string
+		response       []byte
+		expectReverted bool
+	}{
+		{"revert reason", revertData, true},
+		{"truncated revert reason", revertData[:len(revertData)-32], false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ethClient := new(mocks.Client)
+			ethClient.On("Call", mock.Anything, "eth_call", mock.Anything, "latest").Return(nil).
+				Run(func(args mock.Arguments) {
+					res := args.Get(0)
+					err := res.(encoding.TextUnmarshaler).UnmarshalText([]byte(hexutil.Encode(test.response)))
+					require.NoError(t, err)
+				})
+
+			codec, err := eth.GetV6ContractCodec("FluxAggregator")
+			require.NoError(t, err)
+			contract := ethsvc.NewConnectedContract(codec, cltest.NewAddress(), ethClient, nil)
+
+			var count uint32
+			err = contract.Call(&count, "oracleCount")
+			require.Error(t, err)
+			if test.expectReverted {
+				assert.Equal(t, ethsvc.ErrCallReverted, errors.Cause(err))
+				assert.Contains(t, err.Error(), `oracleCount reverted with reason "not enabled oracle"`)
+			} else {
+				assert.NotEqual(t, ethsvc.ErrCallReverted, errors.Cause(err))
+			}
+			ethClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestConnectedContract_WaitMined(t *testing.T) {
+	defer ethsvc.SetWaitMinedPollInterval(10 * time.Millisecond)()
+
+	txHash := cltest.NewHash()
+	blockHash := cltest.NewHash()
+	minedReceipt := func(status uint64) *eth.TxReceipt {
+		s := hexutil.Uint64(status)
+		return &eth.TxReceipt{
+			Hash:        txHash,
+			BlockHash:   &blockHash,
+			BlockNumber: cltest.Int(42),
+			Status:      &s,
+		}
+	}
+	codec, err := eth.GetV6ContractCodec("FluxAggregator")
+	require.NoError(t, err)
+
+	t.Run("polls until the receipt is mined", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		ethClient.On("GetTxReceipt", txHash).Return(&eth.TxReceipt{}, nil).Twice()
+		ethClient.On("GetTxReceipt", txHash).Return(nil, errors.New("flaky node")).Once()
+		ethClient.On("GetTxReceipt", txHash).Return(minedReceipt(1), nil).Once()
+		contract := ethsvc.NewConnectedContract(codec, cltest.NewAddress(), ethClient, nil)
+
+		receipt, err := contract.WaitMined(context.Background(), txHash)
+		require.NoError(t, err)
+		assert.Equal(t, *minedReceipt(1), receipt)
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("returns ErrTxReverted if the transaction failed", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		ethClient.On("GetTxReceipt", txHash).Return(minedReceipt(0), nil).Once()
+		contract := ethsvc.NewConnectedContract(codec, cltest.NewAddress(), ethClient, nil)
+
+		receipt, err := contract.WaitMined(context.Background(), txHash)
+		require.Error(t, err)
+		assert.Equal(t, ethsvc.ErrTxReverted, errors.Cause(err))
+		assert.Equal(t, txHash, receipt.Hash)
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("gives up when the context is done", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		ethClient.On("GetTxReceipt", txHash).Return(&eth.TxReceipt{}, nil)
+		contract := ethsvc.NewConnectedContract(codec, cltest.NewAddress(), ethClient, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := contract.WaitMined(ctx, txHash)
+		require.Error(t, err)
+		assert.Equal(t, context.DeadlineExceeded, errors.Cause(err))
+	})
+}
+
+func TestConnectedContract_SubscribeWithHistory(t *testing.T) {
+	codec, err := eth.GetV6ContractCodec("FluxAggregator")
+	require.NoError(t, err)
+	address := cltest.NewAddress()
+	logAt := func(blockNumber uint64) eth.Log {
+		hash := common.BigToHash(new(big.Int).SetUint64(blockNumber))
+		return eth.Log{Address: address, BlockNumber: blockNumber, BlockHash: hash, TxHash: hash}
+	}
+	live := func(blockNumber uint64) ethsvc.LogBroadcast {
+		rawLog := logAt(blockNumber)
+		lb := new(mocks.LogBroadcast)
+		lb.On("Log").Return(&rawLog)
+		return lb
+	}
+
+	var registered ethsvc.LogListener
+	logBroadcaster := new(mocks.LogBroadcaster)
+	logBroadcaster.On("Register", address, mock.Anything).Return(true).
+		Run(func(args mock.Arguments) { registered = args.Get(1).(ethsvc.LogListener) }).
+		Once()
+	logBroadcaster.On("Unregister", address, mock.Anything).Return().
+		Run(func(args mock.Arguments) { assert.Equal(t, registered, args.Get(1)) }).
+		Once()
+
+	ethClient := new(mocks.Client)
+	ethClient.On("GetLogs", mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+		return q.FromBlock.Uint64() == 2 && len(q.Addresses) == 1 && q.Addresses[0] == address
+	})).
+		Return([]eth.Log{logAt(4), logAt(2), logAt(3)}, nil).
+		Run(func(mock.Arguments) {
+			// Live logs arriving while the history is fetched overlap with it
+			registered.HandleLog(live(4), nil)
+			registered.HandleLog(live(5), nil)
+		}).
+		Once()
+
+	listener := new(lifecycleRecordingListener)
+	contract := ethsvc.NewConnectedContract(codec, address, ethClient, logBroadcaster)
+	unsubscribe, err := contract.SubscribeWithHistory(listener, 2)
+	require.NoError(t, err)
+
+	registered.HandleLog(live(3), nil)
+	registered.HandleLog(live(1), nil) // e.g. backfilled, but before fromBlock
+	registered.HandleLog(live(6), nil)
+	unsubscribe()
+
+	expected := []string{"HandleLog(2)", "HandleLog(3)", "HandleLog(4)", "HandleLog(5)", "HandleLog(6)"}
+	assert.Equal(t, expected, listener.Events())
+	ethClient.AssertExpectations(t)
+	logBroadcaster.AssertExpectations(t)
+}
+
+func TestConnectedContract_SubscribeWithHistory_UnregistersIfHistoryFails(t *testing.T) {
+	codec, err := eth.GetV6ContractCodec("FluxAggregator")
+	require.NoError(t, err)
+	address := cltest.NewAddress()
+
+	logBroadcaster := new(mocks.LogBroadcaster)
+	logBroadcaster.On("Register", address, mock.Anything).Return(true).Once()
+	logBroadcaster.On("Unregister", address, mock.Anything).Return().Once()
+	ethClient := new(mocks.Client)
+	ethClient.On("GetLogs", mock.Anything).Return(nil, errors.New("node unreachable")).Once()
+
+	contract := ethsvc.NewConnectedContract(codec, address, ethClient, logBroadcaster)
+	_, err = contract.SubscribeWithHistory(new(lifecycleRecordingListener), 2)
+	require.Error(t, err)
+	ethClient.AssertExpectations(t)
+	logBroadcaster.AssertExpectations(t)
+}
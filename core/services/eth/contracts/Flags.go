@@ -0,0 +1,91 @@
+package contracts
+
+import (
+	"github.com/smartcontractkit/chainlink/core/eth"
+	ethsvc "github.com/smartcontractkit/chainlink/core/services/eth"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockery -name Flags -output ../../../internal/mocks/ -case=underscore
+
+// Flags is a contract which raises a flag for each subject, such as an
+// aggregator, which is in a known-bad state, and lowers it once it recovers.
+type Flags interface {
+	ethsvc.ConnectedContract
+	GetFlag(subject common.Address) (bool, error)
+}
+
+const (
+	// FlagsName is the name of the interface of Chainlink's Ethereum contract
+	// for flagging feeds in a known-bad state.
+	FlagsName = "FlagsInterface"
+)
+
+var (
+	// FlagsTopics are the event topics of the v0.6 FlagsInterface, as
+	// registered with the eth package. Eagerly fails if not found.
+	FlagsTopics = eth.MustRegisterContractEventTopics(
+		FlagsName, eth.ContractABIVersionV6,
+		"FlagRaised", "FlagLowered")
+	// FlagsFlagRaisedLogTopic is the FlagRaised filter topic for the Flags
+	// contract.
+	FlagsFlagRaisedLogTopic = FlagsTopics["FlagRaised"]
+	// FlagsFlagLoweredLogTopic is the FlagLowered filter topic for the Flags
+	// contract.
+	FlagsFlagLoweredLogTopic = FlagsTopics["FlagLowered"]
+)
+
+type flags struct {
+	ethsvc.ConnectedContract
+}
+
+type LogFlagRaised struct {
+	eth.Log
+	Subject common.Address
+}
+
+type LogFlagLowered struct {
+	eth.Log
+	Subject common.Address
+}
+
+var flagsLogTypes = map[common.Hash]interface{}{
+	FlagsFlagRaisedLogTopic:  LogFlagRaised{},
+	FlagsFlagLoweredLogTopic: LogFlagLowered{},
+}
+
+func NewFlags(address common.Address, ethClient eth.Client, logBroadcaster ethsvc.LogBroadcaster) (Flags, error) {
+	codec, err := eth.GetV6ContractCodec(FlagsName)
+	if err != nil {
+		return nil, err
+	}
+	connectedContract := ethsvc.NewConnectedContract(codec, address, ethClient, logBroadcaster)
+	return &flags{connectedContract}, nil
+}
+
+func (f *flags) SubscribeToLogs(listener ethsvc.LogListener) (connected bool, _ ethsvc.UnsubscribeFunc) {
+	return f.ConnectedContract.SubscribeToLogs(
+		ethsvc.NewDecodingLogListener(f, flagsLogTypes, listener),
+	)
+}
+
+// SubscribeWithHistory is ConnectedContract.SubscribeWithHistory, with the
+// logs decoded as SubscribeToLogs decodes them
+func (f *flags) SubscribeWithHistory(listener ethsvc.LogListener, fromBlock uint64) (ethsvc.UnsubscribeFunc, error) {
+	return f.ConnectedContract.SubscribeWithHistory(
+		ethsvc.NewDecodingLogListener(f, flagsLogTypes, listener),
+		fromBlock,
+	)
+}
+
+// GetFlag returns whether the flag of subject is raised.
+func (f *flags) GetFlag(subject common.Address) (bool, error) {
+	var raised bool
+	err := f.Call(&raised, "getFlag", subject)
+	if err != nil {
+		return false, errors.Wrap(err, "unable to get flag")
+	}
+	return raised, nil
+}
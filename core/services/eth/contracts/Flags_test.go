@@ -0,0 +1,58 @@
+package contracts_test
+
+import (
+	"encoding"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlags_GetFlag(t *testing.T) {
+	flagsAddress := cltest.NewAddress()
+	subject := cltest.NewAddress()
+
+	selector := make([]byte, 16)
+	copy(selector, utils.MustHash("getFlag(address)").Bytes()[:4])
+	expectedCallArgs := eth.CallArgs{
+		To:   flagsAddress,
+		Data: append(selector, subject[:]...),
+	}
+
+	tests := []struct {
+		name     string
+		response string
+		raised   bool
+	}{
+		{"lowered", common.BigToHash(common.Big0).Hex(), false},
+		{"raised", common.BigToHash(common.Big1).Hex(), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ethClient := new(mocks.Client)
+			ethClient.On("Call", mock.Anything, "eth_call", expectedCallArgs, "latest").Return(nil).
+				Run(func(args mock.Arguments) {
+					res := args.Get(0)
+					err := res.(encoding.TextUnmarshaler).UnmarshalText([]byte(test.response))
+					require.NoError(t, err)
+				})
+
+			flags, err := contracts.NewFlags(flagsAddress, ethClient, nil)
+			require.NoError(t, err)
+
+			raised, err := flags.GetFlag(subject)
+			require.NoError(t, err)
+			assert.Equal(t, test.raised, raised)
+			ethClient.AssertExpectations(t)
+		})
+	}
+}
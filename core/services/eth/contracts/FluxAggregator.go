@@ -1,7 +1,12 @@
 package contracts
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/eth"
 	ethsvc "github.com/smartcontractkit/chainlink/core/services/eth"
@@ -15,6 +20,13 @@ import (
 type FluxAggregator interface {
 	ethsvc.ConnectedContract
 	RoundState(oracle common.Address) (FluxAggregatorRoundState, error)
+	RoundStateWithContext(ctx context.Context, oracle common.Address) (FluxAggregatorRoundState, error)
+	EligibleToSubmit(oracle common.Address) (bool, error)
+	GetOracles() ([]common.Address, error)
+	LatestAnswer() (*big.Int, error)
+	OracleCount() (uint32, error)
+	Decimals() (uint8, error)
+	Description() (string, error)
 }
 
 const (
@@ -24,18 +36,47 @@ const (
 )
 
 var (
+	// FluxAggregatorTopics are the event topics of the v0.6 FluxAggregator,
+	// as registered with the eth package. Eagerly fails if not found.
+	FluxAggregatorTopics = eth.MustRegisterContractEventTopics(
+		FluxAggregatorName, eth.ContractABIVersionV6,
+		"NewRound", "AnswerUpdated", "SubmissionReceived", "OraclePermissionsUpdated")
 	// AggregatorNewRoundLogTopic20191220 is the NewRound filter topic for
-	// the FluxAggregator as of Dec. 20th 2019. Eagerly fails if not found.
-	AggregatorNewRoundLogTopic20191220 = eth.MustGetV6ContractEventID("FluxAggregator", "NewRound")
+	// the FluxAggregator as of Dec. 20th 2019.
+	AggregatorNewRoundLogTopic20191220 = FluxAggregatorTopics["NewRound"]
 	// AggregatorAnswerUpdatedLogTopic20191220 is the AnswerUpdated filter topic for
-	// the FluxAggregator as of Dec. 20th 2019. Eagerly fails if not found.
-	AggregatorAnswerUpdatedLogTopic20191220 = eth.MustGetV6ContractEventID("FluxAggregator", "AnswerUpdated")
+	// the FluxAggregator as of Dec. 20th 2019.
+	AggregatorAnswerUpdatedLogTopic20191220 = FluxAggregatorTopics["AnswerUpdated"]
+	// AggregatorSubmissionReceivedLogTopic20191220 is the SubmissionReceived
+	// filter topic for the FluxAggregator as of Dec. 20th 2019.
+	AggregatorSubmissionReceivedLogTopic20191220 = FluxAggregatorTopics["SubmissionReceived"]
+	// AggregatorOraclePermissionsUpdatedLogTopic20191220 is the
+	// OraclePermissionsUpdated filter topic for the FluxAggregator as of Dec.
+	// 20th 2019.
+	AggregatorOraclePermissionsUpdatedLogTopic20191220 = FluxAggregatorTopics["OraclePermissionsUpdated"]
 )
 
 type fluxAggregator struct {
 	ethsvc.ConnectedContract
 	ethClient eth.Client
 	address   common.Address
+
+	// The decimals and description of a deployed aggregator never change, so
+	// they are fetched once and memoized
+	metadataMutex sync.Mutex
+	decimals      *uint8
+	description   *string
+
+	// roundStateMaxAge is how long each oracle's latest round state is cached
+	// for EligibleToSubmit, or zero if round states aren't cached
+	roundStateMaxAge time.Duration
+	roundStateMutex  sync.Mutex
+	roundStates      map[common.Address]cachedRoundState
+}
+
+type cachedRoundState struct {
+	state     FluxAggregatorRoundState
+	fetchedAt time.Time
 }
 
 type LogNewRound struct {
@@ -52,9 +93,24 @@ type LogAnswerUpdated struct {
 	Timestamp *big.Int
 }
 
+type LogSubmissionReceived struct {
+	eth.Log
+	Submission *big.Int
+	Round      uint32
+	Oracle     common.Address
+}
+
+type LogOraclePermissionsUpdated struct {
+	eth.Log
+	Oracle      common.Address
+	Whitelisted bool
+}
+
 var fluxAggregatorLogTypes = map[common.Hash]interface{}{
-	AggregatorNewRoundLogTopic20191220:      LogNewRound{},
-	AggregatorAnswerUpdatedLogTopic20191220: LogAnswerUpdated{},
+	AggregatorNewRoundLogTopic20191220:                 LogNewRound{},
+	AggregatorAnswerUpdatedLogTopic20191220:            LogAnswerUpdated{},
+	AggregatorSubmissionReceivedLogTopic20191220:       LogSubmissionReceived{},
+	AggregatorOraclePermissionsUpdatedLogTopic20191220: LogOraclePermissionsUpdated{},
 }
 
 func NewFluxAggregator(address common.Address, ethClient eth.Client, logBroadcaster ethsvc.LogBroadcaster) (FluxAggregator, error) {
@@ -63,7 +119,34 @@ func NewFluxAggregator(address common.Address, ethClient eth.Client, logBroadcas
 		return nil, err
 	}
 	connectedContract := ethsvc.NewConnectedContract(codec, address, ethClient, logBroadcaster)
-	return &fluxAggregator{connectedContract, ethClient, address}, nil
+	return &fluxAggregator{
+		ConnectedContract: connectedContract,
+		ethClient:         ethClient,
+		address:           address,
+	}, nil
+}
+
+// NewFluxAggregatorWithRoundStateCache returns a FluxAggregator which caches
+// the round state it last fetched for each oracle, for up to maxAge, and
+// answers EligibleToSubmit from the cached state while it's fresh.
+// RoundState always fetches the current state, refreshing the cache.
+func NewFluxAggregatorWithRoundStateCache(
+	address common.Address,
+	ethClient eth.Client,
+	logBroadcaster ethsvc.LogBroadcaster,
+	maxAge time.Duration,
+) (FluxAggregator, error) {
+	if maxAge <= 0 {
+		return nil, fmt.Errorf("round state cache max age must be positive, got %s", maxAge)
+	}
+	fa, err := NewFluxAggregator(address, ethClient, logBroadcaster)
+	if err != nil {
+		return nil, err
+	}
+	rv := fa.(*fluxAggregator)
+	rv.roundStateMaxAge = maxAge
+	rv.roundStates = make(map[common.Address]cachedRoundState)
+	return rv, nil
 }
 
 func (fa *fluxAggregator) SubscribeToLogs(listener ethsvc.LogListener) (connected bool, _ ethsvc.UnsubscribeFunc) {
@@ -72,6 +155,15 @@ func (fa *fluxAggregator) SubscribeToLogs(listener ethsvc.LogListener) (connecte
 	)
 }
 
+// SubscribeWithHistory is ConnectedContract.SubscribeWithHistory, with the
+// logs decoded as SubscribeToLogs decodes them
+func (fa *fluxAggregator) SubscribeWithHistory(listener ethsvc.LogListener, fromBlock uint64) (ethsvc.UnsubscribeFunc, error) {
+	return fa.ConnectedContract.SubscribeWithHistory(
+		ethsvc.NewDecodingLogListener(fa, fluxAggregatorLogTypes, listener),
+		fromBlock,
+	)
+}
+
 type FluxAggregatorRoundState struct {
 	ReportableRoundID uint32   `abi:"_roundId"`
 	EligibleToSubmit  bool     `abi:"_eligibleToSubmit"`
@@ -87,11 +179,152 @@ func (rs FluxAggregatorRoundState) TimesOutAt() uint64 {
 	return rs.Timeout + rs.StartedAt
 }
 
+// Diff describes each field of other which differs from rs, in field order,
+// e.g. "ReportableRoundID 5→6".  It returns nil if they're the same.
+func (rs FluxAggregatorRoundState) Diff(other FluxAggregatorRoundState) []string {
+	var changes []string
+	changed := func(field string, from, to interface{}) {
+		changes = append(changes, fmt.Sprintf("%s %v→%v", field, from, to))
+	}
+	bigChanged := func(field string, from, to *big.Int) {
+		if (from == nil) != (to == nil) || (from != nil && from.Cmp(to) != 0) {
+			changed(field, from, to)
+		}
+	}
+
+	if rs.ReportableRoundID != other.ReportableRoundID {
+		changed("ReportableRoundID", rs.ReportableRoundID, other.ReportableRoundID)
+	}
+	if rs.EligibleToSubmit != other.EligibleToSubmit {
+		changed("EligibleToSubmit", rs.EligibleToSubmit, other.EligibleToSubmit)
+	}
+	bigChanged("LatestAnswer", rs.LatestAnswer, other.LatestAnswer)
+	if rs.Timeout != other.Timeout {
+		changed("Timeout", rs.Timeout, other.Timeout)
+	}
+	if rs.StartedAt != other.StartedAt {
+		changed("StartedAt", rs.StartedAt, other.StartedAt)
+	}
+	bigChanged("AvailableFunds", rs.AvailableFunds, other.AvailableFunds)
+	bigChanged("PaymentAmount", rs.PaymentAmount, other.PaymentAmount)
+	if rs.OracleCount != other.OracleCount {
+		changed("OracleCount", rs.OracleCount, other.OracleCount)
+	}
+	return changes
+}
+
 func (fa *fluxAggregator) RoundState(oracle common.Address) (FluxAggregatorRoundState, error) {
+	return fa.RoundStateWithContext(context.Background(), oracle)
+}
+
+// RoundStateWithContext is RoundState, abandoning the call with ctx's error if
+// ctx is done before it completes
+func (fa *fluxAggregator) RoundStateWithContext(ctx context.Context, oracle common.Address) (FluxAggregatorRoundState, error) {
 	var result FluxAggregatorRoundState
-	err := fa.Call(&result, "oracleRoundState", oracle)
+	err := fa.CallContext(ctx, &result, "oracleRoundState", oracle)
 	if err != nil {
-		return FluxAggregatorRoundState{}, errors.Wrap(err, "unable to encode message call")
+		return FluxAggregatorRoundState{}, errors.Wrap(err, "unable to get round state")
 	}
+	fa.cacheRoundState(oracle, result)
 	return result, nil
 }
+
+// EligibleToSubmit reports whether oracle may submit to the aggregator's
+// reportable round, from the cached round state if round states are cached and
+// it's fresh, and otherwise from the current round state
+func (fa *fluxAggregator) EligibleToSubmit(oracle common.Address) (bool, error) {
+	if state, ok := fa.cachedRoundState(oracle); ok {
+		return state.EligibleToSubmit, nil
+	}
+	state, err := fa.RoundState(oracle)
+	if err != nil {
+		return false, err
+	}
+	return state.EligibleToSubmit, nil
+}
+
+func (fa *fluxAggregator) cacheRoundState(oracle common.Address, state FluxAggregatorRoundState) {
+	if fa.roundStateMaxAge == 0 {
+		return
+	}
+	fa.roundStateMutex.Lock()
+	defer fa.roundStateMutex.Unlock()
+	fa.roundStates[oracle] = cachedRoundState{state, time.Now()}
+}
+
+// cachedRoundState returns oracle's cached round state, if it's cached and no
+// older than roundStateMaxAge
+func (fa *fluxAggregator) cachedRoundState(oracle common.Address) (FluxAggregatorRoundState, bool) {
+	if fa.roundStateMaxAge == 0 {
+		return FluxAggregatorRoundState{}, false
+	}
+	fa.roundStateMutex.Lock()
+	defer fa.roundStateMutex.Unlock()
+	cached, ok := fa.roundStates[oracle]
+	if !ok || time.Since(cached.fetchedAt) > fa.roundStateMaxAge {
+		return FluxAggregatorRoundState{}, false
+	}
+	return cached.state, true
+}
+
+func (fa *fluxAggregator) GetOracles() ([]common.Address, error) {
+	var oracles []common.Address
+	err := fa.Call(&oracles, "getOracles")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get oracles")
+	}
+	return oracles, nil
+}
+
+// LatestAnswer returns the aggregator's most recently reported answer
+func (fa *fluxAggregator) LatestAnswer() (*big.Int, error) {
+	var answer *big.Int
+	err := fa.Call(&answer, "latestAnswer")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get latest answer")
+	}
+	return answer, nil
+}
+
+func (fa *fluxAggregator) OracleCount() (uint32, error) {
+	var count uint32
+	err := fa.Call(&count, "oracleCount")
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get oracle count")
+	}
+	return count, nil
+}
+
+// Decimals returns the number of decimal places in the aggregator's answers
+func (fa *fluxAggregator) Decimals() (uint8, error) {
+	fa.metadataMutex.Lock()
+	defer fa.metadataMutex.Unlock()
+	if fa.decimals != nil {
+		return *fa.decimals, nil
+	}
+	var decimals uint8
+	err := fa.Call(&decimals, "decimals")
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get decimals")
+	}
+	fa.decimals = &decimals
+	return decimals, nil
+}
+
+// Description returns the aggregator's human-readable description of its
+// answers, e.g. "ETH / USD"
+func (fa *fluxAggregator) Description() (string, error) {
+	fa.metadataMutex.Lock()
+	defer fa.metadataMutex.Unlock()
+	if fa.description != nil {
+		return *fa.description, nil
+	}
+	var raw [32]byte
+	err := fa.Call(&raw, "description")
+	if err != nil {
+		return "", errors.Wrap(err, "unable to get description")
+	}
+	description := string(bytes.TrimRight(raw[:], "\x00"))
+	fa.description = &description
+	return description, nil
+}
@@ -1,17 +1,22 @@
 package contracts_test
 
 import (
+	"context"
 	"encoding"
+	"errors"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/eth"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	ethsvc "github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -52,9 +57,9 @@ func TestFluxAggregatorClient_RoundState(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			ethClient := new(mocks.Client)
 
-			ethClient.On("Call", mock.Anything, "eth_call", expectedCallArgs, "latest").Return(nil).
+			ethClient.On("CallContext", mock.Anything, mock.Anything, "eth_call", expectedCallArgs, "latest").Return(nil).
 				Run(func(args mock.Arguments) {
-					res := args.Get(0)
+					res := args.Get(1)
 					err := res.(encoding.TextUnmarshaler).UnmarshalText([]byte(test.response))
 					require.NoError(t, err)
 				})
@@ -75,6 +80,178 @@ func TestFluxAggregatorClient_RoundState(t *testing.T) {
 	}
 }
 
+func TestFluxAggregatorClient_EligibleToSubmit(t *testing.T) {
+	t.Parallel()
+
+	aggregatorAddress := cltest.NewAddress()
+	nodeAddr := cltest.NewAddress()
+	mockRoundState := func(ethClient *mocks.Client, eligible bool) *mock.Call {
+		return ethClient.On("CallContext", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				response := cltest.MakeRoundStateReturnData(1, eligible, 0, 0, 0, 0, 0, 3)
+				require.NoError(t, args.Get(1).(encoding.TextUnmarshaler).UnmarshalText([]byte(response)))
+			})
+	}
+
+	t.Run("reflects the round state's EligibleToSubmit", func(t *testing.T) {
+		for _, eligible := range []bool{true, false} {
+			ethClient := new(mocks.Client)
+			mockRoundState(ethClient, eligible).Once()
+			fa, err := contracts.NewFluxAggregator(aggregatorAddress, ethClient, nil)
+			require.NoError(t, err)
+
+			actual, err := fa.EligibleToSubmit(nodeAddr)
+			require.NoError(t, err)
+			assert.Equal(t, eligible, actual)
+			ethClient.AssertExpectations(t)
+		}
+	})
+
+	t.Run("fetches the round state every time without the cache", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		mockRoundState(ethClient, true).Twice()
+		fa, err := contracts.NewFluxAggregator(aggregatorAddress, ethClient, nil)
+		require.NoError(t, err)
+
+		_, err = fa.RoundState(nodeAddr)
+		require.NoError(t, err)
+		_, err = fa.EligibleToSubmit(nodeAddr)
+		require.NoError(t, err)
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("uses the cached round state", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		mockRoundState(ethClient, true).Once()
+		fa, err := contracts.NewFluxAggregatorWithRoundStateCache(aggregatorAddress, ethClient, nil, time.Hour)
+		require.NoError(t, err)
+
+		_, err = fa.RoundState(nodeAddr)
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			eligible, err := fa.EligibleToSubmit(nodeAddr)
+			require.NoError(t, err)
+			assert.True(t, eligible)
+		}
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("fetches the round state once the cached one is stale", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		mockRoundState(ethClient, true).Once()
+		mockRoundState(ethClient, false).Once()
+		fa, err := contracts.NewFluxAggregatorWithRoundStateCache(aggregatorAddress, ethClient, nil, time.Millisecond)
+		require.NoError(t, err)
+
+		eligible, err := fa.EligibleToSubmit(nodeAddr)
+		require.NoError(t, err)
+		assert.True(t, eligible)
+		time.Sleep(5 * time.Millisecond)
+		eligible, err = fa.EligibleToSubmit(nodeAddr)
+		require.NoError(t, err)
+		assert.False(t, eligible)
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("needs a positive max age", func(t *testing.T) {
+		_, err := contracts.NewFluxAggregatorWithRoundStateCache(aggregatorAddress, new(mocks.Client), nil, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestFluxAggregatorRoundState_Diff(t *testing.T) {
+	t.Parallel()
+
+	base := contracts.FluxAggregatorRoundState{
+		ReportableRoundID: 5,
+		EligibleToSubmit:  true,
+		LatestAnswer:      big.NewInt(100),
+		Timeout:           60,
+		StartedAt:         1000,
+		AvailableFunds:    big.NewInt(10),
+		PaymentAmount:     big.NewInt(1),
+		OracleCount:       3,
+	}
+	withChanges := func(change func(rs *contracts.FluxAggregatorRoundState)) contracts.FluxAggregatorRoundState {
+		rs := base
+		change(&rs)
+		return rs
+	}
+
+	tests := []struct {
+		name     string
+		other    contracts.FluxAggregatorRoundState
+		expected []string
+	}{
+		{"no change", base, nil},
+		{"equal big ints", withChanges(func(rs *contracts.FluxAggregatorRoundState) {
+			rs.LatestAnswer = big.NewInt(100)
+		}), nil},
+		{"single field", withChanges(func(rs *contracts.FluxAggregatorRoundState) {
+			rs.ReportableRoundID = 6
+		}), []string{"ReportableRoundID 5→6"}},
+		{"big int to nil", withChanges(func(rs *contracts.FluxAggregatorRoundState) {
+			rs.AvailableFunds = nil
+		}), []string{"AvailableFunds 10→<nil>"}},
+		{"multiple fields", withChanges(func(rs *contracts.FluxAggregatorRoundState) {
+			rs.ReportableRoundID = 6
+			rs.EligibleToSubmit = false
+			rs.LatestAnswer = big.NewInt(101)
+			rs.StartedAt = 1060
+			rs.OracleCount = 4
+		}), []string{
+			"ReportableRoundID 5→6",
+			"EligibleToSubmit true→false",
+			"LatestAnswer 100→101",
+			"StartedAt 1000→1060",
+			"OracleCount 3→4",
+		}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, base.Diff(test.other))
+		})
+	}
+}
+
+func TestFluxAggregatorClient_RoundStateWithContext_Cancelled(t *testing.T) {
+	ethClient := new(mocks.Client)
+	called := make(chan struct{})
+	// A slow client, which only returns once the call is abandoned
+	ethClient.On("CallContext", mock.Anything, mock.Anything, "eth_call", mock.Anything, "latest").
+		Return(func(ctx context.Context, _ interface{}, _ string, _ ...interface{}) error {
+			close(called)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+	fa, err := contracts.NewFluxAggregator(cltest.NewAddress(), ethClient, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-called
+		cancel()
+	}()
+
+	errs := make(chan error)
+	go func() {
+		_, err := fa.RoundStateWithContext(ctx, cltest.NewAddress())
+		errs <- err
+	}()
+	select {
+	case err := <-errs:
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.Canceled), "%v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("RoundStateWithContext did not return after its context was cancelled")
+	}
+	ethClient.AssertExpectations(t)
+}
+
 func TestFluxAggregatorClient_DecodesLogs(t *testing.T) {
 	fa, err := contracts.NewFluxAggregator(common.Address{}, nil, nil)
 	require.NoError(t, err)
@@ -112,4 +289,232 @@ func TestFluxAggregatorClient_DecodesLogs(t *testing.T) {
 	var badAnswerUpdatedLog BadLogAnswerUpdated
 	err = fa.UnpackLog(&badAnswerUpdatedLog, "AnswerUpdated", answerUpdatedLogRaw)
 	require.Error(t, err)
+
+	submissionReceivedLogRaw := cltest.LogFromFixture(t, "../../testdata/submission_received_log.json")
+	var submissionReceivedLog contracts.LogSubmissionReceived
+	err = fa.UnpackLog(&submissionReceivedLog, "SubmissionReceived", submissionReceivedLogRaw)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), submissionReceivedLog.Submission.Int64())
+	require.Equal(t, uint32(2), submissionReceivedLog.Round)
+	require.Equal(t, common.HexToAddress("f17f52151ebef6c7334fad080c5704d77216b732"), submissionReceivedLog.Oracle)
+
+	oraclePermissionsUpdatedLogRaw := cltest.LogFromFixture(t, "../../testdata/oracle_permissions_updated_log.json")
+	var oraclePermissionsUpdatedLog contracts.LogOraclePermissionsUpdated
+	err = fa.UnpackLog(&oraclePermissionsUpdatedLog, "OraclePermissionsUpdated", oraclePermissionsUpdatedLogRaw)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("f17f52151ebef6c7334fad080c5704d77216b732"), oraclePermissionsUpdatedLog.Oracle)
+	require.False(t, oraclePermissionsUpdatedLog.Whitelisted)
+}
+
+func TestFluxAggregatorClient_SubscribeToLogs_DecodesEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected interface{}
+	}{
+		{"NewRound", "../../testdata/new_round_log.json", &contracts.LogNewRound{}},
+		{"AnswerUpdated", "../../testdata/answer_updated_log.json", &contracts.LogAnswerUpdated{}},
+		{"SubmissionReceived", "../../testdata/submission_received_log.json", &contracts.LogSubmissionReceived{}},
+		{"OraclePermissionsUpdated", "../../testdata/oracle_permissions_updated_log.json", &contracts.LogOraclePermissionsUpdated{}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			rawLog := cltest.LogFromFixture(t, test.fixture)
+
+			// Capture the decoding listener the aggregator registers
+			var decodingListener ethsvc.LogListener
+			logBroadcaster := new(mocks.LogBroadcaster)
+			logBroadcaster.On("Register", rawLog.Address, mock.Anything).
+				Run(func(args mock.Arguments) { decodingListener = args.Get(1).(ethsvc.LogListener) }).
+				Return(true)
+			fa, err := contracts.NewFluxAggregator(rawLog.Address, nil, logBroadcaster)
+			require.NoError(t, err)
+
+			listener := new(mocks.LogListener)
+			listener.On("HandleLog", mock.Anything, nil).Once()
+			fa.SubscribeToLogs(listener)
+			require.NotNil(t, decodingListener)
+
+			var decoded interface{}
+			logBroadcast := new(mocks.LogBroadcast)
+			logBroadcast.On("Log").Return(&rawLog)
+			logBroadcast.On("UpdateLog", mock.Anything).Run(func(args mock.Arguments) { decoded = args.Get(0) })
+			decodingListener.HandleLog(logBroadcast, nil)
+
+			listener.AssertExpectations(t)
+			assert.IsType(t, test.expected, decoded)
+		})
+	}
+}
+
+func TestFluxAggregatorClient_GetOracles(t *testing.T) {
+	aggregatorAddress := cltest.NewAddress()
+	oracles := []common.Address{cltest.NewAddress(), cltest.NewAddress()}
+
+	ethClient := new(mocks.Client)
+	expectedCallArgs := eth.CallArgs{
+		To:   aggregatorAddress,
+		Data: utils.MustHash("getOracles()").Bytes()[:4],
+	}
+	ethClient.On("Call", mock.Anything, "eth_call", expectedCallArgs, "latest").Return(nil).
+		Run(func(args mock.Arguments) {
+			res := args.Get(0)
+			err := res.(encoding.TextUnmarshaler).UnmarshalText([]byte(cltest.MakeGetOraclesReturnData(oracles...)))
+			require.NoError(t, err)
+		})
+
+	fa, err := contracts.NewFluxAggregator(aggregatorAddress, ethClient, nil)
+	require.NoError(t, err)
+
+	actual, err := fa.GetOracles()
+	require.NoError(t, err)
+	assert.Equal(t, oracles, actual)
+	ethClient.AssertExpectations(t)
+}
+
+func TestFluxAggregatorClient_OracleCount(t *testing.T) {
+	aggregatorAddress := cltest.NewAddress()
+
+	ethClient := new(mocks.Client)
+	expectedCallArgs := eth.CallArgs{
+		To:   aggregatorAddress,
+		Data: utils.MustHash("oracleCount()").Bytes()[:4],
+	}
+	ethClient.On("Call", mock.Anything, "eth_call", expectedCallArgs, "latest").Return(nil).
+		Run(func(args mock.Arguments) {
+			res := args.Get(0)
+			err := res.(encoding.TextUnmarshaler).UnmarshalText([]byte(hexutil.Encode(utils.EVMWordUint64(3))))
+			require.NoError(t, err)
+		})
+
+	fa, err := contracts.NewFluxAggregator(aggregatorAddress, ethClient, nil)
+	require.NoError(t, err)
+
+	count, err := fa.OracleCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), count)
+	ethClient.AssertExpectations(t)
+}
+
+func TestFluxAggregatorClient_LatestAnswer(t *testing.T) {
+	aggregatorAddress := cltest.NewAddress()
+
+	ethClient := new(mocks.Client)
+	expectedCallArgs := eth.CallArgs{
+		To:   aggregatorAddress,
+		Data: utils.MustHash("latestAnswer()").Bytes()[:4],
+	}
+	ethClient.On("Call", mock.Anything, "eth_call", expectedCallArgs, "latest").Return(nil).
+		Run(func(args mock.Arguments) {
+			res := args.Get(0)
+			err := res.(encoding.TextUnmarshaler).UnmarshalText([]byte(hexutil.Encode(utils.EVMWordUint64(10000))))
+			require.NoError(t, err)
+		})
+
+	fa, err := contracts.NewFluxAggregator(aggregatorAddress, ethClient, nil)
+	require.NoError(t, err)
+
+	answer, err := fa.LatestAnswer()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(10000), answer)
+	ethClient.AssertExpectations(t)
+}
+
+func TestFluxAggregatorClient_GetOracles_CallFails(t *testing.T) {
+	ethClient := new(mocks.Client)
+	ethClient.On("Call", mock.Anything, "eth_call", mock.Anything, "latest").
+		Return(errors.New("execution reverted"))
+
+	fa, err := contracts.NewFluxAggregator(cltest.NewAddress(), ethClient, nil)
+	require.NoError(t, err)
+
+	_, err = fa.GetOracles()
+	require.Error(t, err)
+	ethClient.AssertExpectations(t)
+}
+
+func TestFluxAggregatorClient_Decimals_IsMemoized(t *testing.T) {
+	aggregatorAddress := cltest.NewAddress()
+
+	ethClient := new(mocks.Client)
+	expectedCallArgs := eth.CallArgs{
+		To:   aggregatorAddress,
+		Data: utils.MustHash("decimals()").Bytes()[:4],
+	}
+	ethClient.On("Call", mock.Anything, "eth_call", expectedCallArgs, "latest").
+		Return(errors.New("connection refused")).
+		Once()
+	ethClient.On("Call", mock.Anything, "eth_call", expectedCallArgs, "latest").Return(nil).
+		Run(func(args mock.Arguments) {
+			res := args.Get(0)
+			err := res.(encoding.TextUnmarshaler).UnmarshalText([]byte(hexutil.Encode(utils.EVMWordUint64(8))))
+			require.NoError(t, err)
+		}).
+		Once()
+
+	fa, err := contracts.NewFluxAggregator(aggregatorAddress, ethClient, nil)
+	require.NoError(t, err)
+
+	// Failures aren't memoized
+	_, err = fa.Decimals()
+	require.Error(t, err)
+
+	for i := 0; i < 3; i++ {
+		decimals, err := fa.Decimals()
+		require.NoError(t, err)
+		assert.Equal(t, uint8(8), decimals)
+	}
+	ethClient.AssertNumberOfCalls(t, "Call", 2)
+	ethClient.AssertExpectations(t)
+}
+
+func TestFluxAggregatorClient_Description_IsMemoized(t *testing.T) {
+	aggregatorAddress := cltest.NewAddress()
+
+	ethClient := new(mocks.Client)
+	expectedCallArgs := eth.CallArgs{
+		To:   aggregatorAddress,
+		Data: utils.MustHash("description()").Bytes()[:4],
+	}
+	var description [32]byte
+	copy(description[:], "ETH / USD")
+	ethClient.On("Call", mock.Anything, "eth_call", expectedCallArgs, "latest").Return(nil).
+		Run(func(args mock.Arguments) {
+			res := args.Get(0)
+			err := res.(encoding.TextUnmarshaler).UnmarshalText([]byte(hexutil.Encode(description[:])))
+			require.NoError(t, err)
+		}).
+		Once()
+
+	fa, err := contracts.NewFluxAggregator(aggregatorAddress, ethClient, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		actual, err := fa.Description()
+		require.NoError(t, err)
+		assert.Equal(t, "ETH / USD", actual)
+	}
+	ethClient.AssertNumberOfCalls(t, "Call", 1)
+	ethClient.AssertExpectations(t)
+}
+
+func TestFluxAggregator_RegisteredTopics(t *testing.T) {
+	topics, ok := eth.GetContractEventTopics(contracts.FluxAggregatorName, eth.ContractABIVersionV6)
+	require.True(t, ok)
+
+	assert.Equal(t, eth.MustGetV6ContractEventID("FluxAggregator", "NewRound"), topics["NewRound"])
+	assert.Equal(t, eth.MustGetV6ContractEventID("FluxAggregator", "AnswerUpdated"), topics["AnswerUpdated"])
+	assert.Equal(t, eth.MustGetV6ContractEventID("FluxAggregator", "SubmissionReceived"), topics["SubmissionReceived"])
+	assert.Equal(t, eth.MustGetV6ContractEventID("FluxAggregator", "OraclePermissionsUpdated"), topics["OraclePermissionsUpdated"])
+	assert.Len(t, topics, 4)
+
+	assert.Equal(t, topics["NewRound"], contracts.AggregatorNewRoundLogTopic20191220)
+	assert.Equal(t, topics["AnswerUpdated"], contracts.AggregatorAnswerUpdatedLogTopic20191220)
+	assert.ElementsMatch(t, []common.Hash{
+		contracts.AggregatorNewRoundLogTopic20191220,
+		contracts.AggregatorAnswerUpdatedLogTopic20191220,
+		contracts.AggregatorSubmissionReceivedLogTopic20191220,
+		contracts.AggregatorOraclePermissionsUpdatedLogTopic20191220,
+	}, topics.Topics())
 }
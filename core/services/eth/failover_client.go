@@ -0,0 +1,137 @@
+package eth
+
+import (
+	"context"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/logger"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+// logBroadcasterClient is the part of eth.Client which the LogBroadcaster uses
+type logBroadcasterClient interface {
+	GetLatestBlock() (eth.Block, error)
+	GetLogs(q ethereum.FilterQuery) ([]eth.Log, error)
+	SubscribeToLogs(ctx context.Context, channel chan<- eth.Log, q ethereum.FilterQuery) (eth.Subscription, error)
+	SubscribeToNewHeads(ctx context.Context, channel chan<- eth.BlockHeader) (eth.Subscription, error)
+}
+
+// failoverClient passes each call on to the active one of several clients,
+// starting with the first.  When a call to the active client fails, or one of
+// its subscriptions does, the next client in order is made active, wrapping
+// around after the last.  The failed call isn't retried itself: the
+// LogBroadcaster retries failed subscriptions and backfills anyway, and the
+// retry goes to the newly active client.
+//
+// A client stays active until it fails, so the broadcaster only returns to the
+// first client once the others have all failed.
+type failoverClient struct {
+	clients []eth.Client
+
+	mutex  sync.RWMutex
+	active int
+}
+
+var _ logBroadcasterClient = (*failoverClient)(nil)
+
+func newFailoverClient(clients []eth.Client) *failoverClient {
+	return &failoverClient{clients: clients}
+}
+
+func (c *failoverClient) current() (int, eth.Client) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.active, c.clients[c.active]
+}
+
+// demote makes the client after failed active, unless failed has already been
+// demoted, e.g. by a concurrent call on it which failed too
+func (c *failoverClient) demote(failed int, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.active != failed {
+		return
+	}
+	c.active = (failed + 1) % len(c.clients)
+	logger.Warnw("LogBroadcaster eth client failed, failing over to the next client",
+		"failedClient", failed,
+		"activeClient", c.active,
+		"error", err,
+	)
+}
+
+func (c *failoverClient) GetLatestBlock() (eth.Block, error) {
+	idx, client := c.current()
+	block, err := client.GetLatestBlock()
+	if err != nil {
+		c.demote(idx, err)
+	}
+	return block, err
+}
+
+func (c *failoverClient) GetLogs(q ethereum.FilterQuery) ([]eth.Log, error) {
+	idx, client := c.current()
+	logs, err := client.GetLogs(q)
+	if err != nil {
+		c.demote(idx, err)
+	}
+	return logs, err
+}
+
+func (c *failoverClient) SubscribeToLogs(ctx context.Context, channel chan<- eth.Log, q ethereum.FilterQuery) (eth.Subscription, error) {
+	idx, client := c.current()
+	sub, err := client.SubscribeToLogs(ctx, channel, q)
+	if err != nil {
+		c.demote(idx, err)
+		return nil, err
+	}
+	return newFailoverSubscription(sub, func(err error) { c.demote(idx, err) }), nil
+}
+
+func (c *failoverClient) SubscribeToNewHeads(ctx context.Context, channel chan<- eth.BlockHeader) (eth.Subscription, error) {
+	idx, client := c.current()
+	sub, err := client.SubscribeToNewHeads(ctx, channel)
+	if err != nil {
+		c.demote(idx, err)
+		return nil, err
+	}
+	return newFailoverSubscription(sub, func(err error) { c.demote(idx, err) }), nil
+}
+
+// failoverSubscription passes on the errors of an inner subscription, calling
+// onErr first, so that its client is demoted before the broadcaster
+// resubscribes
+type failoverSubscription struct {
+	inner    eth.Subscription
+	chErr    chan error
+	chDone   chan struct{}
+	doneOnce sync.Once
+}
+
+func newFailoverSubscription(inner eth.Subscription, onErr func(error)) *failoverSubscription {
+	sub := &failoverSubscription{
+		inner:  inner,
+		chErr:  make(chan error, 1),
+		chDone: make(chan struct{}),
+	}
+	go func() {
+		select {
+		case err, ok := <-inner.Err():
+			if ok && err != nil {
+				onErr(err)
+				sub.chErr <- err
+			}
+		case <-sub.chDone:
+		}
+	}()
+	return sub
+}
+
+func (s *failoverSubscription) Err() <-chan error { return s.chErr }
+
+func (s *failoverSubscription) Unsubscribe() {
+	s.doneOnce.Do(func() { close(s.chDone) })
+	s.inner.Unsubscribe()
+}
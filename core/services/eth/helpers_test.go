@@ -1,3 +1,22 @@
 package eth
 
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/eth"
+)
+
 var ExposedAppendLogChannel = appendLogChannel
+
+// SetWaitMinedPollInterval sets the interval at which WaitMined polls, and
+// returns a function restoring the previous one
+func SetWaitMinedPollInterval(d time.Duration) (restore func()) {
+	previous := waitMinedPollInterval
+	waitMinedPollInterval = d
+	return func() { waitMinedPollInterval = previous }
+}
+
+func ExposedFetchBackfillLogs(lb LogBroadcaster) ([]eth.Log, error) {
+	logs, _, err := lb.(*logBroadcaster).fetchBackfillLogs()
+	return logs, err
+}
@@ -1,9 +1,13 @@
 package eth
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"math/big"
 	"reflect"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/eth"
@@ -14,6 +18,8 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 )
 
 //go:generate mockery -name LogBroadcaster -output ../../internal/mocks/ -case=underscore
@@ -24,17 +30,268 @@ import (
 // of creating a new websocket subscription for each request, it multiplexes all subscriptions
 // to all of the relevant contracts over a single connection and forwards the logs to the
 // relevant subscribers.
+//
+// Backfilled logs are delivered in ascending (BlockNumber, Index) order.  Logs
+// from the live subscription are delivered in the order the Ethereum node sends
+// them, which is not guaranteed to be sorted, e.g. after a reorg.
+//
+// Logs are never dropped between the subscription and the listeners: every
+// stage hands logs on over an unbuffered channel, so a slow listener holds up
+// the subscription itself.  Should the Ethereum client's own subscription
+// buffer overflow as a result, the subscription fails with
+// rpc.ErrSubscriptionQueueOverflow, and is recreated with a backfill, like any
+// other failed subscription.
+//
+// Register and Unregister are safe to call concurrently with each other and
+// with logs being broadcast.  Logs are delivered to listeners one at a time,
+// by the same goroutine which adds and removes them, so once Unregister has
+// returned, the listener receives no further logs, including any which were
+// in flight when it was called.  As a consequence, neither may be called
+// from within a listener's callbacks.
+//
+// A LogBroadcaster runs at most once.  Start and Stop may each be called any
+// number of times, in any order: only the first call to each has any effect,
+// except that Start returns ErrLogBroadcasterStopped once the broadcaster has
+// been stopped, rather than restarting it.
 type LogBroadcaster interface {
 	utils.DependentAwaiter
-	Start()
+	Start() error
 	Register(address common.Address, listener LogListener) (connected bool)
+	RegisterAndWaitForConnect(ctx context.Context, address common.Address, listener LogListener) error
+	RegisterFromBlock(address common.Address, listener LogListener, fromBlock uint64) (connected bool)
+	RegisterWithFilter(address common.Address, listener LogListener, filter LogFilter) (connected bool)
 	Unregister(address common.Address, listener LogListener)
+	ReplayFromBlock(listener LogListener, fromBlock uint64) error
+	SnapshotRegistrations() []Registration
+	RestoreRegistrations(registrations []Registration)
 	Stop()
+	HealthReport() LogBroadcasterHealth
+	RecentDeliveries() []DeliveryRecord
+	ReorgHistory() []ReorgEvent
+	ReconciliationReport() (delivered uint64, consumed uint64, pendingKeys []LogKey)
+}
+
+// LogBroadcasterHealth describes the state of the LogBroadcaster's connection to
+// the Ethereum node, for use in readiness and liveness probes.
+type LogBroadcasterHealth struct {
+	// Subscribed is true while the broadcaster holds a live log subscription
+	Subscribed bool `json:"subscribed"`
+	// LastBlockSeen is the highest block number among the logs received
+	LastBlockSeen uint64 `json:"lastBlockSeen"`
+	// LastLogReceivedAt is the time the last log was received, or the zero
+	// time if no log has been received yet
+	LastLogReceivedAt time.Time `json:"lastLogReceivedAt"`
+	// BackfillStatus is the status of the most recent backfill
+	BackfillStatus BackfillStatus `json:"backfillStatus"`
+}
+
+// DeliveryRecord describes a single log delivered to a listener, for
+// debugging missed or unexpected log handling.  See
+// LogBroadcasterOptions.RecentDeliveriesSize.
+type DeliveryRecord struct {
+	Address     common.Address     `json:"address"`
+	BlockNumber uint64             `json:"blockNumber"`
+	Topic       common.Hash        `json:"topic"` // The log's first topic, if any
+	Consumer    models.LogConsumer `json:"consumer"`
+}
+
+// deliveryHistory is a fixed-size ring buffer of the most recent deliveries.
+// It's written by the resubscribe loop, and may be read from any goroutine.
+type deliveryHistory struct {
+	mutex   sync.Mutex
+	records []DeliveryRecord
+	next    int  // index of the slot to write next
+	full    bool // true once every slot has been written
+}
+
+func newDeliveryHistory(size uint) *deliveryHistory {
+	return &deliveryHistory{records: make([]DeliveryRecord, size)}
+}
+
+func (h *deliveryHistory) add(record DeliveryRecord) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.records[h.next] = record
+	h.next = (h.next + 1) % len(h.records)
+	h.full = h.full || h.next == 0
+}
+
+// newestFirst returns a copy of the recorded deliveries, most recent first
+func (h *deliveryHistory) newestFirst() []DeliveryRecord {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	count := h.next
+	if h.full {
+		count = len(h.records)
+	}
+	rv := make([]DeliveryRecord, count)
+	for i := range rv {
+		rv[i] = h.records[(h.next-1-i+len(h.records))%len(h.records)]
+	}
+	return rv
+}
+
+// LogKey identifies a log delivered to a consumer, as reported by
+// ReconciliationReport
+type LogKey struct {
+	BlockHash common.Hash        `json:"blockHash"`
+	LogIndex  uint               `json:"logIndex"`
+	Consumer  models.LogConsumer `json:"consumer"`
+}
+
+// reconciliationKey is the comparable form of a LogKey, whose consumer ID is a
+// pointer
+type reconciliationKey struct {
+	blockHash    common.Hash
+	logIndex     uint
+	consumerType string
+	consumerID   string
+}
+
+func newReconciliationKey(key LogKey) reconciliationKey {
+	rv := reconciliationKey{blockHash: key.BlockHash, logIndex: key.LogIndex, consumerType: key.Consumer.Type}
+	if key.Consumer.ID != nil {
+		rv.consumerID = key.Consumer.ID.String()
+	}
+	return rv
+}
+
+// consumptionReconciler tracks whether each of the most recent deliveries was
+// marked consumed.  Deliveries are added by the resubscribe loop, but may be
+// marked consumed from any goroutine, e.g. by a listener which consumes its
+// logs asynchronously.
+type consumptionReconciler struct {
+	mutex    sync.Mutex
+	size     int
+	order    []LogKey // oldest first
+	consumed map[reconciliationKey]bool
+}
+
+func newConsumptionReconciler(size uint) *consumptionReconciler {
+	return &consumptionReconciler{size: int(size), consumed: make(map[reconciliationKey]bool)}
+}
+
+// delivered adds key to the window, evicting the oldest delivery if it's full.
+// A log redelivered to the same consumer keeps its place and status.
+func (r *consumptionReconciler) delivered(key LogKey) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	rkey := newReconciliationKey(key)
+	if _, ok := r.consumed[rkey]; ok {
+		return
+	}
+	if len(r.order) >= r.size {
+		delete(r.consumed, newReconciliationKey(r.order[0]))
+		r.order = r.order[1:]
+	}
+	r.order = append(r.order, key)
+	r.consumed[rkey] = false
+}
+
+// markConsumed records the consumption of key, if it's still in the window
+func (r *consumptionReconciler) markConsumed(key LogKey) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	rkey := newReconciliationKey(key)
+	if _, ok := r.consumed[rkey]; ok {
+		r.consumed[rkey] = true
+	}
+}
+
+// report returns the number of deliveries in the window, how many of them
+// were consumed, and the keys of the rest, oldest first
+func (r *consumptionReconciler) report() (delivered uint64, consumed uint64, pendingKeys []LogKey) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, key := range r.order {
+		if r.consumed[newReconciliationKey(key)] {
+			consumed++
+		} else {
+			pendingKeys = append(pendingKeys, key)
+		}
+	}
+	return uint64(len(r.order)), consumed, pendingKeys
+}
+
+// ReorgEvent describes a reorg observed by the broadcaster: a log arrived from
+// a different block than it had already seen at the same height.  See
+// LogBroadcasterOptions.ReorgHistorySize.
+type ReorgEvent struct {
+	// FromHeight is the height at which the replaced block was seen.  Blocks
+	// below it which held no logs may have been replaced too.
+	FromHeight uint64 `json:"fromHeight"`
+	// Depth is the number of blocks from FromHeight up to the highest one seen
+	// before the reorg, all of which it replaced
+	Depth        uint64      `json:"depth"`
+	OldBlockHash common.Hash `json:"oldBlockHash"`
+	NewBlockHash common.Hash `json:"newBlockHash"`
+	DetectedAt   time.Time   `json:"detectedAt"`
+}
+
+// reorgHistory holds the most recent reorgs, oldest first.  It's written by
+// the resubscribe loop, and may be read from any goroutine.
+type reorgHistory struct {
+	mutex  sync.Mutex
+	events []ReorgEvent
+	size   int
+}
+
+func (h *reorgHistory) add(event ReorgEvent) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.events = append(h.events, event)
+	if len(h.events) > h.size {
+		h.events = h.events[len(h.events)-h.size:]
+	}
+}
+
+// newestFirst returns a copy of the recorded reorgs, most recent first
+func (h *reorgHistory) newestFirst() []ReorgEvent {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	rv := make([]ReorgEvent, len(h.events))
+	for i, event := range h.events {
+		rv[len(rv)-1-i] = event
+	}
+	return rv
 }
 
+// reorgTrackingDepth is how many blocks below the highest one seen the
+// broadcaster remembers the hashes of, to detect reorgs by.  Deeper reorgs
+// aren't recorded.
+const reorgTrackingDepth = 256
+
+// BackfillStatus is the status of the LogBroadcaster's most recent backfill
+type BackfillStatus string
+
+const (
+	// BackfillStatusIdle means no backfill has been attempted yet
+	BackfillStatusIdle BackfillStatus = "idle"
+	// BackfillStatusInProgress means logs are being fetched or delivered
+	BackfillStatusInProgress BackfillStatus = "inProgress"
+	// BackfillStatusFailed means the last attempt to fetch logs failed, and
+	// will be retried
+	BackfillStatusFailed BackfillStatus = "failed"
+	// BackfillStatusComplete means all backfilled logs have been delivered
+	BackfillStatusComplete BackfillStatus = "complete"
+)
+
 // The LogListener responds to log events through HandleLog, and contains setup/tear-down
 // callbacks in the On* functions. The Consumer function returns an instance of the LogConsumer, which
 // uniquely identifies the listener
+//
+// Each time the broadcaster (re)subscribes, OnConnect is called before any of
+// the logs backfilled for the new subscription are passed to HandleLog.  If
+// the listener is also a BackfillCompleteListener, OnBackfillComplete is then
+// called once every backfilled log has been passed to HandleLog, and before
+// any live logs are.
+//
+// The broadcaster never marks logs consumed itself.  HandleLog decides, log by
+// log, whether to call LogBroadcast.MarkConsumed.  A log it returns without
+// consuming is left unconsumed, and is delivered again by the next backfill
+// which covers it, or by ReplayFromBlock, so a listener can defer a log which
+// it can't act on yet, e.g. because it fails a business rule, simply by not
+// consuming it.
 type LogListener interface {
 	OnConnect()
 	OnDisconnect()
@@ -42,39 +299,463 @@ type LogListener interface {
 	Consumer() models.LogConsumer
 }
 
+// A BackfillCompleteListener is a LogListener which is notified when it has
+// caught up with the chain after connecting.  Logs which were delivered by
+// the previous subscription may still be redelivered after OnBackfillComplete,
+// and should be deduplicated with the LogBroadcast helpers as usual.
+type BackfillCompleteListener interface {
+	LogListener
+	OnBackfillComplete()
+}
+
+// A LogFilter reports whether a listener wants a log.  Logs it rejects aren't
+// delivered to the listener at all, so no consumption of them is recorded.
+type LogFilter func(log eth.Log) bool
+
+// A RemovedLogListener is a LogListener which is told about logs it was
+// delivered which have since been reorged out of the chain, so that it can
+// undo their effects.  It is only notified if the broadcaster was created with
+// LogBroadcasterOptions.NotifyRemovedLogs set.  Logs which are reorged out
+// before being delivered, e.g. while held for HeadSafetyDepth, are never
+// reported.
+type RemovedLogListener interface {
+	LogListener
+	OnLogRemoved(log eth.Log)
+}
+
+// A HeadListener is a LogListener which is told whenever the head advances, so
+// that it can check the confirmations of the logs it's waiting on without
+// waiting for another log to arrive.  Heads come from the new heads
+// subscription if LogBroadcasterOptions.SubscribeToHeads is set, and from
+// polling GetLatestBlock every HeadPollInterval otherwise.  They are delivered
+// in ascending order, and each at most once.
+type HeadListener interface {
+	LogListener
+	OnNewHead(head eth.Block)
+}
+
+var (
+	// ErrSubscriptionClosed is returned when the log subscription to the
+	// Ethereum node is closed with an error
+	ErrSubscriptionClosed = errors.New("log subscription closed")
+	// ErrBackfillFailed is returned when the logs since the backfill depth
+	// could not be fetched
+	ErrBackfillFailed = errors.New("backfilling logs failed")
+	// ErrConsumptionWrite is returned when a log consumption could not be
+	// recorded in the database
+	ErrConsumptionWrite = errors.New("unable to record log consumption")
+	// ErrListenerNotRegistered is returned when logs are replayed to a
+	// listener which isn't registered for any address
+	ErrListenerNotRegistered = errors.New("listener is not registered")
+	// ErrLogBroadcasterStopped is returned when starting a LogBroadcaster
+	// which has already been stopped
+	ErrLogBroadcasterStopped = errors.New("log broadcaster has been stopped")
+)
+
+// LogBroadcasterError wraps an underlying error with one of the sentinel
+// errors above, so that callers can check for either using errors.Is
+type LogBroadcasterError struct {
+	Kind error
+	Err  error
+}
+
+func newLogBroadcasterError(kind, err error) error {
+	return &LogBroadcasterError{Kind: kind, Err: err}
+}
+
+func (e *LogBroadcasterError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+}
+
+// Is allows errors.Is to match e against its sentinel Kind
+func (e *LogBroadcasterError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap returns the underlying error
+func (e *LogBroadcasterError) Unwrap() error {
+	return e.Err
+}
+
+// ListenerPanicPolicy determines how the LogBroadcaster treats listeners whose
+// HandleLog panics.  Panics are always recovered and logged, so that a faulty
+// listener cannot stall delivery to the others.
+type ListenerPanicPolicy struct {
+	// MaxConsecutivePanics is the number of consecutive panicking HandleLog
+	// calls after which a listener is unregistered.  Zero means the listener
+	// is never unregistered.
+	MaxConsecutivePanics uint
+}
+
+// DefaultListenerPanicPolicy recovers from listener panics, but never
+// unregisters the listener
+var DefaultListenerPanicPolicy = ListenerPanicPolicy{}
+
 type logBroadcaster struct {
-	ethClient     eth.Client
+	ethClient     logBroadcasterClient
 	orm           *orm.ORM
 	backfillDepth uint64
-	connected     bool
-
-	listeners        map[common.Address]map[LogListener]struct{}
-	chAddListener    chan registration
+	panicPolicy   ListenerPanicPolicy
+	chainID       *big.Int
+
+	// connected is written only by the resubscribe loop, but is read by
+	// Register, so is guarded by connectedMutex
+	connected      bool
+	connectedMutex sync.RWMutex
+
+	buildFilterQuery  FilterQueryBuilder
+	dependentsTimeout time.Duration
+
+	// stalenessTimeout is described in LogBroadcasterOptions.  latestHead is
+	// the most recently polled block number, against which head progress is
+	// measured.  It is only accessed by the resubscribe loop.
+	stalenessTimeout time.Duration
+	latestHead       uint64
+
+	// headSafetyDepth is described in LogBroadcasterOptions.  heldLogs are the
+	// logs received within headSafetyDepth blocks of latestHead, in the order
+	// they arrived.  They are only accessed by the resubscribe loop.
+	headSafetyDepth uint64
+	heldLogs        []eth.Log
+
+	subscribeToHeads bool
+
+	// headPollInterval and clock are described in LogBroadcasterOptions
+	headPollInterval time.Duration
+	clock            utils.Afterer
+
+	// notifiedHead is the latest head passed to the HeadListeners.  It is only
+	// accessed by the resubscribe loop.
+	notifiedHead uint64
+
+	// backfillBatches holds the batches of backfilled logs which haven't all
+	// been delivered yet.  It is only accessed by the resubscribe loop.
+	backfillBatches []*backfillBatch
+
+	// backfillPageSize and onBackfillPage are described in
+	// LogBroadcasterOptions.  pagedBackfills counts the paged backfills whose
+	// final page hasn't been received by the resubscribe loop yet, and is only
+	// accessed by it.  chBackfillPages passes each page to the loop before its
+	// logs are delivered.
+	backfillPageSize uint64
+	onBackfillPage   func(page []eth.Log)
+	pagedBackfills   int
+	chBackfillPages  chan *backfillBatch
+
+	// backfillSuspectGap and onBackfillSuspect are described in
+	// LogBroadcasterOptions
+	backfillSuspectGap uint64
+	onBackfillSuspect  func(from, to uint64)
+
+	// recentDeliveries records the latest deliveries, if
+	// LogBroadcasterOptions.RecentDeliveriesSize is non-zero, and is nil
+	// otherwise
+	recentDeliveries *deliveryHistory
+
+	// reconciler tracks the consumption of the latest deliveries, if
+	// LogBroadcasterOptions.ReconciliationWindowSize is non-zero, and is nil
+	// otherwise
+	reconciler *consumptionReconciler
+
+	// reorgHistory records the latest reorgs, if
+	// LogBroadcasterOptions.ReorgHistorySize is non-zero, and is nil otherwise.
+	// onReorg is described in LogBroadcasterOptions.  seenBlockHashes holds
+	// the hash of the block each log was received from, by height, for the
+	// reorgTrackingDepth blocks up to highestSeenBlock.  They are only
+	// accessed by the resubscribe loop.
+	reorgHistory     *reorgHistory
+	onReorg          func(event ReorgEvent)
+	deleteReorged    bool
+	seenBlockHashes  map[uint64]common.Hash
+	highestSeenBlock uint64
+
+	// notifyRemovedLogs is described in LogBroadcasterOptions
+	notifyRemovedLogs bool
+
+	// listeners holds the filter each listener was registered with, or nil if
+	// it wants every log
+	listeners        map[common.Address]map[LogListener]LogFilter
+	listenerPanics   map[registration]uint
+	chAddListener    chan registrationRequest
 	chRemoveListener chan registration
+	chReplay         chan replayRequest
+	chSnapshot       chan chan []Registration
+	chRestore        chan []registrationRequest
+
+	// historicalBackfills are the registrations made with RegisterFromBlock
+	// whose historical logs haven't been delivered yet.  They are held until
+	// the broadcaster is connected, and are only accessed by the resubscribe
+	// loop.
+	historicalBackfills []registrationRequest
+
+	// pendingConnects are the channels to close once the corresponding
+	// listeners registered with RegisterAndWaitForConnect are connected.  It is
+	// only accessed by the resubscribe loop.
+	pendingConnects map[registration]chan struct{}
+
+	health      LogBroadcasterHealth
+	healthMutex sync.RWMutex
+
+	// runState records whether Start and Stop have been called, so that each
+	// takes effect at most once
+	runState      logBroadcasterRunState
+	runStateMutex sync.Mutex
 
 	utils.DependentAwaiter
 	chStop chan struct{}
 	chDone chan struct{}
 }
 
-// NewLogBroadcaster creates a new instance of the logBroadcaster
+type logBroadcasterRunState int
+
+const (
+	logBroadcasterUnstarted logBroadcasterRunState = iota
+	logBroadcasterStarted
+	logBroadcasterStopped
+)
+
+// LogBroadcasterOptions configures the optional behaviour of the LogBroadcaster
+type LogBroadcasterOptions struct {
+	// PanicPolicy determines how listeners whose HandleLog panics are treated
+	PanicPolicy ListenerPanicPolicy
+	// StalenessTimeout is how long the log subscription may go without
+	// delivering a log before the broadcaster polls the latest block.  If the
+	// head hasn't advanced either, the subscription is presumed dead and is
+	// recreated.  Zero disables staleness detection.
+	StalenessTimeout time.Duration
+	// FilterQueryBuilder builds the query used to backfill logs.  If nil,
+	// DefaultFilterQueryBuilder is used.
+	FilterQueryBuilder FilterQueryBuilder
+	// DependentsTimeout is how long Start waits for the dependents to become
+	// ready before subscribing anyway.  Zero means it waits indefinitely.
+	DependentsTimeout time.Duration
+	// HeadSafetyDepth is how many blocks behind the latest head a log must be
+	// before it's delivered.  Backfills start backfillDepth blocks before
+	// head-HeadSafetyDepth, and newer logs, whether backfilled or live, are held
+	// until the head has advanced far enough.  Held logs which are reorged out
+	// are never delivered.  Zero means logs are delivered as soon as they are
+	// received, with no confirmations.
+	HeadSafetyDepth uint64
+	// SubscribeToHeads makes the broadcaster track the head through a new
+	// heads subscription while it's connected, rather than by polling
+	// GetLatestBlock.  If the head subscription fails, it falls back to polling
+	// until the next time it connects.
+	SubscribeToHeads bool
+	// HeadPollInterval is how often GetLatestBlock is polled, while there's no
+	// new heads subscription, to release held logs and notify HeadListeners.
+	// Polling is skipped entirely while a new heads subscription is active.
+	// Zero polls every second.
+	HeadPollInterval time.Duration
+	// Clock schedules the head polls.  If nil, the system clock is used.
+	Clock utils.Afterer
+	// BackfillPageSize bounds the number of logs fetched and delivered at a
+	// time while backfilling.  The backfill range is split into block ranges
+	// holding at most this many logs, and each page's consumptions are recorded
+	// before the next page is fetched.  A single block holding more logs is
+	// still delivered as one page.  Zero fetches the whole backfill at once.
+	BackfillPageSize uint64
+	// OnBackfillPage, if set, is called with each page of a paged backfill
+	// before its logs are delivered
+	OnBackfillPage func(page []eth.Log)
+	// BackfillSuspectGap is how many blocks the last log fetched for a backfill
+	// (or page of one) may fall short of the end of the requested range before
+	// the result is treated as suspect, since some RPC providers silently
+	// truncate eth_getLogs responses.  A suspect result is logged as a warning,
+	// and passed to OnBackfillSuspect, but is still delivered.  Empty results
+	// are never suspect, as they can't be told apart from quiet contracts.
+	// Zero disables the check.
+	BackfillSuspectGap uint64
+	// OnBackfillSuspect, if set, is called with the range of blocks, from, to
+	// inclusive, following the last log of a suspect backfill result
+	OnBackfillSuspect func(from, to uint64)
+	// RecentDeliveriesSize is how many of the most recent log deliveries to
+	// listeners are kept in memory, for RecentDeliveries to report.  Zero
+	// disables the record.
+	RecentDeliveriesSize uint
+	// ReconciliationWindowSize is how many of the most recent log deliveries
+	// to listeners are tracked until they're marked consumed, for
+	// ReconciliationReport to report.  Zero disables the tracking.
+	ReconciliationWindowSize uint
+	// NotifyRemovedLogs makes the broadcaster pass logs which the node reports
+	// as removed by a reorg to the OnLogRemoved method of listeners which
+	// implement RemovedLogListener.  Otherwise, removed logs are dropped.
+	NotifyRemovedLogs bool
+	// ReorgHistorySize is how many of the most recent reorgs are kept in
+	// memory, for ReorgHistory to report.  A reorg is detected when a log
+	// arrives from a different block than one already received from at the
+	// same height.  Zero disables the record.
+	ReorgHistorySize uint
+	// OnReorg, if set, is called with each reorg detected, e.g. to persist it.
+	// It's called from the resubscribe loop, so should return promptly.
+	OnReorg func(event ReorgEvent)
+	// DeleteReorgedConsumptions makes the broadcaster delete the recorded
+	// consumptions of the logs from each block it detects has been reorged
+	// out, as ReorgHistorySize describes, so that they don't linger once the
+	// log has been replaced.
+	DeleteReorgedConsumptions bool
+	// ChainID is the id of the chain the logs are received from.  Log
+	// consumptions are recorded and checked on this chain, so that those
+	// recorded on another, e.g. before the node was pointed at a different
+	// network, don't suppress its logs.  If nil, consumptions are unscoped by
+	// chain.
+	ChainID *big.Int
+}
+
+// BuildFilterQuery returns the query for the logs emitted since fromBlock by
+// every address with at least one listener, as DefaultFilterQueryBuilder
+// builds it.  The addresses are in ascending order, so that the query is
+// deterministic.  If no address has any listeners, the query has no
+// addresses, and so matches the logs of every contract: callers should check
+// for this first, as the broadcaster does.
+func BuildFilterQuery(fromBlock *big.Int, listeners map[common.Address][]LogListener) ethereum.FilterQuery {
+	var addresses []common.Address
+	for address, addressListeners := range listeners {
+		if len(addressListeners) > 0 {
+			addresses = append(addresses, address)
+		}
+	}
+	sortAddresses(addresses)
+	return DefaultFilterQueryBuilder(fromBlock, addresses)
+}
+
+// FilterQueryBuilder returns the query used to backfill the logs emitted by
+// the given addresses since fromBlock
+type FilterQueryBuilder func(fromBlock *big.Int, addresses []common.Address) ethereum.FilterQuery
+
+// DefaultFilterQueryBuilder queries for all logs emitted by the addresses from
+// fromBlock up to the latest block
+func DefaultFilterQueryBuilder(fromBlock *big.Int, addresses []common.Address) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		Addresses: addresses,
+	}
+}
+
+// defaultHeadPollInterval is how often the head is polled if
+// LogBroadcasterOptions.HeadPollInterval is zero
+const defaultHeadPollInterval = 1 * time.Second
+
+// DefaultLogBroadcasterOptions recovers from listener panics without
+// unregistering the listener.  Staleness detection and the dependents timeout
+// are off, so that startup waits for every dependent.
+var DefaultLogBroadcasterOptions = LogBroadcasterOptions{
+	PanicPolicy: DefaultListenerPanicPolicy,
+}
+
+// NewLogBroadcaster creates a new instance of the logBroadcaster, using the
+// DefaultLogBroadcasterOptions
 func NewLogBroadcaster(ethClient eth.Client, orm *orm.ORM, backfillDepth uint64) LogBroadcaster {
+	return NewLogBroadcasterWithOptions(ethClient, orm, backfillDepth, DefaultLogBroadcasterOptions)
+}
+
+// NewLogBroadcasterWithOptions creates a new instance of the logBroadcaster,
+// configured by opts
+func NewLogBroadcasterWithOptions(
+	ethClient eth.Client,
+	orm *orm.ORM,
+	backfillDepth uint64,
+	opts LogBroadcasterOptions,
+) LogBroadcaster {
+	return newLogBroadcaster(ethClient, orm, backfillDepth, opts)
+}
+
+// NewLogBroadcasterWithClients creates a new instance of the logBroadcaster,
+// configured by opts, which fails over between clients, e.g. connected to
+// redundant Ethereum nodes.  It subscribes and backfills through the first
+// client until a call to it fails, or its subscription does, and then moves on
+// to the next, in order.  A failed subscription is recreated on the next
+// client, so the listeners see OnDisconnect and then OnConnect, and the logs
+// they missed in between are backfilled by it, as after any other failure.
+func NewLogBroadcasterWithClients(
+	clients []eth.Client,
+	orm *orm.ORM,
+	backfillDepth uint64,
+	opts LogBroadcasterOptions,
+) (LogBroadcaster, error) {
+	switch len(clients) {
+	case 0:
+		return nil, errors.New("log broadcaster needs at least one eth client")
+	case 1:
+		return newLogBroadcaster(clients[0], orm, backfillDepth, opts), nil
+	default:
+		return newLogBroadcaster(newFailoverClient(clients), orm, backfillDepth, opts), nil
+	}
+}
+
+func newLogBroadcaster(
+	ethClient logBroadcasterClient,
+	orm *orm.ORM,
+	backfillDepth uint64,
+	opts LogBroadcasterOptions,
+) *logBroadcaster {
+	filterQueryBuilder := opts.FilterQueryBuilder
+	if filterQueryBuilder == nil {
+		filterQueryBuilder = DefaultFilterQueryBuilder
+	}
+	var recentDeliveries *deliveryHistory
+	if opts.RecentDeliveriesSize > 0 {
+		recentDeliveries = newDeliveryHistory(opts.RecentDeliveriesSize)
+	}
+	headPollInterval := opts.HeadPollInterval
+	if headPollInterval == 0 {
+		headPollInterval = defaultHeadPollInterval
+	}
+	var clock utils.Afterer = utils.Clock{}
+	if opts.Clock != nil {
+		clock = opts.Clock
+	}
+	var reconciler *consumptionReconciler
+	if opts.ReconciliationWindowSize > 0 {
+		reconciler = newConsumptionReconciler(opts.ReconciliationWindowSize)
+	}
+	var reorgs *reorgHistory
+	if opts.ReorgHistorySize > 0 {
+		reorgs = &reorgHistory{size: int(opts.ReorgHistorySize)}
+	}
 	return &logBroadcaster{
-		ethClient:        ethClient,
-		orm:              orm,
-		backfillDepth:    backfillDepth,
-		listeners:        make(map[common.Address]map[LogListener]struct{}),
-		chAddListener:    make(chan registration),
-		chRemoveListener: make(chan registration),
-		chStop:           make(chan struct{}),
-		chDone:           make(chan struct{}),
-		DependentAwaiter: utils.NewDependentAwaiter(),
+		ethClient:          ethClient,
+		orm:                orm,
+		backfillDepth:      backfillDepth,
+		panicPolicy:        opts.PanicPolicy,
+		chainID:            opts.ChainID,
+		stalenessTimeout:   opts.StalenessTimeout,
+		buildFilterQuery:   filterQueryBuilder,
+		dependentsTimeout:  opts.DependentsTimeout,
+		headSafetyDepth:    opts.HeadSafetyDepth,
+		subscribeToHeads:   opts.SubscribeToHeads,
+		headPollInterval:   headPollInterval,
+		clock:              clock,
+		backfillPageSize:   opts.BackfillPageSize,
+		onBackfillPage:     opts.OnBackfillPage,
+		backfillSuspectGap: opts.BackfillSuspectGap,
+		onBackfillSuspect:  opts.OnBackfillSuspect,
+		recentDeliveries:   recentDeliveries,
+		reconciler:         reconciler,
+		reorgHistory:       reorgs,
+		onReorg:            opts.OnReorg,
+		deleteReorged:      opts.DeleteReorgedConsumptions,
+		seenBlockHashes:    make(map[uint64]common.Hash),
+		notifyRemovedLogs:  opts.NotifyRemovedLogs,
+		chBackfillPages:    make(chan *backfillBatch),
+		listeners:          make(map[common.Address]map[LogListener]LogFilter),
+		listenerPanics:     make(map[registration]uint),
+		pendingConnects:    make(map[registration]chan struct{}),
+		chAddListener:      make(chan registrationRequest),
+		chRemoveListener:   make(chan registration),
+		chReplay:           make(chan replayRequest),
+		chSnapshot:         make(chan chan []Registration),
+		chRestore:          make(chan []registrationRequest),
+		chStop:             make(chan struct{}),
+		chDone:             make(chan struct{}),
+		DependentAwaiter:   utils.NewDependentAwaiter(),
+		health:             LogBroadcasterHealth{BackfillStatus: BackfillStatusIdle},
 	}
 }
 
 // The LogBroadcast type wraps an eth.Log but provides additional functionality
 // for determining whether or not the log has been consumed and for marking
-// the log as consumed
+// the log as consumed.  Unless MarkConsumed is called, the log remains
+// unconsumed once HandleLog returns.  See LogListener.
 type LogBroadcast interface {
 	Log() interface{}
 	UpdateLog(eth.RawLog)
@@ -86,6 +767,11 @@ type logBroadcast struct {
 	orm      *orm.ORM
 	log      eth.RawLog
 	consumer models.LogConsumer
+	batch    *backfillBatch
+	// replay is true if the log is being redelivered by ReplayFromBlock
+	replay     bool
+	chainID    *big.Int
+	reconciler *consumptionReconciler
 }
 
 func (lb *logBroadcast) Log() interface{} {
@@ -97,12 +783,59 @@ func (lb *logBroadcast) UpdateLog(newLog eth.RawLog) {
 }
 
 func (lb *logBroadcast) WasAlreadyConsumed() (bool, error) {
-	return lb.orm.HasConsumedLog(lb.log, lb.consumer)
+	if lb.replay {
+		return false, nil
+	}
+	lc := lb.consumption()
+	if lb.batch != nil && lb.batch.contains(lc) {
+		return true, nil
+	}
+	consumed, err := lb.orm.LogConsumptionExists(&lc)
+	if consumed {
+		// A listener which skips a log it already consumed isn't dropping it
+		lb.reconcile()
+	}
+	return consumed, err
 }
 
+// MarkConsumed records the consumption of a backfilled log as part of its
+// batch, if the batch hasn't been flushed yet, and otherwise records it
+// immediately in its own transaction.  A replayed log's existing consumption
+// record is left as it is.
 func (lb *logBroadcast) MarkConsumed() error {
-	lc := models.NewLogConsumption(lb.log, lb.consumer)
-	return lb.orm.CreateLogConsumption(&lc)
+	lc := lb.consumption()
+	if lb.replay {
+		consumed, err := lb.orm.LogConsumptionExists(&lc)
+		if err != nil {
+			return newLogBroadcasterError(ErrConsumptionWrite, err)
+		} else if consumed {
+			lb.reconcile()
+			return nil
+		}
+	}
+	if lb.batch != nil && lb.batch.add(lc) {
+		lb.reconcile()
+		return nil
+	}
+	if err := lb.orm.CreateLogConsumption(&lc); err != nil {
+		return newLogBroadcasterError(ErrConsumptionWrite, err)
+	}
+	lb.reconcile()
+	return nil
+}
+
+// reconcile records the log's consumption with the broadcaster's reconciler,
+// if it has one
+func (lb *logBroadcast) reconcile() {
+	if lb.reconciler != nil {
+		lb.reconciler.markConsumed(LogKey{lb.log.GetBlockHash(), lb.log.GetIndex(), lb.consumer})
+	}
+}
+
+// consumption is the record of the log's consumption by the consumer, on the
+// broadcaster's chain
+func (lb *logBroadcast) consumption() models.LogConsumption {
+	return models.NewLogConsumptionOnChain(lb.log, lb.consumer, lb.chainID)
 }
 
 type registration struct {
@@ -110,6 +843,93 @@ type registration struct {
 	listener LogListener
 }
 
+// registrationRequest is a registration to add, with the block from which its
+// listener wants historical logs delivered, or nil if it only wants the usual
+// backfill.  chConnected, if set, is closed once the listener is connected.
+// filter, if set, selects the logs delivered to the listener.
+type registrationRequest struct {
+	registration
+	fromBlock   *big.Int
+	chConnected chan struct{}
+	filter      LogFilter
+}
+
+// Registration is a listener's registration for the logs of an address, as
+// captured by SnapshotRegistrations.  FromBlock is the highest block the
+// broadcaster had received a log from, from which RestoreRegistrations
+// fetches the logs the listener may have missed, or nil if it hadn't received
+// any.
+type Registration struct {
+	Address   common.Address
+	Listener  LogListener
+	Filter    LogFilter
+	FromBlock *big.Int
+}
+
+type logKey struct {
+	blockHash common.Hash
+	index     uint
+}
+
+// backfillBatch collects the consumptions of a single backfill's logs, so that
+// they can all be recorded in one transaction once every log in the backfill
+// has been delivered.
+type backfillBatch struct {
+	// pending holds the backfilled logs which haven't been delivered yet.  It is
+	// only accessed by the resubscribe loop.
+	pending map[logKey]struct{}
+	// final is set on the last page of a paged backfill
+	final bool
+
+	mutex        sync.Mutex
+	consumptions []models.LogConsumption
+	flushed      bool
+}
+
+func newBackfillBatch(logs []eth.Log) *backfillBatch {
+	pending := make(map[logKey]struct{}, len(logs))
+	for _, log := range logs {
+		pending[logKey{log.BlockHash, log.Index}] = struct{}{}
+	}
+	return &backfillBatch{pending: pending}
+}
+
+// add appends lc to the batch, and returns false if the batch has already been
+// flushed, in which case the caller must record lc itself
+func (batch *backfillBatch) add(lc models.LogConsumption) bool {
+	batch.mutex.Lock()
+	defer batch.mutex.Unlock()
+	if batch.flushed {
+		return false
+	}
+	batch.consumptions = append(batch.consumptions, lc)
+	return true
+}
+
+// contains is true iff a consumption of the same log by the same consumer is
+// waiting to be flushed
+func (batch *backfillBatch) contains(lc models.LogConsumption) bool {
+	batch.mutex.Lock()
+	defer batch.mutex.Unlock()
+	for _, c := range batch.consumptions {
+		if c.BlockHash == lc.BlockHash && c.LogIndex == lc.LogIndex &&
+			c.ConsumerType == lc.ConsumerType && c.ConsumerID == lc.ConsumerID {
+			return true
+		}
+	}
+	return false
+}
+
+// drain marks the batch as flushed, and returns the consumptions collected so far
+func (batch *backfillBatch) drain() []models.LogConsumption {
+	batch.mutex.Lock()
+	defer batch.mutex.Unlock()
+	batch.flushed = true
+	consumptions := batch.consumptions
+	batch.consumptions = nil
+	return consumptions
+}
+
 // A ManagedSubscription acts as wrapper for the eth.Subscription. Specifically, the
 // ManagedSubscription closes the log channel as soon as the unsubscribe request is made
 type ManagedSubscription interface {
@@ -138,20 +958,54 @@ func (sub managedSubscription) Unsubscribe() {
 
 const logBroadcasterCursorName = "logBroadcaster"
 
-func (b *logBroadcaster) Start() {
+// Start waits for the broadcaster's dependents to be ready, and then subscribes
+// to logs.  Starting a running broadcaster is a no-op, and starting a stopped
+// one returns ErrLogBroadcasterStopped.
+func (b *logBroadcaster) Start() error {
+	b.runStateMutex.Lock()
+	defer b.runStateMutex.Unlock()
+	switch b.runState {
+	case logBroadcasterStarted:
+		return nil
+	case logBroadcasterStopped:
+		return ErrLogBroadcasterStopped
+	}
+	b.runState = logBroadcasterStarted
 	go b.awaitInitialSubscribers()
+	return nil
 }
 
 func (b *logBroadcaster) awaitInitialSubscribers() {
+	var chTimeout <-chan time.Time
+	if b.dependentsTimeout > 0 {
+		timeout := time.NewTimer(b.dependentsTimeout)
+		defer timeout.Stop()
+		chTimeout = timeout.C
+	}
+
 	for {
 		select {
 		case r := <-b.chAddListener:
 			b.onAddListener(r)
 
+		case requests := <-b.chRestore:
+			b.onRestoreRegistrations(requests)
+
+		case chRegistrations := <-b.chSnapshot:
+			chRegistrations <- b.snapshotRegistrations()
+
 		case <-b.DependentAwaiter.AwaitDependents():
 			go b.startResubscribeLoop()
 			return
 
+		case <-chTimeout:
+			logger.Warnw("LogBroadcaster timed out waiting for dependents to be ready, subscribing anyway",
+				"pendingDependents", b.PendingDependents(),
+				"timeout", b.dependentsTimeout,
+			)
+			go b.startResubscribeLoop()
+			return
+
 		case <-b.chStop:
 			close(b.chDone)
 			return
@@ -159,25 +1013,138 @@ func (b *logBroadcaster) awaitInitialSubscribers() {
 	}
 }
 
+// addresses returns the addresses with registered listeners, in ascending order
 func (b *logBroadcaster) addresses() []common.Address {
 	var addresses []common.Address
 	for address := range b.listeners {
 		addresses = append(addresses, address)
 	}
+	sortAddresses(addresses)
 	return addresses
 }
 
+func sortAddresses(addresses []common.Address) {
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+}
+
+// Stop unsubscribes from logs and waits for the broadcaster to shut down.
+// Stopping a stopped broadcaster is a no-op, and stopping one which was never
+// started prevents it from starting.
 func (b *logBroadcaster) Stop() {
+	b.runStateMutex.Lock()
+	defer b.runStateMutex.Unlock()
+	previous := b.runState
+	b.runState = logBroadcasterStopped
+	if previous == logBroadcasterStopped {
+		return
+	}
 	close(b.chStop)
-	<-b.chDone
+	if previous == logBroadcasterStarted {
+		<-b.chDone
+	}
+}
+
+// HealthReport returns a snapshot of the broadcaster's connection state
+func (b *logBroadcaster) HealthReport() LogBroadcasterHealth {
+	b.healthMutex.RLock()
+	defer b.healthMutex.RUnlock()
+	return b.health
+}
+
+// RecentDeliveries returns the most recent log deliveries to listeners, newest
+// first, or nil if LogBroadcasterOptions.RecentDeliveriesSize is zero
+func (b *logBroadcaster) RecentDeliveries() []DeliveryRecord {
+	if b.recentDeliveries == nil {
+		return nil
+	}
+	return b.recentDeliveries.newestFirst()
+}
+
+// ReconciliationReport returns the number of logs delivered to listeners
+// within the window set by LogBroadcasterOptions.ReconciliationWindowSize, how
+// many of those were marked consumed, or found to be consumed already, and
+// the keys of the rest, oldest first.  It reports nothing if the window is
+// disabled.
+func (b *logBroadcaster) ReconciliationReport() (delivered uint64, consumed uint64, pendingKeys []LogKey) {
+	if b.reconciler == nil {
+		return 0, 0, nil
+	}
+	return b.reconciler.report()
+}
+
+// ReorgHistory returns the most recent reorgs observed, newest first, or nil
+// if LogBroadcasterOptions.ReorgHistorySize is zero
+func (b *logBroadcaster) ReorgHistory() []ReorgEvent {
+	if b.reorgHistory == nil {
+		return nil
+	}
+	return b.reorgHistory.newestFirst()
+}
+
+func (b *logBroadcaster) updateHealth(update func(health *LogBroadcasterHealth)) {
+	b.healthMutex.Lock()
+	defer b.healthMutex.Unlock()
+	update(&b.health)
+}
+
+func (b *logBroadcaster) setBackfillStatus(status BackfillStatus) {
+	b.updateHealth(func(health *LogBroadcasterHealth) { health.BackfillStatus = status })
 }
 
 func (b *logBroadcaster) Register(address common.Address, listener LogListener) (connected bool) {
+	return b.register(registrationRequest{registration{address, listener}, nil, nil, nil})
+}
+
+// RegisterFromBlock is Register, but the logs emitted by address since
+// fromBlock are also fetched, with a GetLogs call scoped to address alone, and
+// delivered to listener once the broadcaster is connected.  Other listeners
+// are unaffected.  These logs may overlap with the usual backfill, so the
+// listener should deduplicate them with WasAlreadyConsumed as usual.
+func (b *logBroadcaster) RegisterFromBlock(address common.Address, listener LogListener, fromBlock uint64) (connected bool) {
+	return b.register(registrationRequest{registration{address, listener}, new(big.Int).SetUint64(fromBlock), nil, nil})
+}
+
+// RegisterWithFilter is Register, but only the logs for which filter returns
+// true are delivered to listener, whether live, backfilled or replayed.  A nil
+// filter delivers every log, as Register does.
+func (b *logBroadcaster) RegisterWithFilter(address common.Address, listener LogListener, filter LogFilter) (connected bool) {
+	return b.register(registrationRequest{registration{address, listener}, nil, nil, filter})
+}
+
+// RegisterAndWaitForConnect is Register, but blocks until the listener is
+// connected: until its OnConnect has been called, or, if the broadcaster is
+// already subscribed to logs from address, until it has been added to that
+// subscription, in which case OnConnect isn't called, just as Register would
+// return true.  It returns ctx's error if ctx is done first, and
+// ErrLogBroadcasterStopped if the broadcaster is stopped first.  The listener
+// remains registered if it was added before either happened.
+func (b *logBroadcaster) RegisterAndWaitForConnect(ctx context.Context, address common.Address, listener LogListener) error {
+	chConnected := make(chan struct{})
 	select {
-	case b.chAddListener <- registration{address, listener}:
+	case b.chAddListener <- registrationRequest{registration{address, listener}, nil, chConnected, nil}:
+	case <-ctx.Done():
+		return ctx.Err()
 	case <-b.chStop:
+		return ErrLogBroadcasterStopped
 	}
-	return b.connected
+	select {
+	case <-chConnected:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.chStop:
+		return ErrLogBroadcasterStopped
+	}
+}
+
+func (b *logBroadcaster) register(r registrationRequest) (connected bool) {
+	select {
+	case b.chAddListener <- r:
+	case <-b.chStop:
+	}
+	return b.isConnected()
 }
 
 func (b *logBroadcaster) Unregister(address common.Address, listener LogListener) {
@@ -187,6 +1154,119 @@ func (b *logBroadcaster) Unregister(address common.Address, listener LogListener
 	}
 }
 
+type replayRequest struct {
+	listener  LogListener
+	fromBlock uint64
+	chErr     chan error
+}
+
+// ReplayFromBlock fetches the logs emitted since fromBlock by the addresses
+// listener is registered for, and redelivers them to listener alone, in
+// ascending (BlockNumber, Index) order.  It returns once they have all been
+// passed to HandleLog, or ErrLogBroadcasterStopped if the broadcaster is
+// stopped first.
+//
+// Replay intentionally bypasses deduplication: WasAlreadyConsumed reports
+// every replayed log as unconsumed, so that a listener can reprocess logs it
+// has already consumed, e.g. after recovering from a bug.  Existing
+// consumption records are left untouched, and MarkConsumed only records
+// consumptions which are missing.
+func (b *logBroadcaster) ReplayFromBlock(listener LogListener, fromBlock uint64) error {
+	request := replayRequest{listener, fromBlock, make(chan error, 1)}
+	select {
+	case b.chReplay <- request:
+	case <-b.chStop:
+		return ErrLogBroadcasterStopped
+	}
+	return <-request.chErr
+}
+
+// onReplay redelivers the requested logs to the listener
+func (b *logBroadcaster) onReplay(request replayRequest) error {
+	var addresses []common.Address
+	for address, listeners := range b.listeners {
+		if _, registered := listeners[request.listener]; registered {
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) == 0 {
+		return ErrListenerNotRegistered
+	}
+
+	q := b.buildFilterQuery(new(big.Int).SetUint64(request.fromBlock), addresses)
+	logs, err := b.ethClient.GetLogs(q)
+	if err != nil {
+		return errors.Wrapf(err, "unable to fetch logs to replay from block %d", request.fromBlock)
+	}
+	sortLogs(logs)
+
+	for _, rawLog := range logs {
+		if rawLog.Removed {
+			continue
+		}
+		b.handleLog(registration{rawLog.Address, request.listener}, rawLog.Copy(), nil, true)
+	}
+	logger.Debugw("LogBroadcaster replayed logs to listener",
+		"fromBlock", request.fromBlock,
+		"logs", len(logs),
+		"listener", fmt.Sprintf("%T", request.listener),
+	)
+	return nil
+}
+
+// SnapshotRegistrations returns the broadcaster's current registrations, in
+// ascending order of address, e.g. so that they can be restored with
+// RestoreRegistrations to a broadcaster rebuilt with a new configuration or
+// client.  It returns nil if the broadcaster is stopped.
+func (b *logBroadcaster) SnapshotRegistrations() []Registration {
+	chRegistrations := make(chan []Registration, 1)
+	select {
+	case b.chSnapshot <- chRegistrations:
+	case <-b.chStop:
+		return nil
+	}
+	return <-chRegistrations
+}
+
+func (b *logBroadcaster) snapshotRegistrations() []Registration {
+	var fromBlock *big.Int
+	if health := b.HealthReport(); !health.LastLogReceivedAt.IsZero() {
+		fromBlock = new(big.Int).SetUint64(health.LastBlockSeen)
+	}
+	var registrations []Registration
+	for _, address := range b.addresses() {
+		for listener, filter := range b.listeners[address] {
+			registrations = append(registrations, Registration{address, listener, filter, fromBlock})
+		}
+	}
+	return registrations
+}
+
+// RestoreRegistrations registers each of registrations, as
+// RegisterWithFilter would, together, so that a single resubscription covers
+// all of their addresses.  The logs emitted since each one's FromBlock, if
+// set, are delivered to its listener once the broadcaster is connected, as
+// RegisterFromBlock does, so that it continues from where it left off.
+// Restoring a registration which already exists panics, as registering it
+// again would.
+func (b *logBroadcaster) RestoreRegistrations(registrations []Registration) {
+	requests := make([]registrationRequest, len(registrations))
+	for i, r := range registrations {
+		requests[i] = registrationRequest{registration{r.Address, r.Listener}, r.FromBlock, nil, r.Filter}
+	}
+	select {
+	case b.chRestore <- requests:
+	case <-b.chStop:
+	}
+}
+
+func (b *logBroadcaster) onRestoreRegistrations(requests []registrationRequest) (needsResubscribe bool) {
+	for _, r := range requests {
+		needsResubscribe = b.onAddListener(r) || needsResubscribe
+	}
+	return needsResubscribe
+}
+
 // The subscription is closed in two cases:
 //   - intentionally, when the set of contracts we're listening to changes
 //   - on a connection error
@@ -199,6 +1279,7 @@ func (b *logBroadcaster) startResubscribeLoop() {
 
 	var subscription ManagedSubscription = newNoopSubscription()
 	defer func() { subscription.Unsubscribe() }()
+	defer b.flushBackfillBatches()
 
 	var chRawLogs chan eth.Log
 	for {
@@ -206,71 +1287,275 @@ func (b *logBroadcaster) startResubscribeLoop() {
 		if abort {
 			return
 		}
-
-		chBackfilledLogs, abort := b.backfillLogs()
-		if abort {
+
+		chBackfilledLogs, handoffBlock, abort := b.backfillLogs()
+		if abort {
+			return
+		}
+
+		// Each time this loop runs, chRawLogs is reconstituted as:
+		//     remaining logs from last subscription <= backfilled logs <= logs from new subscription
+		// The backfill runs up to handoffBlock, the head when it started, and the new
+		// subscription's logs which it already delivered are dropped.  There may still
+		// be duplicated logs in this channel, e.g. from the last subscription.  It is the
+		// responsibility of subscribers to account for this using the helpers on the
+		// LogBroadcast type.
+		chRawLogs = appendLogChannel(chRawLogs,
+			handOffToLiveLogs(chBackfilledLogs, newSubscription.Logs(), handoffBlock))
+		subscription.Unsubscribe()
+		subscription = newSubscription
+
+		b.notifyConnect()
+		b.deliverHistoricalBackfills()
+		if !b.backfillInProgress() {
+			// Nothing was backfilled, or it has already been delivered
+			b.notifyBackfillComplete()
+		}
+		shouldResubscribe, err := b.process(subscription, chRawLogs)
+		if err != nil {
+			logger.Error(err)
+			b.notifyDisconnect()
+			continue
+		} else if !shouldResubscribe {
+			b.notifyDisconnect()
+			return
+		}
+	}
+}
+
+// backfillLogs starts delivering the logs from backfillDepth blocks before the
+// safe head, up to handoffBlock, the head when it started
+func (b *logBroadcaster) backfillLogs() (chBackfilledLogs chan eth.Log, handoffBlock uint64, abort bool) {
+	if len(b.listeners) == 0 {
+		ch := make(chan eth.Log)
+		close(ch)
+		return ch, 0, false
+	}
+
+	if b.backfillPageSize > 0 {
+		return b.backfillLogsInPages()
+	}
+
+	b.setBackfillStatus(BackfillStatusInProgress)
+	abort = utils.RetryWithBackoff(b.chStop, "backfilling logs", func() error {
+		var logs []eth.Log
+		var err error
+		logs, handoffBlock, err = b.fetchBackfillLogs()
+		if err != nil {
+			b.setBackfillStatus(BackfillStatusFailed)
+			return err
+		}
+		b.setBackfillStatus(BackfillStatusInProgress)
+		if len(logs) > 0 {
+			b.backfillBatches = append(b.backfillBatches, newBackfillBatch(logs))
+		}
+
+		chBackfilledLogs = make(chan eth.Log)
+		go b.deliverBackfilledLogs(logs, chBackfilledLogs)
+		return nil
+
+	})
+	return
+}
+
+// backfillLogsInPages starts delivering the backfill in pages of at most
+// backfillPageSize logs, fetching each page once the previous one has been
+// delivered
+func (b *logBroadcaster) backfillLogsInPages() (chBackfilledLogs chan eth.Log, handoffBlock uint64, abort bool) {
+	b.setBackfillStatus(BackfillStatusInProgress)
+	var fromBlock, toBlock uint64
+	abort = utils.RetryWithBackoff(b.chStop, "backfilling logs", func() error {
+		var err error
+		fromBlock, toBlock, err = b.backfillRange()
+		if err != nil {
+			b.setBackfillStatus(BackfillStatusFailed)
+			return err
+		}
+		return nil
+	})
+	if abort {
+		return nil, 0, true
+	}
+
+	b.pagedBackfills++
+	chBackfilledLogs = make(chan eth.Log)
+	go b.deliverBackfillPages(fromBlock, toBlock, chBackfilledLogs)
+	return chBackfilledLogs, toBlock, false
+}
+
+// deliverBackfillPages fetches and delivers the logs from fromBlock to toBlock,
+// in pages of at most backfillPageSize logs.  The block range of each page is
+// adapted to the density of the logs: it starts at backfillPageSize blocks,
+// and is halved when a page holds too many logs, or doubled when it holds
+// less than half as many as it could.
+func (b *logBroadcaster) deliverBackfillPages(fromBlock, toBlock uint64, chBackfilledLogs chan<- eth.Log) {
+	defer close(chBackfilledLogs)
+	span := b.backfillPageSize
+	for {
+		pageEnd := fromBlock + span - 1
+		if pageEnd > toBlock || pageEnd < fromBlock {
+			pageEnd = toBlock // Overflow protection
+		}
+
+		var logs []eth.Log
+		abort := utils.RetryWithBackoff(b.chStop, "backfilling page of logs", func() error {
+			var err error
+			logs, err = b.fetchBackfillPage(fromBlock, pageEnd)
+			if err != nil {
+				b.setBackfillStatus(BackfillStatusFailed)
+				return err
+			}
+			b.setBackfillStatus(BackfillStatusInProgress)
+			return nil
+		})
+		if abort {
+			return
+		}
+		if uint64(len(logs)) > b.backfillPageSize && pageEnd > fromBlock {
+			span = (pageEnd - fromBlock + 1) / 2
+			continue
+		}
+
+		page := newBackfillBatch(logs)
+		page.final = pageEnd == toBlock
+		select {
+		case b.chBackfillPages <- page:
+		case <-b.chStop:
+			return
+		}
+		if b.onBackfillPage != nil {
+			b.onBackfillPage(logs)
+		}
+		for _, log := range logs {
+			select {
+			case chBackfilledLogs <- log:
+			case <-b.chStop:
+				return
+			}
+		}
+		if page.final {
+			b.setBackfillStatus(BackfillStatusComplete)
 			return
 		}
 
-		// Each time this loop runs, chRawLogs is reconstituted as:
-		//     remaining logs from last subscription <= backfilled logs <= logs from new subscription
-		// There will be duplicated logs in this channel.  It is the responsibility of subscribers
-		// to account for this using the helpers on the LogBroadcast type.
-		chRawLogs = appendLogChannel(chRawLogs, chBackfilledLogs)
-		chRawLogs = appendLogChannel(chRawLogs, newSubscription.Logs())
-		subscription.Unsubscribe()
-		subscription = newSubscription
-
-		b.notifyConnect()
-		shouldResubscribe, err := b.process(subscription, chRawLogs)
-		if err != nil {
-			logger.Error(err)
-			b.notifyDisconnect()
-			continue
-		} else if !shouldResubscribe {
-			b.notifyDisconnect()
-			return
+		fromBlock = pageEnd + 1
+		if uint64(len(logs)) < b.backfillPageSize/2 && span*2 > span {
+			span *= 2
 		}
 	}
 }
 
-func (b *logBroadcaster) backfillLogs() (chBackfilledLogs chan eth.Log, abort bool) {
-	if len(b.listeners) == 0 {
-		ch := make(chan eth.Log)
-		close(ch)
-		return ch, false
+// fetchBackfillPage fetches the logs for the registered addresses between
+// fromBlock and toBlock, inclusive.  Any error it returns wraps
+// ErrBackfillFailed.
+func (b *logBroadcaster) fetchBackfillPage(fromBlock, toBlock uint64) ([]eth.Log, error) {
+	q := b.buildFilterQuery(big.NewInt(int64(fromBlock)), b.addresses())
+	if q.ToBlock == nil || q.ToBlock.Uint64() > toBlock {
+		q.ToBlock = big.NewInt(int64(toBlock))
+	}
+	logs, err := b.ethClient.GetLogs(q)
+	if err != nil {
+		return nil, newLogBroadcasterError(ErrBackfillFailed, err)
 	}
+	sortLogs(logs)
+	b.checkBackfillCompleteness(logs, q.ToBlock.Uint64())
+	return logs, nil
+}
 
-	abort = utils.RetryWithBackoff(b.chStop, "backfilling logs", func() error {
-		latestBlock, err := b.ethClient.GetLatestBlock()
-		if err != nil {
-			return err
-		}
-		currentHeight := uint64(latestBlock.Number)
+// registerBackfillPage registers a page of a paged backfill, before any of its logs
+// are delivered
+func (b *logBroadcaster) registerBackfillPage(page *backfillBatch) {
+	if page.final {
+		b.pagedBackfills--
+	}
+	if len(page.pending) > 0 {
+		b.backfillBatches = append(b.backfillBatches, page)
+	} else if !b.backfillInProgress() {
+		b.notifyBackfillComplete()
+	}
+}
 
-		// Backfill from `backfillDepth` blocks ago.  It's up to the subscribers to
-		// filter out logs they've already dealt with.
-		fromBlock := currentHeight - b.backfillDepth
-		if fromBlock > currentHeight {
-			fromBlock = 0 // Overflow protection
-		}
+// backfillInProgress is true if any backfilled logs may not have been
+// delivered yet
+func (b *logBroadcaster) backfillInProgress() bool {
+	return len(b.backfillBatches) > 0 || b.pagedBackfills > 0
+}
 
-		q := ethereum.FilterQuery{
-			FromBlock: big.NewInt(int64(fromBlock)),
-			Addresses: b.addresses(),
-		}
+// backfillRange returns the range of blocks to backfill, from `backfillDepth`
+// blocks before the safe head, up to the latest block.  Any error it returns
+// wraps ErrBackfillFailed.
+func (b *logBroadcaster) backfillRange() (fromBlock, toBlock uint64, _ error) {
+	latestBlock, err := b.ethClient.GetLatestBlock()
+	if err != nil {
+		return 0, 0, newLogBroadcasterError(ErrBackfillFailed, err)
+	}
+	currentHeight := uint64(latestBlock.Number)
+	b.latestHead = currentHeight
+
+	// Backfill from `backfillDepth` blocks before the safe head.  It's up to
+	// the subscribers to filter out logs they've already dealt with.
+	safeHeight := currentHeight - b.headSafetyDepth
+	if safeHeight > currentHeight {
+		safeHeight = 0 // Overflow protection
+	}
+	fromBlock = safeHeight - b.backfillDepth
+	if fromBlock > safeHeight {
+		fromBlock = 0 // Overflow protection
+	}
+	return fromBlock, currentHeight, nil
+}
 
-		logs, err := b.ethClient.GetLogs(q)
-		if err != nil {
-			return err
-		}
+// fetchBackfillLogs fetches all logs for the registered addresses from
+// `backfillDepth` blocks ago up to the latest block, which it returns as
+// toBlock.  Any error it returns wraps ErrBackfillFailed.
+func (b *logBroadcaster) fetchBackfillLogs() (_ []eth.Log, toBlock uint64, _ error) {
+	fromBlock, toBlock, err := b.backfillRange()
+	if err != nil {
+		return nil, 0, err
+	}
 
-		chBackfilledLogs = make(chan eth.Log)
-		go b.deliverBackfilledLogs(logs, chBackfilledLogs)
-		return nil
+	q := b.buildFilterQuery(big.NewInt(int64(fromBlock)), b.addresses())
+	if q.ToBlock == nil || q.ToBlock.Uint64() > toBlock {
+		q.ToBlock = big.NewInt(int64(toBlock))
+	}
+	logs, err := b.ethClient.GetLogs(q)
+	if err != nil {
+		return nil, 0, newLogBroadcasterError(ErrBackfillFailed, err)
+	}
+	sortLogs(logs)
+	b.checkBackfillCompleteness(logs, q.ToBlock.Uint64())
+	return logs, toBlock, nil
+}
+
+// checkBackfillCompleteness warns, and calls onBackfillSuspect, if the sorted
+// logs fetched for a backfill up to toBlock end more than backfillSuspectGap
+// blocks before it
+func (b *logBroadcaster) checkBackfillCompleteness(logs []eth.Log, toBlock uint64) {
+	if b.backfillSuspectGap == 0 || len(logs) == 0 {
+		return
+	}
+	lastLogBlock := logs[len(logs)-1].BlockNumber
+	if lastLogBlock >= toBlock || toBlock-lastLogBlock <= b.backfillSuspectGap {
+		return
+	}
+	logger.Warnw("LogBroadcaster backfilled logs end well before the requested block; the eth node may have truncated them",
+		"lastLogBlock", lastLogBlock,
+		"toBlock", toBlock,
+		"gap", toBlock-lastLogBlock,
+	)
+	if b.onBackfillSuspect != nil {
+		b.onBackfillSuspect(lastLogBlock+1, toBlock)
+	}
+}
 
+// sortLogs sorts logs in place by ascending (BlockNumber, Index)
+func sortLogs(logs []eth.Log) {
+	sort.SliceStable(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
 	})
-	return
 }
 
 func (b *logBroadcaster) deliverBackfilledLogs(logs []eth.Log, chBackfilledLogs chan<- eth.Log) {
@@ -282,19 +1567,48 @@ func (b *logBroadcaster) deliverBackfilledLogs(logs []eth.Log, chBackfilledLogs
 			return
 		}
 	}
+	b.setBackfillStatus(BackfillStatusComplete)
+}
+
+func (b *logBroadcaster) isConnected() bool {
+	b.connectedMutex.RLock()
+	defer b.connectedMutex.RUnlock()
+	return b.connected
+}
+
+func (b *logBroadcaster) setConnected(connected bool) {
+	b.connectedMutex.Lock()
+	defer b.connectedMutex.Unlock()
+	b.connected = connected
 }
 
 func (b *logBroadcaster) notifyConnect() {
-	b.connected = true
+	b.setConnected(true)
+	b.updateHealth(func(health *LogBroadcasterHealth) { health.Subscribed = true })
 	for _, listeners := range b.listeners {
 		for listener := range listeners {
 			listener.OnConnect()
 		}
 	}
+	for r, chConnected := range b.pendingConnects {
+		close(chConnected)
+		delete(b.pendingConnects, r)
+	}
+}
+
+func (b *logBroadcaster) notifyBackfillComplete() {
+	for _, listeners := range b.listeners {
+		for listener := range listeners {
+			if l, ok := listener.(BackfillCompleteListener); ok {
+				l.OnBackfillComplete()
+			}
+		}
+	}
 }
 
 func (b *logBroadcaster) notifyDisconnect() {
-	b.connected = false
+	b.setConnected(false)
+	b.updateHealth(func(health *LogBroadcasterHealth) { health.Subscribed = false })
 	for _, listeners := range b.listeners {
 		for listener := range listeners {
 			listener.OnDisconnect()
@@ -309,24 +1623,89 @@ func (b *logBroadcaster) process(subscription eth.Subscription, chRawLogs <-chan
 	debounceResubscribe := time.NewTicker(1 * time.Second)
 	defer debounceResubscribe.Stop()
 
+	var chStalenessCheck <-chan time.Time
+	var receivedLog, receivedHead bool
+	if b.stalenessTimeout > 0 {
+		stalenessCheck := time.NewTicker(b.stalenessTimeout)
+		defer stalenessCheck.Stop()
+		chStalenessCheck = stalenessCheck.C
+	}
+
+	headSubscription, chHeads := b.createHeadSubscription()
+	defer func() { headSubscription.Unsubscribe() }()
+	chHeadSubscriptionErr := headSubscription.Err()
+	var chHeadPoll <-chan time.Time
+	if chHeads == nil {
+		chHeadPoll = b.clock.After(b.headPollInterval)
+	}
+
 	for {
 		select {
 		case rawLog := <-chRawLogs:
-			b.onRawLog(rawLog)
+			receivedLog = true
+			needsResubscribe = b.onRawLog(rawLog) || needsResubscribe
+
+		case page := <-b.chBackfillPages:
+			b.registerBackfillPage(page)
+
+		case head := <-chHeads:
+			receivedHead = true
+			needsResubscribe = b.onNewHead(head) || needsResubscribe
+
+		case err := <-chHeadSubscriptionErr:
+			logger.Warnw("LogBroadcaster head subscription failed, polling for the head instead",
+				"error", err,
+			)
+			headSubscription.Unsubscribe()
+			headSubscription = noopHeadSubscription{}
+			chHeads, chHeadSubscriptionErr = nil, nil
+			chHeadPoll = b.clock.After(b.headPollInterval)
+
+		case <-chHeadPoll:
+			if len(b.heldLogs) > 0 || b.hasHeadListeners() {
+				needsResubscribe = b.pollHead() || needsResubscribe
+			}
+			chHeadPoll = b.clock.After(b.headPollInterval)
 
 		case r := <-b.chAddListener:
 			needsResubscribe = b.onAddListener(r) || needsResubscribe
+			if !needsResubscribe {
+				// Otherwise, they're delivered once resubscribed
+				b.deliverHistoricalBackfills()
+			}
 
 		case r := <-b.chRemoveListener:
 			needsResubscribe = b.onRemoveListener(r) || needsResubscribe
+			if _, ok := b.listeners[r.address]; !ok {
+				b.dropHeldLogsFor(r.address)
+			}
+
+		case request := <-b.chReplay:
+			request.chErr <- b.onReplay(request)
+
+		case requests := <-b.chRestore:
+			needsResubscribe = b.onRestoreRegistrations(requests) || needsResubscribe
+			if !needsResubscribe {
+				b.deliverHistoricalBackfills()
+			}
+
+		case chRegistrations := <-b.chSnapshot:
+			chRegistrations <- b.snapshotRegistrations()
 
 		case <-debounceResubscribe.C:
 			if needsResubscribe {
 				return true, nil
 			}
 
+		case <-chStalenessCheck:
+			// Heads arriving over the same connection show it's still alive
+			if !receivedLog && !receivedHead && b.subscriptionIsStale() {
+				return true, nil
+			}
+			receivedLog, receivedHead = false, false
+
 		case err := <-subscription.Err():
-			return true, err
+			return true, newLogBroadcasterError(ErrSubscriptionClosed, err)
 
 		case <-b.chStop:
 			return false, nil
@@ -334,39 +1713,512 @@ func (b *logBroadcaster) process(subscription eth.Subscription, chRawLogs <-chan
 	}
 }
 
-func (b *logBroadcaster) onRawLog(rawLog eth.Log) {
+// createHeadSubscription subscribes to new heads, if the broadcaster is
+// configured to and has any listeners.  Otherwise, or if the subscription
+// fails, it returns a no-op subscription and a nil channel, and the head is
+// polled for instead.
+func (b *logBroadcaster) createHeadSubscription() (eth.Subscription, <-chan eth.BlockHeader) {
+	if !b.subscribeToHeads || len(b.listeners) == 0 {
+		return noopHeadSubscription{}, nil
+	}
+	chHeads := make(chan eth.BlockHeader)
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	sub, err := b.ethClient.SubscribeToNewHeads(ctx, chHeads)
+	if err != nil {
+		logger.Warnw("LogBroadcaster unable to subscribe to new heads, polling for the head instead",
+			"error", err,
+		)
+		return noopHeadSubscription{}, nil
+	}
+	return sub, chHeads
+}
+
+// onNewHead advances the latest head, releases any held logs which are now
+// deep enough, and passes the head on to the HeadListeners
+func (b *logBroadcaster) onNewHead(head eth.BlockHeader) (needsResubscribe bool) {
+	number := head.Number.ToInt().Uint64()
+	if number > b.latestHead {
+		b.latestHead = number
+	}
+	if len(b.heldLogs) > 0 {
+		needsResubscribe = b.releaseSafeLogs()
+	}
+	b.notifyNewHead(eth.Block{Number: hexutil.Uint64(number)})
+	return needsResubscribe
+}
+
+func (b *logBroadcaster) hasHeadListeners() bool {
+	for _, listeners := range b.listeners {
+		for listener := range listeners {
+			if _, ok := listener.(HeadListener); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// notifyNewHead passes head to each HeadListener once, however many addresses
+// it's registered for, unless they have already been told about it or a later
+// head
+func (b *logBroadcaster) notifyNewHead(head eth.Block) {
+	if uint64(head.Number) <= b.notifiedHead {
+		return
+	}
+	b.notifiedHead = uint64(head.Number)
+	notified := make(map[LogListener]struct{})
+	for _, listeners := range b.listeners {
+		for listener := range listeners {
+			headListener, ok := listener.(HeadListener)
+			if _, alreadyNotified := notified[listener]; !ok || alreadyNotified {
+				continue
+			}
+			notified[listener] = struct{}{}
+			b.handleHead(headListener, head)
+		}
+	}
+}
+
+// handleHead passes head to listener, recovering from and logging any panic
+func (b *logBroadcaster) handleHead(listener HeadListener, head eth.Block) {
+	defer func() {
+		if err := recover(); err != nil {
+			logger.Errorw(fmt.Sprintf("LogListener panicked in OnNewHead: %v", err),
+				"listener", fmt.Sprintf("%T", listener),
+				"head", uint64(head.Number),
+			)
+		}
+	}()
+	listener.OnNewHead(head)
+}
+
+// subscriptionIsStale is called when the subscription has delivered no logs for
+// a whole staleness timeout.  That's normal for contracts which emit few logs,
+// so the subscription is only presumed dead if the head hasn't advanced either.
+func (b *logBroadcaster) subscriptionIsStale() bool {
+	if len(b.listeners) == 0 {
+		return false
+	}
+	latestBlock, err := b.ethClient.GetLatestBlock()
+	if err != nil {
+		logger.Warnw("LogBroadcaster unable to poll latest block while checking subscription staleness",
+			"error", err,
+		)
+		return false
+	}
+	b.notifyNewHead(latestBlock)
+	head := uint64(latestBlock.Number)
+	if head > b.latestHead {
+		b.latestHead = head
+		return false
+	}
+	logger.Warnw("LogBroadcaster subscription appears stale, resubscribing",
+		"stalenessTimeout", b.stalenessTimeout,
+		"head", head,
+	)
+	return true
+}
+
+func (b *logBroadcaster) onRawLog(rawLog eth.Log) (needsResubscribe bool) {
+	b.updateHealth(func(health *LogBroadcasterHealth) {
+		if rawLog.BlockNumber > health.LastBlockSeen {
+			health.LastBlockSeen = rawLog.BlockNumber
+		}
+		health.LastLogReceivedAt = time.Now()
+	})
+	if !rawLog.Removed {
+		b.trackReorgs(rawLog)
+	}
+
+	if b.headSafetyDepth == 0 {
+		return b.broadcastRawLog(rawLog)
+	}
+	if rawLog.Removed {
+		if b.dropHeldLog(rawLog) {
+			// It was never delivered, so there's nothing for listeners to undo
+			b.deliveredBackfilledLog(b.backfillBatchFor(rawLog), rawLog)
+			return false
+		}
+		return b.broadcastRawLog(rawLog)
+	}
+	// The node has seen at least the block this log was emitted in
+	if rawLog.BlockNumber > b.latestHead {
+		b.latestHead = rawLog.BlockNumber
+	}
+	b.heldLogs = append(b.heldLogs, rawLog)
+	return b.releaseSafeLogs()
+}
+
+// trackReorgs records a reorg if rawLog is from a different block than was
+// previously seen at its height
+func (b *logBroadcaster) trackReorgs(rawLog eth.Log) {
+	if b.reorgHistory == nil && b.onReorg == nil && !b.deleteReorged {
+		return
+	}
+	height := rawLog.BlockNumber
+	seenHash, seen := b.seenBlockHashes[height]
+	switch {
+	case !seen:
+		b.seenBlockHashes[height] = rawLog.BlockHash
+		if height > b.highestSeenBlock {
+			b.highestSeenBlock = height
+		}
+	case seenHash != rawLog.BlockHash:
+		event := ReorgEvent{
+			FromHeight:   height,
+			Depth:        b.highestSeenBlock - height + 1,
+			OldBlockHash: seenHash,
+			NewBlockHash: rawLog.BlockHash,
+			DetectedAt:   time.Now(),
+		}
+		// The blocks seen above it were replaced too
+		orphaned := []common.Hash{seenHash}
+		for seenHeight, hash := range b.seenBlockHashes {
+			if seenHeight > height {
+				orphaned = append(orphaned, hash)
+				delete(b.seenBlockHashes, seenHeight)
+			}
+		}
+		b.seenBlockHashes[height] = rawLog.BlockHash
+		b.highestSeenBlock = height
+		b.recordReorg(event)
+		if b.deleteReorged {
+			b.deleteConsumptions(orphaned)
+		}
+	}
+	if len(b.seenBlockHashes) > reorgTrackingDepth {
+		for seenHeight := range b.seenBlockHashes {
+			if seenHeight+reorgTrackingDepth <= b.highestSeenBlock {
+				delete(b.seenBlockHashes, seenHeight)
+			}
+		}
+	}
+}
+
+func (b *logBroadcaster) recordReorg(event ReorgEvent) {
+	logger.Warnw("LogBroadcaster observed a reorg",
+		"fromHeight", event.FromHeight,
+		"depth", event.Depth,
+		"oldBlockHash", event.OldBlockHash.Hex(),
+		"newBlockHash", event.NewBlockHash.Hex(),
+	)
+	if b.reorgHistory != nil {
+		b.reorgHistory.add(event)
+	}
+	if b.onReorg != nil {
+		b.onReorg(event)
+	}
+}
+
+// deleteConsumptions deletes the consumptions of the logs from the orphaned
+// blocks.  Failures are logged, since the consumptions of orphaned logs don't
+// suppress their replacements, which are from different blocks.
+func (b *logBroadcaster) deleteConsumptions(orphaned []common.Hash) {
+	for _, hash := range orphaned {
+		deleted, err := b.orm.DeleteLogConsumptionsForBlockHash(hash)
+		if err != nil {
+			logger.Errorw("LogBroadcaster unable to delete consumptions of reorged logs",
+				"blockHash", hash.Hex(),
+				"error", err,
+			)
+			continue
+		}
+		if deleted > 0 {
+			logger.Infow("LogBroadcaster deleted consumptions of reorged logs",
+				"blockHash", hash.Hex(),
+				"consumptions", deleted,
+			)
+		}
+	}
+}
+
+// releaseSafeLogs delivers the held logs which are at least headSafetyDepth
+// blocks behind the latest head, in the order they arrived
+func (b *logBroadcaster) releaseSafeLogs() (needsResubscribe bool) {
+	var stillHeld []eth.Log
+	for _, log := range b.heldLogs {
+		if log.BlockNumber+b.headSafetyDepth > b.latestHead {
+			stillHeld = append(stillHeld, log)
+			continue
+		}
+		needsResubscribe = b.broadcastRawLog(log) || needsResubscribe
+	}
+	b.heldLogs = stillHeld
+	return needsResubscribe
+}
+
+// dropHeldLog discards the held copy of a log which has been reorged out, and
+// reports whether it was held
+func (b *logBroadcaster) dropHeldLog(removed eth.Log) (wasHeld bool) {
+	var stillHeld []eth.Log
+	for _, log := range b.heldLogs {
+		if log.BlockHash != removed.BlockHash || log.Index != removed.Index {
+			stillHeld = append(stillHeld, log)
+		} else {
+			wasHeld = true
+		}
+	}
+	b.heldLogs = stillHeld
+	return wasHeld
+}
+
+// pollHead releases the held logs which the head has advanced far enough past
+// since they arrived, and passes the head on to the HeadListeners.  It's
+// needed when there's no head subscription, as the head is otherwise only
+// learned from the logs received.
+func (b *logBroadcaster) pollHead() (needsResubscribe bool) {
+	latestBlock, err := b.ethClient.GetLatestBlock()
+	if err != nil {
+		logger.Warnw("LogBroadcaster unable to poll latest block",
+			"heldLogs", len(b.heldLogs),
+			"error", err,
+		)
+		return false
+	}
+	if head := uint64(latestBlock.Number); head > b.latestHead {
+		b.latestHead = head
+	}
+	if len(b.heldLogs) > 0 {
+		needsResubscribe = b.releaseSafeLogs()
+	}
+	b.notifyNewHead(latestBlock)
+	return needsResubscribe
+}
+
+// broadcastRawLog passes rawLog to the listeners registered for its address
+func (b *logBroadcaster) broadcastRawLog(rawLog eth.Log) (needsResubscribe bool) {
+	batch := b.backfillBatchFor(rawLog)
+	if _, ok := b.listeners[rawLog.Address]; !ok {
+		// Its listeners unregistered after it was sent, e.g. while it was
+		// buffered by the last subscription, which the narrower one replaces
+		logger.Debugw("LogBroadcaster dropping log for address with no listeners",
+			"address", rawLog.Address.Hex(),
+			"blockNumber", rawLog.BlockNumber,
+		)
+		b.deliveredBackfilledLog(batch, rawLog)
+		return false
+	}
 	for listener := range b.listeners[rawLog.Address] {
-		// Ignore duplicate logs sent back due to reorgs
+		// Removed logs are only passed on to listeners which asked for them
 		if rawLog.Removed {
+			b.notifyRemovedLog(listener, rawLog)
+			continue
+		}
+
+		r := registration{rawLog.Address, listener}
+		if !b.handleLog(r, rawLog.Copy(), batch, false) {
+			delete(b.listenerPanics, r)
 			continue
 		}
 
-		rawLogCopy := rawLog.Copy()
-		lb := logBroadcast{b.orm, &rawLogCopy, listener.Consumer()}
-		listener.HandleLog(&lb, nil)
+		b.listenerPanics[r]++
+		max := b.panicPolicy.MaxConsecutivePanics
+		if max > 0 && b.listenerPanics[r] >= max {
+			logger.Errorw("Unregistering LogListener after consecutive panics in HandleLog",
+				"address", r.address.Hex(),
+				"panics", b.listenerPanics[r],
+			)
+			needsResubscribe = b.onRemoveListener(r) || needsResubscribe
+		}
+	}
+	b.deliveredBackfilledLog(batch, rawLog)
+	return needsResubscribe
+}
+
+// notifyRemovedLog passes rawLog, which has been reorged out, to listener if
+// it's a RemovedLogListener and NotifyRemovedLogs is set
+func (b *logBroadcaster) notifyRemovedLog(listener LogListener, rawLog eth.Log) {
+	removedLogListener, ok := listener.(RemovedLogListener)
+	if !b.notifyRemovedLogs || !ok || !b.wantsLog(registration{rawLog.Address, listener}, rawLog) {
+		return
+	}
+	defer func() {
+		if err := recover(); err != nil {
+			logger.Errorw(fmt.Sprintf("LogListener panicked in OnLogRemoved: %v", err),
+				"address", rawLog.Address.Hex(),
+				"listener", fmt.Sprintf("%T", listener),
+				"blockNumber", rawLog.BlockNumber,
+				"txHash", rawLog.TxHash.Hex(),
+			)
+		}
+	}()
+	removedLogListener.OnLogRemoved(rawLog.Copy())
+}
+
+// wantsLog reports whether the filter r's listener was registered with, if
+// any, accepts rawLog
+func (b *logBroadcaster) wantsLog(r registration, rawLog eth.Log) bool {
+	filter := b.listeners[r.address][r.listener]
+	return filter == nil || filter(rawLog)
+}
+
+// backfillBatchFor returns the batch which rawLog was backfilled in, or nil if
+// it isn't awaiting delivery from any backfill
+func (b *logBroadcaster) backfillBatchFor(rawLog eth.Log) *backfillBatch {
+	key := logKey{rawLog.BlockHash, rawLog.Index}
+	for _, batch := range b.backfillBatches {
+		if _, isPending := batch.pending[key]; isPending {
+			return batch
+		}
+	}
+	return nil
+}
+
+// deliveredBackfilledLog records that rawLog has been delivered to the
+// listeners, and flushes its batch once every log in it has been delivered.
+// Once every outstanding batch has been delivered, the listeners have caught
+// up with the chain.
+func (b *logBroadcaster) deliveredBackfilledLog(batch *backfillBatch, rawLog eth.Log) {
+	if batch == nil {
+		return
+	}
+	delete(batch.pending, logKey{rawLog.BlockHash, rawLog.Index})
+	if len(batch.pending) > 0 {
+		return
+	}
+	for i, other := range b.backfillBatches {
+		if other == batch {
+			b.backfillBatches = append(b.backfillBatches[:i], b.backfillBatches[i+1:]...)
+			break
+		}
+	}
+	b.flushBackfillBatch(batch)
+	if !b.backfillInProgress() {
+		b.notifyBackfillComplete()
+	}
+}
+
+// flushBackfillBatches records the consumptions collected by every batch
+// which hasn't been flushed yet
+func (b *logBroadcaster) flushBackfillBatches() {
+	for _, batch := range b.backfillBatches {
+		b.flushBackfillBatch(batch)
+	}
+	b.backfillBatches = nil
+}
+
+func (b *logBroadcaster) flushBackfillBatch(batch *backfillBatch) {
+	consumptions := batch.drain()
+	if len(consumptions) == 0 {
+		return
+	}
+	if err := b.orm.MarkConsumedBatch(consumptions); err != nil {
+		logger.Errorw("LogBroadcaster unable to record consumptions of backfilled logs",
+			"consumptions", len(consumptions),
+			"error", newLogBroadcasterError(ErrConsumptionWrite, err),
+		)
+	}
+}
+
+// handleLog passes rawLog to the registered listener, recovering from and
+// logging any panic.  It returns true if the listener panicked.
+func (b *logBroadcaster) handleLog(r registration, rawLog eth.Log, batch *backfillBatch, replay bool) (panicked bool) {
+	var consumer models.LogConsumer
+	defer func() {
+		if err := recover(); err != nil {
+			logger.Errorw(fmt.Sprintf("LogListener panicked in HandleLog: %v", err),
+				"address", r.address.Hex(),
+				"listener", fmt.Sprintf("%T", r.listener),
+				"consumer", consumer,
+				"blockNumber", rawLog.BlockNumber,
+				"txHash", rawLog.TxHash.Hex(),
+			)
+			panicked = true
+		}
+	}()
+
+	if !b.wantsLog(r, rawLog) {
+		return false
+	}
+	consumer = r.listener.Consumer()
+	if b.recentDeliveries != nil {
+		record := DeliveryRecord{Address: r.address, BlockNumber: rawLog.BlockNumber, Consumer: consumer}
+		if len(rawLog.Topics) > 0 {
+			record.Topic = rawLog.Topics[0]
+		}
+		b.recentDeliveries.add(record)
+	}
+	if b.reconciler != nil {
+		b.reconciler.delivered(LogKey{rawLog.BlockHash, rawLog.Index, consumer})
 	}
+	lb := logBroadcast{b.orm, &rawLog, consumer, batch, replay, b.chainID, b.reconciler}
+	r.listener.HandleLog(&lb, nil)
+	return false
 }
 
-func (b *logBroadcaster) onAddListener(r registration) (needsResubscribe bool) {
+func (b *logBroadcaster) onAddListener(r registrationRequest) (needsResubscribe bool) {
 	_, knownAddress := b.listeners[r.address]
 	if !knownAddress {
-		b.listeners[r.address] = make(map[LogListener]struct{})
+		b.listeners[r.address] = make(map[LogListener]LogFilter)
 	}
 	if _, exists := b.listeners[r.address][r.listener]; exists {
 		panic("registration already exists")
 	}
-	b.listeners[r.address][r.listener] = struct{}{}
+	b.listeners[r.address][r.listener] = r.filter
+	if r.fromBlock != nil {
+		b.historicalBackfills = append(b.historicalBackfills, r)
+	}
 
 	if !knownAddress {
 		// Recreate the subscription with the new contract address
+		if r.chConnected != nil {
+			b.pendingConnects[r.registration] = r.chConnected
+		}
 		return true
 	}
+	if r.chConnected != nil {
+		if b.isConnected() {
+			close(r.chConnected)
+		} else {
+			b.pendingConnects[r.registration] = r.chConnected
+		}
+	}
 	return false
 }
 
+// deliverHistoricalBackfills delivers the logs requested by each pending
+// RegisterFromBlock call to its listener alone, if it's still registered.
+// Failures are logged rather than retried.
+func (b *logBroadcaster) deliverHistoricalBackfills() {
+	requests := b.historicalBackfills
+	b.historicalBackfills = nil
+	for _, r := range requests {
+		if _, registered := b.listeners[r.address][r.listener]; !registered {
+			continue
+		}
+		q := b.buildFilterQuery(r.fromBlock, []common.Address{r.address})
+		logs, err := b.ethClient.GetLogs(q)
+		if err != nil {
+			logger.Errorw("LogBroadcaster unable to fetch historical logs for listener",
+				"address", r.address.Hex(),
+				"fromBlock", r.fromBlock,
+				"listener", fmt.Sprintf("%T", r.listener),
+				"error", err,
+			)
+			continue
+		}
+		sortLogs(logs)
+		for _, rawLog := range logs {
+			if rawLog.Removed || rawLog.Address != r.address {
+				continue
+			}
+			b.handleLog(r.registration, rawLog.Copy(), nil, false)
+		}
+		logger.Debugw("LogBroadcaster delivered historical logs to listener",
+			"address", r.address.Hex(),
+			"fromBlock", r.fromBlock,
+			"logs", len(logs),
+			"listener", fmt.Sprintf("%T", r.listener),
+		)
+	}
+}
+
 func (b *logBroadcaster) onRemoveListener(r registration) (needsResubscribe bool) {
 	r.listener.OnDisconnect()
 	delete(b.listeners[r.address], r.listener)
+	delete(b.listenerPanics, r)
+	delete(b.pendingConnects, r)
 	if len(b.listeners[r.address]) == 0 {
 		delete(b.listeners, r.address)
 		// Recreate the subscription without this contract address
@@ -375,6 +2227,22 @@ func (b *logBroadcaster) onRemoveListener(r registration) (needsResubscribe bool
 	return false
 }
 
+// dropHeldLogsFor discards the held logs of an address which no longer has
+// any listeners, so that they're never delivered, even to a listener which
+// registers for it before they'd have been released.  Any such listener gets
+// them from its backfill instead.
+func (b *logBroadcaster) dropHeldLogsFor(address common.Address) {
+	var stillHeld []eth.Log
+	for _, log := range b.heldLogs {
+		if log.Address != address {
+			stillHeld = append(stillHeld, log)
+			continue
+		}
+		b.deliveredBackfilledLog(b.backfillBatchFor(log), log)
+	}
+	b.heldLogs = stillHeld
+}
+
 // createSubscription creates a new log subscription starting at the current block.  If previous logs
 // are needed, they must be obtained through backfilling, as subscriptions can only be started from
 // the current head.
@@ -417,6 +2285,11 @@ func (s noopSubscription) Err() <-chan error  { return nil }
 func (s noopSubscription) Logs() chan eth.Log { return s.chRawLogs }
 func (s noopSubscription) Unsubscribe()       { close(s.chRawLogs) }
 
+type noopHeadSubscription struct{}
+
+func (noopHeadSubscription) Err() <-chan error { return nil }
+func (noopHeadSubscription) Unsubscribe()      {}
+
 // DecodingLogListener receives raw logs from the LogBroadcaster and decodes them into
 // Go structs using the provided ContractCodec (a simple wrapper around a go-ethereum
 // ABI type).
@@ -490,6 +2363,37 @@ func (l *decodingLogListener) HandleLog(lb LogBroadcast, err error) {
 	l.LogListener.HandleLog(lb, nil)
 }
 
+// handOffToLiveLogs returns a channel carrying the backfilled logs, followed by
+// the live logs from the subscription which was created before the backfill.
+// The backfill is authoritative up to handoffBlock, so live logs at or below
+// it which the backfill already delivered are dropped.  Others, e.g. removed
+// logs, or logs from blocks which replaced backfilled ones in a reorg, are
+// passed on, so that nothing is lost in the handoff.
+func handOffToLiveLogs(chBackfilledLogs, chLiveLogs <-chan eth.Log, handoffBlock uint64) chan eth.Log {
+	chCombined := make(chan eth.Log)
+
+	go func() {
+		defer close(chCombined)
+		backfilled := make(map[logKey]struct{})
+		for rawLog := range chBackfilledLogs {
+			if rawLog.BlockNumber <= handoffBlock {
+				backfilled[logKey{rawLog.BlockHash, rawLog.Index}] = struct{}{}
+			}
+			chCombined <- rawLog
+		}
+		for rawLog := range chLiveLogs {
+			if rawLog.BlockNumber <= handoffBlock && !rawLog.Removed {
+				if _, ok := backfilled[logKey{rawLog.BlockHash, rawLog.Index}]; ok {
+					continue
+				}
+			}
+			chCombined <- rawLog
+		}
+	}()
+
+	return chCombined
+}
+
 func appendLogChannel(ch1, ch2 <-chan eth.Log) chan eth.Log {
 	if ch1 == nil && ch2 == nil {
 		return nil
@@ -1,8 +1,14 @@
 package eth_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -10,6 +16,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/eth"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/logger"
 	ethsvc "github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -17,8 +24,11 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 )
 
 func createJob(t *testing.T, store *store.Store) models.JobSpec {
@@ -96,6 +106,62 @@ func TestLogBroadcaster_AwaitsInitialSubscribersOnStartup(t *testing.T) {
 	sub.AssertExpectations(t)
 }
 
+func TestDefaultLogBroadcasterOptions_HaveNoTimeouts(t *testing.T) {
+	t.Parallel()
+
+	// Startup waits for every dependent unless a timeout is set explicitly
+	assert.Zero(t, ethsvc.DefaultLogBroadcasterOptions.DependentsTimeout)
+	assert.Zero(t, ethsvc.DefaultLogBroadcasterOptions.StalenessTimeout)
+}
+
+func TestLogBroadcaster_StopsAwaitingDependentsAfterTimeout(t *testing.T) {
+	// Not parallel, as it raises the global log level to capture the warning
+	previousLogger := logger.GetLogger().Desugar()
+	logger.SetLogger(cltest.CreateTestLogger(zapcore.WarnLevel))
+	defer logger.SetLogger(previousLogger)
+
+	ethClient := new(mocks.Client)
+	sub := new(mocks.Subscription)
+	chSubscribe := make(chan struct{}, 10)
+	ethClient.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).
+		Return(sub, nil).
+		Run(func(mock.Arguments) { chSubscribe <- struct{}{} })
+	ethClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(123)}, nil)
+	ethClient.On("GetLogs", mock.Anything).Return([]eth.Log{}, nil)
+	sub.On("Err").Return(nil)
+	sub.On("Unsubscribe").Return()
+
+	const dependentsTimeout = 500 * time.Millisecond
+	opts := ethsvc.DefaultLogBroadcasterOptions
+	opts.DependentsTimeout = dependentsTimeout
+	lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+	lb.AddDependents(2)
+	lb.Start()
+	defer lb.Stop()
+
+	listener := new(mocks.LogListener)
+	listener.On("OnConnect").Return()
+	listener.On("OnDisconnect").Return()
+	lb.Register(cltest.NewAddress(), listener)
+
+	started := time.Now()
+	lb.DependentReady()
+	select {
+	case <-chSubscribe:
+		require.True(t, time.Since(started) >= dependentsTimeout/2,
+			"subscribed before the dependents timed out")
+	case <-time.After(5 * time.Second):
+		t.Fatal("did not subscribe after the dependents timed out")
+	}
+
+	require.Eventually(t, func() bool {
+		return strings.Contains(cltest.MemoryLogTestingOnly().String(),
+			"LogBroadcaster timed out waiting for dependents to be ready")
+	}, 5*time.Second, 10*time.Millisecond)
+	// The pretty printer may wrap the field name in color codes
+	require.Regexp(t, `pendingDependents\S*=1\b`, cltest.MemoryLogTestingOnly().String())
+}
+
 func TestLogBroadcaster_ResubscribesOnAddOrRemoveContract(t *testing.T) {
 	t.Parallel()
 
@@ -176,6 +242,60 @@ func (listner simpleLogListner) Consumer() models.LogConsumer {
 	}
 }
 
+// lifecycleRecordingListener records the callbacks it receives, in order
+type lifecycleRecordingListener struct {
+	mutex  sync.Mutex
+	events []string
+}
+
+var _ ethsvc.BackfillCompleteListener = (*lifecycleRecordingListener)(nil)
+var _ ethsvc.RemovedLogListener = (*lifecycleRecordingListener)(nil)
+
+func (l *lifecycleRecordingListener) record(event string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *lifecycleRecordingListener) Events() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return append([]string{}, l.events...)
+}
+
+func (l *lifecycleRecordingListener) HandleLog(lb ethsvc.LogBroadcast, err error) {
+	l.record(fmt.Sprintf("HandleLog(%d)", lb.Log().(*eth.Log).BlockNumber))
+}
+func (l *lifecycleRecordingListener) OnConnect()                   { l.record("OnConnect") }
+func (l *lifecycleRecordingListener) OnDisconnect()                { l.record("OnDisconnect") }
+func (l *lifecycleRecordingListener) OnBackfillComplete()          { l.record("OnBackfillComplete") }
+func (l *lifecycleRecordingListener) Consumer() models.LogConsumer { return models.LogConsumer{} }
+func (l *lifecycleRecordingListener) OnLogRemoved(log eth.Log) {
+	l.record(fmt.Sprintf("OnLogRemoved(%d)", log.BlockNumber))
+}
+
+// headRecordingListener is a lifecycleRecordingListener which also records the
+// heads it's told about
+type headRecordingListener struct {
+	lifecycleRecordingListener
+	headsMutex sync.Mutex
+	heads      []uint64
+}
+
+var _ ethsvc.HeadListener = (*headRecordingListener)(nil)
+
+func (l *headRecordingListener) OnNewHead(head eth.Block) {
+	l.headsMutex.Lock()
+	defer l.headsMutex.Unlock()
+	l.heads = append(l.heads, uint64(head.Number))
+}
+
+func (l *headRecordingListener) Heads() []uint64 {
+	l.headsMutex.Lock()
+	defer l.headsMutex.Unlock()
+	return append([]uint64{}, l.heads...)
+}
+
 func TestLogBroadcaster_BroadcastsToCorrectRecipients(t *testing.T) {
 	t.Parallel()
 
@@ -676,6 +796,315 @@ func TestLogBroadcaster_InjectsLogConsumptionRecordFunctions(t *testing.T) {
 	requireLogConsumptionCount(t, store, 2)
 }
 
+func TestLogBroadcaster_RecordsBackfilledLogConsumptionsTogether(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	const blockHeight uint64 = 3
+	addr := common.Address{1}
+	backfilledLogs := []eth.Log{
+		{Address: addr, BlockHash: cltest.NewHash(), BlockNumber: 1, Index: 0},
+		{Address: addr, BlockHash: cltest.NewHash(), BlockNumber: 2, Index: 0},
+		{Address: addr, BlockHash: cltest.NewHash(), BlockNumber: 3, Index: 0},
+	}
+
+	ethClient := new(mocks.Client)
+	sub := new(mocks.Subscription)
+	ethClient.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).Return(sub, nil)
+	ethClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(blockHeight)}, nil)
+	ethClient.On("GetLogs", mock.Anything).Return(backfilledLogs, nil)
+	sub.On("Err").Return(nil)
+	sub.On("Unsubscribe").Return()
+
+	lb := ethsvc.NewLogBroadcaster(ethClient, store.ORM, 10)
+
+	var listenerCount int32
+	job := createJob(t, store)
+	logListener := simpleLogListner{
+		func(lb ethsvc.LogBroadcast, err error) {
+			require.NoError(t, lb.MarkConsumed())
+			consumed, err := lb.WasAlreadyConsumed()
+			require.NoError(t, err)
+			require.True(t, consumed)
+			if atomic.AddInt32(&listenerCount, 1) < int32(len(backfilledLogs)) {
+				// Nothing is written until the whole backfill has been delivered
+				count, err := store.ORM.CountOf(&models.LogConsumption{})
+				require.NoError(t, err)
+				require.Zero(t, count)
+			}
+		},
+		*job.ID,
+	}
+	lb.Register(addr, &logListener)
+	lb.Start()
+	defer lb.Stop()
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&listenerCount) == int32(len(backfilledLogs))
+	}, 5*time.Second, 10*time.Millisecond)
+	requireLogConsumptionCount(t, store, len(backfilledLogs))
+}
+
+// consumerLogListener is a LogListener with an arbitrary consumer
+type consumerLogListener struct {
+	simpleLogListner
+	consumer models.LogConsumer
+}
+
+func (l consumerLogListener) Consumer() models.LogConsumer { return l.consumer }
+
+func TestLogBroadcaster_TracksConsumptionsPerConsumerType(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	const blockHeight uint64 = 0
+
+	ethClient := new(mocks.Client)
+	sub := new(mocks.Subscription)
+	chchRawLogs := make(chan chan<- eth.Log, 1)
+	ethClient.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			chchRawLogs <- args.Get(1).(chan<- eth.Log)
+		}).
+		Return(sub, nil).
+		Once()
+	ethClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(blockHeight)}, nil)
+	ethClient.On("GetLogs", mock.Anything).Return([]eth.Log{}, nil).Once()
+	sub.On("Err").Return(nil)
+	sub.On("Unsubscribe").Return()
+
+	lb := ethsvc.NewLogBroadcaster(ethClient, store.ORM, 10)
+	lb.Start()
+	defer lb.Stop()
+
+	// A job and a service which happen to share an ID
+	job := createJob(t, store)
+	var jobHandled, serviceHandled int32
+	newListener := func(consumerType string, handled *int32, markConsumed bool) consumerLogListener {
+		return consumerLogListener{
+			simpleLogListner{func(lb ethsvc.LogBroadcast, err error) {
+				require.NoError(t, err)
+				consumed, err := lb.WasAlreadyConsumed()
+				require.NoError(t, err)
+				require.False(t, consumed)
+				if markConsumed {
+					require.NoError(t, lb.MarkConsumed())
+				}
+				atomic.AddInt32(handled, 1)
+			}, *job.ID},
+			models.LogConsumer{Type: consumerType, ID: job.ID},
+		}
+	}
+	jobListener := newListener(models.LogConsumerTypeJob, &jobHandled, true)
+	serviceListener := newListener(models.LogConsumerTypeService, &serviceHandled, false)
+
+	addr := common.Address{1}
+	lb.Register(addr, &jobListener)
+	lb.Register(addr, &serviceListener)
+
+	chRawLogs := <-chchRawLogs
+	rawLog := eth.Log{Address: addr, BlockHash: cltest.NewHash(), BlockNumber: 0, Index: 0}
+	chRawLogs <- rawLog
+
+	// The job's consumption of the log doesn't count as the service's
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&jobHandled) == 1 && atomic.LoadInt32(&serviceHandled) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	requireLogConsumptionCount(t, store, 1)
+
+	jobConsumed, err := store.ORM.HasConsumedLog(&rawLog, jobListener.Consumer())
+	require.NoError(t, err)
+	assert.True(t, jobConsumed)
+	serviceConsumed, err := store.ORM.HasConsumedLog(&rawLog, serviceListener.Consumer())
+	require.NoError(t, err)
+	assert.False(t, serviceConsumed)
+
+	serviceConsumption := models.NewLogConsumption(&rawLog, serviceListener.Consumer())
+	require.NoError(t, store.ORM.CreateLogConsumption(&serviceConsumption))
+	requireLogConsumptionCount(t, store, 2)
+}
+
+func TestLogBroadcaster_ScopesConsumptionsByChain(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := createJob(t, store)
+	addr := common.Address{1}
+	rawLog := eth.Log{Address: addr, BlockHash: cltest.NewHash(), BlockNumber: 0, Index: 0}
+
+	// deliver broadcasts rawLog from a broadcaster on chainID to a listener,
+	// which marks it consumed, and returns whether it was already consumed
+	deliver := func(chainID *big.Int) (alreadyConsumed bool) {
+		ethClient := new(mocks.Client)
+		sub := new(mocks.Subscription)
+		chchRawLogs := make(chan chan<- eth.Log, 1)
+		ethClient.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				chchRawLogs <- args.Get(1).(chan<- eth.Log)
+			}).
+			Return(sub, nil).
+			Once()
+		ethClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(0)}, nil)
+		ethClient.On("GetLogs", mock.Anything).Return([]eth.Log{}, nil).Once()
+		sub.On("Err").Return(nil)
+		sub.On("Unsubscribe").Return()
+
+		opts := ethsvc.DefaultLogBroadcasterOptions
+		opts.ChainID = chainID
+		lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, store.ORM, 10, opts)
+		lb.Start()
+		defer lb.Stop()
+
+		chConsumed := make(chan bool, 1)
+		listener := simpleLogListner{func(lb ethsvc.LogBroadcast, err error) {
+			require.NoError(t, err)
+			consumed, err := lb.WasAlreadyConsumed()
+			require.NoError(t, err)
+			if !consumed {
+				require.NoError(t, lb.MarkConsumed())
+			}
+			chConsumed <- consumed
+		}, *job.ID}
+		lb.Register(addr, &listener)
+		(<-chchRawLogs) <- rawLog
+
+		select {
+		case consumed := <-chConsumed:
+			return consumed
+		case <-time.After(5 * time.Second):
+			t.Fatal("log was not delivered")
+		}
+		return false
+	}
+
+	chainA, chainB := big.NewInt(1), big.NewInt(2)
+	assert.False(t, deliver(chainA))
+	assert.True(t, deliver(chainA))
+	// The consumption on chain A doesn't suppress the same log on chain B
+	assert.False(t, deliver(chainB))
+	requireLogConsumptionCount(t, store, 2)
+
+	// Consumptions unscoped by chain, e.g. recorded before chain ids were,
+	// count on every chain
+	legacyLog := eth.Log{Address: addr, BlockHash: cltest.NewHash(), BlockNumber: 0, Index: 0}
+	consumer := models.LogConsumer{Type: models.LogConsumerTypeJob, ID: job.ID}
+	legacy := models.NewLogConsumption(&legacyLog, consumer)
+	require.NoError(t, store.ORM.CreateLogConsumption(&legacy))
+	for _, chainID := range []*big.Int{chainA, chainB} {
+		lc := models.NewLogConsumptionOnChain(&legacyLog, consumer, chainID)
+		exists, err := store.ORM.LogConsumptionExists(&lc)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	}
+}
+
+func TestLogBroadcaster_ReconciliationReport(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+	sub := new(mocks.Subscription)
+	chchRawLogs := make(chan chan<- eth.Log, 1)
+	ethClient.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			chchRawLogs <- args.Get(1).(chan<- eth.Log)
+		}).
+		Return(sub, nil).
+		Once()
+	ethClient.On("GetLatestBlock").Return(eth.Block{Number: 0}, nil)
+	ethClient.On("GetLogs", mock.Anything).Return([]eth.Log{}, nil).Once()
+	sub.On("Err").Return(nil)
+	sub.On("Unsubscribe").Return()
+
+	opts := ethsvc.DefaultLogBroadcasterOptions
+	opts.ReconciliationWindowSize = 10
+	lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, store.ORM, 10, opts)
+	lb.Start()
+	defer lb.Stop()
+
+	// One listener consumes its logs, and the other silently drops them
+	job := createJob(t, store)
+	var consumingHandled, droppingHandled int32
+	newListener := func(consumerType string, handled *int32, markConsumed bool) consumerLogListener {
+		return consumerLogListener{
+			simpleLogListner{func(lb ethsvc.LogBroadcast, err error) {
+				require.NoError(t, err)
+				if markConsumed {
+					require.NoError(t, lb.MarkConsumed())
+				}
+				atomic.AddInt32(handled, 1)
+			}, *job.ID},
+			models.LogConsumer{Type: consumerType, ID: job.ID},
+		}
+	}
+	consuming := newListener(models.LogConsumerTypeJob, &consumingHandled, true)
+	dropping := newListener(models.LogConsumerTypeService, &droppingHandled, false)
+
+	addr := common.Address{1}
+	lb.Register(addr, &consuming)
+	lb.Register(addr, &dropping)
+
+	chRawLogs := <-chchRawLogs
+	logs := []eth.Log{
+		{Address: addr, BlockHash: cltest.NewHash(), BlockNumber: 0, Index: 0},
+		{Address: addr, BlockHash: cltest.NewHash(), BlockNumber: 1, Index: 3},
+	}
+	for _, rawLog := range logs {
+		chRawLogs <- rawLog
+	}
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&consumingHandled) == 2 && atomic.LoadInt32(&droppingHandled) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	requireLogConsumptionCount(t, store, 2)
+
+	delivered, consumed, pendingKeys := lb.ReconciliationReport()
+	assert.Equal(t, uint64(4), delivered)
+	assert.Equal(t, uint64(2), consumed)
+	expected := []ethsvc.LogKey{
+		{BlockHash: logs[0].BlockHash, LogIndex: 0, Consumer: dropping.Consumer()},
+		{BlockHash: logs[1].BlockHash, LogIndex: 3, Consumer: dropping.Consumer()},
+	}
+	assert.Equal(t, expected, pendingKeys)
+
+	disabled := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+	delivered, consumed, pendingKeys = disabled.ReconciliationReport()
+	assert.Zero(t, delivered)
+	assert.Zero(t, consumed)
+	assert.Nil(t, pendingKeys)
+}
+
+func TestLogBroadcaster_ReconciliationReport_IsBounded(t *testing.T) {
+	t.Parallel()
+
+	ethClient := cltest.NewSimulatedEthClient()
+	addr := cltest.NewAddress()
+	for i := 0; i < 5; i++ {
+		ethClient.PushBlock(eth.Log{Address: addr})
+	}
+
+	opts := ethsvc.DefaultLogBroadcasterOptions
+	opts.ReconciliationWindowSize = 3
+	lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+	require.NoError(t, lb.Start())
+	defer lb.Stop()
+
+	listener := new(lifecycleRecordingListener)
+	lb.Register(addr, listener)
+	require.Eventually(t, func() bool {
+		events := listener.Events()
+		return len(events) > 0 && events[len(events)-1] == "OnBackfillComplete"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Only the latest deliveries are kept, none of which were consumed
+	delivered, consumed, pendingKeys := lb.ReconciliationReport()
+	assert.Equal(t, uint64(3), delivered)
+	assert.Zero(t, consumed)
+	require.Len(t, pendingKeys, 3)
+	for _, key := range pendingKeys {
+		assert.Equal(t, listener.Consumer(), key.Consumer)
+	}
+}
+
 func TestLogBroadcaster_ProcessesLogsFromReorgs(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -744,3 +1173,1738 @@ func TestLogBroadcaster_ProcessesLogsFromReorgs(t *testing.T) {
 
 	ethClient.AssertExpectations(t)
 }
+
+func TestLogBroadcaster_ReorgHistory(t *testing.T) {
+	t.Parallel()
+
+	ethClient := new(mocks.Client)
+	sub := new(mocks.Subscription)
+
+	chchRawLogs := make(chan chan<- eth.Log, 1)
+	ethClient.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { chchRawLogs <- args.Get(1).(chan<- eth.Log) }).
+		Return(sub, nil).
+		Once()
+	ethClient.On("GetLatestBlock").
+		Return(eth.Block{Number: hexutil.Uint64(0)}, nil)
+	ethClient.On("GetLogs", mock.Anything).Return([]eth.Log{}, nil).Once()
+	sub.On("Unsubscribe").Return()
+	sub.On("Err").Return(nil)
+
+	var mutex sync.Mutex
+	var persisted []ethsvc.ReorgEvent
+	opts := ethsvc.DefaultLogBroadcasterOptions
+	opts.ReorgHistorySize = 10
+	opts.OnReorg = func(event ethsvc.ReorgEvent) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		persisted = append(persisted, event)
+	}
+	lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+	assert.Empty(t, lb.ReorgHistory())
+	require.NoError(t, lb.Start())
+	defer lb.Stop()
+
+	blockHash0 := cltest.NewHash()
+	blockHash1 := cltest.NewHash()
+	blockHash2 := cltest.NewHash()
+	blockHash1R := cltest.NewHash()
+	blockHash2R := cltest.NewHash()
+
+	addr := cltest.NewAddress()
+	logs := []eth.Log{
+		{Address: addr, BlockHash: blockHash0, BlockNumber: 0, Index: 0},
+		{Address: addr, BlockHash: blockHash1, BlockNumber: 1, Index: 0},
+		{Address: addr, BlockHash: blockHash2, BlockNumber: 2, Index: 0},
+		{Address: addr, BlockHash: blockHash1R, BlockNumber: 1, Index: 0},
+		{Address: addr, BlockHash: blockHash2R, BlockNumber: 2, Index: 0},
+		// Another log from the replacement block isn't another reorg
+		{Address: addr, BlockHash: blockHash2R, BlockNumber: 2, Index: 1},
+	}
+
+	listener := new(lifecycleRecordingListener)
+	lb.Register(addr, listener)
+	chRawLogs := <-chchRawLogs
+	for _, log := range logs {
+		chRawLogs <- log
+	}
+	require.Eventually(t, func() bool { return len(listener.Events()) >= 2+len(logs) }, 5*time.Second, 10*time.Millisecond)
+
+	history := lb.ReorgHistory()
+	require.Len(t, history, 1)
+	assert.Equal(t, uint64(1), history[0].FromHeight)
+	assert.Equal(t, uint64(2), history[0].Depth)
+	assert.Equal(t, blockHash1, history[0].OldBlockHash)
+	assert.Equal(t, blockHash1R, history[0].NewBlockHash)
+	assert.False(t, history[0].DetectedAt.IsZero())
+	mutex.Lock()
+	assert.Equal(t, history, persisted)
+	mutex.Unlock()
+
+	disabled := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+	assert.Nil(t, disabled.ReorgHistory())
+}
+
+func TestLogBroadcaster_ReorgHistory_IsBounded(t *testing.T) {
+	t.Parallel()
+
+	ethClient := cltest.NewSimulatedEthClient()
+	opts := ethsvc.DefaultLogBroadcasterOptions
+	opts.ReorgHistorySize = 2
+	lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+	require.NoError(t, lb.Start())
+	defer lb.Stop()
+
+	addr := cltest.NewAddress()
+	listener := new(lifecycleRecordingListener)
+	lb.Register(addr, listener)
+	require.Eventually(t, func() bool {
+		events := listener.Events()
+		return len(events) > 0 && events[len(events)-1] == "OnBackfillComplete"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	ethClient.PushBlock(eth.Log{Address: addr})
+	for depth := uint64(1); depth <= 3; depth++ {
+		for i := uint64(0); i < depth; i++ {
+			ethClient.PushBlock(eth.Log{Address: addr})
+		}
+		ethClient.Reorg(depth)
+		for i := uint64(0); i < depth; i++ {
+			ethClient.PushBlock(eth.Log{Address: addr})
+		}
+	}
+	require.Eventually(t, func() bool {
+		history := lb.ReorgHistory()
+		return len(history) == 2 && history[0].Depth == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	history := lb.ReorgHistory()
+	assert.Equal(t, uint64(5), history[0].FromHeight)
+	assert.Equal(t, uint64(2), history[1].Depth)
+	assert.Equal(t, uint64(3), history[1].FromHeight)
+}
+
+func TestLogBroadcaster_SnapshotAndRestoreRegistrations(t *testing.T) {
+	t.Parallel()
+
+	addr1, addr2 := common.Address{1}, common.Address{2}
+	blockHash1, blockHash2, blockHash3 := cltest.NewHash(), cltest.NewHash(), cltest.NewHash()
+
+	// newEthClient returns a client whose log subscription is passed to
+	// chchRawLogs, and whose historical logs from block 1 are historicalLogs
+	newEthClient := func(historicalLogs []eth.Log) (*mocks.Client, chan chan<- eth.Log) {
+		ethClient := new(mocks.Client)
+		sub := new(mocks.Subscription)
+		chchRawLogs := make(chan chan<- eth.Log, 1)
+		ethClient.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { chchRawLogs <- args.Get(1).(chan<- eth.Log) }).
+			Return(sub, nil)
+		ethClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(0)}, nil)
+		for _, address := range []common.Address{addr1, addr2} {
+			var logs []eth.Log
+			for _, rawLog := range historicalLogs {
+				if rawLog.Address == address {
+					logs = append(logs, rawLog)
+				}
+			}
+			address := address
+			ethClient.On("GetLogs", mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+				return q.FromBlock != nil && q.FromBlock.Cmp(big.NewInt(1)) == 0 &&
+					len(q.Addresses) == 1 && q.Addresses[0] == address
+			})).Return(logs, nil).Once()
+		}
+		ethClient.On("GetLogs", mock.Anything).Return([]eth.Log{}, nil)
+		sub.On("Unsubscribe").Return()
+		sub.On("Err").Return(nil)
+		return ethClient, chchRawLogs
+	}
+	handledLogs := func(listener *lifecycleRecordingListener) (handled []string) {
+		for _, event := range listener.Events() {
+			if strings.HasPrefix(event, "HandleLog") {
+				handled = append(handled, event)
+			}
+		}
+		return handled
+	}
+
+	ethClient1, chchRawLogs1 := newEthClient(nil)
+	lb1 := ethsvc.NewLogBroadcaster(ethClient1, nil, 10)
+	require.NoError(t, lb1.Start())
+
+	listener1, listener2 := new(lifecycleRecordingListener), new(lifecycleRecordingListener)
+	lb1.Register(addr1, listener1)
+	lb1.Register(addr2, listener2)
+	chRawLogs1 := <-chchRawLogs1
+	chRawLogs1 <- eth.Log{Address: addr1, BlockHash: blockHash1, BlockNumber: 1}
+	require.Eventually(t, func() bool { return len(handledLogs(listener1)) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	registrations := lb1.SnapshotRegistrations()
+	require.Len(t, registrations, 2)
+	assert.Equal(t, addr1, registrations[0].Address)
+	assert.Equal(t, listener1, registrations[0].Listener)
+	assert.Equal(t, addr2, registrations[1].Address)
+	assert.Equal(t, listener2, registrations[1].Listener)
+	for _, r := range registrations {
+		assert.Equal(t, big.NewInt(1), r.FromBlock)
+	}
+	lb1.Stop()
+	assert.Nil(t, lb1.SnapshotRegistrations())
+	ethClient1.AssertNumberOfCalls(t, "SubscribeToLogs", 1)
+
+	// The fresh broadcaster delivers the logs emitted meanwhile, then live logs
+	ethClient2, chchRawLogs2 := newEthClient([]eth.Log{
+		{Address: addr1, BlockHash: blockHash1, BlockNumber: 1},
+		{Address: addr2, BlockHash: blockHash2, BlockNumber: 2},
+	})
+	lb2 := ethsvc.NewLogBroadcaster(ethClient2, nil, 10)
+	require.NoError(t, lb2.Start())
+	defer lb2.Stop()
+	lb2.RestoreRegistrations(registrations)
+
+	chRawLogs2 := <-chchRawLogs2
+	chRawLogs2 <- eth.Log{Address: addr1, BlockHash: blockHash3, BlockNumber: 3}
+	require.Eventually(t, func() bool {
+		return len(handledLogs(listener1)) == 3 && len(handledLogs(listener2)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	// The already delivered log is redelivered, to be deduplicated as usual
+	assert.Equal(t, []string{"HandleLog(1)", "HandleLog(1)", "HandleLog(3)"}, handledLogs(listener1))
+	assert.Equal(t, []string{"HandleLog(2)"}, handledLogs(listener2))
+
+	// A single subscription covers both addresses
+	ethClient2.AssertNumberOfCalls(t, "SubscribeToLogs", 1)
+	for _, call := range ethClient2.Calls {
+		if call.Method == "SubscribeToLogs" {
+			q := call.Arguments.Get(2).(ethereum.FilterQuery)
+			assert.Equal(t, []common.Address{addr1, addr2}, q.Addresses)
+		}
+	}
+}
+
+func TestLogBroadcaster_DeletesConsumptionsOfReorgedBlocks(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+	sub := new(mocks.Subscription)
+	chchRawLogs := make(chan chan<- eth.Log, 1)
+	ethClient.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { chchRawLogs <- args.Get(1).(chan<- eth.Log) }).
+		Return(sub, nil).
+		Once()
+	ethClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(0)}, nil)
+	ethClient.On("GetLogs", mock.Anything).Return([]eth.Log{}, nil).Once()
+	sub.On("Unsubscribe").Return()
+	sub.On("Err").Return(nil)
+
+	opts := ethsvc.DefaultLogBroadcasterOptions
+	opts.DeleteReorgedConsumptions = true
+	lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, store.ORM, 10, opts)
+	require.NoError(t, lb.Start())
+	defer lb.Stop()
+
+	job := createJob(t, store)
+	addr := cltest.NewAddress()
+	blockHash0 := cltest.NewHash()
+	blockHash1 := cltest.NewHash()
+	blockHash2 := cltest.NewHash()
+	blockHash1R := cltest.NewHash()
+	logs := []eth.Log{
+		{Address: addr, BlockHash: blockHash0, BlockNumber: 0, Index: 0},
+		{Address: addr, BlockHash: blockHash1, BlockNumber: 1, Index: 0},
+		{Address: addr, BlockHash: blockHash2, BlockNumber: 2, Index: 0},
+	}
+
+	var mutex sync.Mutex
+	var delivered []common.Hash
+	listener := simpleLogListner{func(lb ethsvc.LogBroadcast, err error) {
+		require.NoError(t, err)
+		consumed, err := lb.WasAlreadyConsumed()
+		require.NoError(t, err)
+		if !consumed {
+			require.NoError(t, lb.MarkConsumed())
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		delivered = append(delivered, lb.Log().(*eth.Log).BlockHash)
+	}, *job.ID}
+	lb.Register(addr, &listener)
+	chRawLogs := <-chchRawLogs
+	for _, log := range logs {
+		chRawLogs <- log
+	}
+	requireLogConsumptionCount(t, store, 3)
+
+	// A consumption by another consumer of the orphaned block is deleted too
+	other := models.LogConsumer{Type: models.LogConsumerTypeService, ID: job.ID}
+	otherConsumption := models.NewLogConsumption(&logs[1], other)
+	require.NoError(t, store.CreateLogConsumption(&otherConsumption))
+	requireLogConsumptionCount(t, store, 4)
+
+	// Replacing block 1 orphans blocks 1 and 2, but not block 0
+	chRawLogs <- eth.Log{Address: addr, BlockHash: blockHash1R, BlockNumber: 1, Index: 0}
+	requireLogConsumptionCount(t, store, 2)
+	for _, log := range logs[1:] {
+		consumed, err := store.HasConsumedLog(&log, listener.Consumer())
+		require.NoError(t, err)
+		assert.False(t, consumed)
+	}
+	consumed, err := store.HasConsumedLog(&logs[0], listener.Consumer())
+	require.NoError(t, err)
+	assert.True(t, consumed)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Len(t, delivered, 4)
+	// The replacement is delivered unconsumed
+	assert.Equal(t, blockHash1R, delivered[3])
+}
+
+func TestLogBroadcaster_BackfillErrorsWrapErrBackfillFailed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetLogs fails", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		rpcErr := errors.New("eth_getLogs failed")
+		ethClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(123)}, nil)
+		ethClient.On("GetLogs", mock.Anything).Return(nil, rpcErr)
+
+		lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+		_, err := ethsvc.ExposedFetchBackfillLogs(lb)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ethsvc.ErrBackfillFailed))
+		require.True(t, errors.Is(err, rpcErr))
+		require.False(t, errors.Is(err, ethsvc.ErrSubscriptionClosed))
+
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("GetLatestBlock fails", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		ethClient.On("GetLatestBlock").Return(eth.Block{}, errors.New("eth_getBlockByNumber failed"))
+
+		lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+		_, err := ethsvc.ExposedFetchBackfillLogs(lb)
+		require.True(t, errors.Is(err, ethsvc.ErrBackfillFailed))
+
+		ethClient.AssertExpectations(t)
+	})
+}
+
+func TestLogBroadcaster_BackfillDeliversLogsInBlockAndIndexOrder(t *testing.T) {
+	t.Parallel()
+
+	addr := cltest.NewAddress()
+	unordered := []eth.Log{
+		{Address: addr, BlockNumber: 3, Index: 0},
+		{Address: addr, BlockNumber: 2, Index: 7},
+		{Address: addr, BlockNumber: 2, Index: 1},
+		{Address: addr, BlockNumber: 3, Index: 0, Removed: true},
+		{Address: addr, BlockNumber: 1, Index: 4},
+		{Address: addr, BlockNumber: 2, Index: 3},
+	}
+
+	ethClient := new(mocks.Client)
+	ethClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(3)}, nil)
+	ethClient.On("GetLogs", mock.Anything).Return(unordered, nil)
+
+	lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+	logs, err := ethsvc.ExposedFetchBackfillLogs(lb)
+	require.NoError(t, err)
+
+	type position struct {
+		BlockNumber uint64
+		Index       uint
+		Removed     bool
+	}
+	var positions []position
+	for _, log := range logs {
+		positions = append(positions, position{log.BlockNumber, log.Index, log.Removed})
+	}
+	// Logs at the same position keep their relative order
+	require.Equal(t, []position{
+		{1, 4, false},
+		{2, 1, false},
+		{2, 3, false},
+		{2, 7, false},
+		{3, 0, false},
+		{3, 0, true},
+	}, positions)
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestLogBroadcaster_BackfillFlagsLogsEndingWellShortOfHead(t *testing.T) {
+	t.Parallel()
+
+	const head uint64 = 1000
+	addr := cltest.NewAddress()
+
+	tests := []struct {
+		name          string
+		logs          []eth.Log
+		wantSuspected [][2]uint64
+	}{
+		{"truncated",
+			[]eth.Log{{Address: addr, BlockNumber: 800}, {Address: addr, BlockNumber: 850}},
+			[][2]uint64{{851, head}}},
+		{"within gap",
+			[]eth.Log{{Address: addr, BlockNumber: 900}, {Address: addr, BlockNumber: head - 100}},
+			nil},
+		{"empty", []eth.Log{}, nil},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			ethClient := new(mocks.Client)
+			ethClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(head)}, nil)
+			ethClient.On("GetLogs", mock.Anything).Return(test.logs, nil)
+
+			var suspected [][2]uint64
+			opts := ethsvc.DefaultLogBroadcasterOptions
+			opts.BackfillSuspectGap = 100
+			opts.OnBackfillSuspect = func(from, to uint64) {
+				suspected = append(suspected, [2]uint64{from, to})
+			}
+			lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 200, opts)
+			logs, err := ethsvc.ExposedFetchBackfillLogs(lb)
+			require.NoError(t, err)
+			assert.Len(t, logs, len(test.logs))
+			assert.Equal(t, test.wantSuspected, suspected)
+
+			ethClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLogBroadcaster_BackfillUsesInjectedFilterQueryBuilder(t *testing.T) {
+	t.Parallel()
+
+	const blockHeight uint64 = 123
+	topic := cltest.NewHash()
+
+	ethClient := new(mocks.Client)
+	ethClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(blockHeight)}, nil)
+	ethClient.On("GetLogs", mock.Anything).Return([]eth.Log{}, nil)
+
+	opts := ethsvc.DefaultLogBroadcasterOptions
+	opts.FilterQueryBuilder = func(fromBlock *big.Int, addresses []common.Address) ethereum.FilterQuery {
+		q := ethsvc.DefaultFilterQueryBuilder(fromBlock, addresses)
+		q.ToBlock = big.NewInt(int64(blockHeight - 2))
+		q.Topics = [][]common.Hash{{topic}}
+		return q
+	}
+	lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+	_, err := ethsvc.ExposedFetchBackfillLogs(lb)
+	require.NoError(t, err)
+
+	ethClient.AssertCalled(t, "GetLogs", ethereum.FilterQuery{
+		FromBlock: big.NewInt(int64(blockHeight - 10)),
+		ToBlock:   big.NewInt(int64(blockHeight - 2)),
+		Topics:    [][]common.Hash{{topic}},
+	})
+	ethClient.AssertExpectations(t)
+}
+
+func TestBuildFilterQuery(t *testing.T) {
+	t.Parallel()
+
+	addr1 := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	addr2 := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	addr3 := common.HexToAddress("0x0000000000000000000000000000000000000003")
+	listener, other := new(lifecycleRecordingListener), new(lifecycleRecordingListener)
+
+	tests := []struct {
+		name          string
+		fromBlock     *big.Int
+		listeners     map[common.Address][]ethsvc.LogListener
+		wantAddresses []common.Address
+	}{
+		{"empty", big.NewInt(1), map[common.Address][]ethsvc.LogListener{}, nil},
+		{"single address", big.NewInt(42),
+			map[common.Address][]ethsvc.LogListener{addr1: {listener}},
+			[]common.Address{addr1}},
+		{"multiple addresses in ascending order", nil,
+			map[common.Address][]ethsvc.LogListener{
+				addr3: {listener},
+				addr1: {listener, other},
+				addr2: {other},
+			},
+			[]common.Address{addr1, addr2, addr3}},
+		{"addresses without listeners are omitted", big.NewInt(7),
+			map[common.Address][]ethsvc.LogListener{addr1: {}, addr2: {listener}},
+			[]common.Address{addr2}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			q := ethsvc.BuildFilterQuery(test.fromBlock, test.listeners)
+			assert.Equal(t, test.wantAddresses, q.Addresses)
+			assert.Equal(t, test.fromBlock, q.FromBlock)
+			assert.Nil(t, q.ToBlock)
+			assert.Empty(t, q.Topics)
+		})
+	}
+}
+
+func TestLogBroadcaster_HandsOffFromBackfillToLiveLogsAtCapturedHead(t *testing.T) {
+	t.Parallel()
+
+	const head uint64 = 10
+
+	addr := cltest.NewAddress()
+	backfilled := []eth.Log{
+		{Address: addr, BlockNumber: head - 1, BlockHash: cltest.NewHash()},
+		{Address: addr, BlockNumber: head, BlockHash: cltest.NewHash()},
+	}
+
+	ethClient := new(mocks.Client)
+	sub := new(mocks.Subscription)
+	chchRawLogs := make(chan chan<- eth.Log, 1)
+	ethClient.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			chchRawLogs <- args.Get(1).(chan<- eth.Log)
+		}).
+		Return(sub, nil).
+		Once()
+	ethClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(head)}, nil)
+	ethClient.On("GetLogs", mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+		return q.ToBlock != nil && q.ToBlock.Uint64() == head
+	})).Return(backfilled, nil).Once()
+	sub.On("Err").Return(nil)
+	sub.On("Unsubscribe").Return()
+
+	lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+	lb.Start()
+	defer lb.Stop()
+
+	listener := new(lifecycleRecordingListener)
+	lb.Register(addr, listener)
+	chRawLogs := <-chchRawLogs
+
+	// The subscription also delivers the log from the captured head, which the
+	// backfill already covered, then a log from a new block above it
+	chRawLogs <- backfilled[1]
+	chRawLogs <- eth.Log{Address: addr, BlockNumber: head + 1, BlockHash: cltest.NewHash()}
+
+	expected := []string{"OnConnect", "HandleLog(9)", "HandleLog(10)", "OnBackfillComplete", "HandleLog(11)"}
+	require.Eventually(t, func() bool { return len(listener.Events()) == len(expected) }, 5*time.Second, 10*time.Millisecond)
+	require.Never(t, func() bool { return len(listener.Events()) > len(expected) }, 500*time.Millisecond, 10*time.Millisecond)
+	assert.Equal(t, expected, listener.Events())
+	ethClient.AssertExpectations(t)
+}
+
+func TestLogBroadcaster_SubscribesThenBackfills(t *testing.T) {
+	t.Parallel()
+
+	const blockHeight uint64 = 123
+
+	mockClient := new(mocks.Client)
+	sub := new(mocks.Subscription)
+	mockClient.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).Return(sub, nil)
+	mockClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(blockHeight)}, nil)
+	mockClient.On("GetLogs", mock.Anything).Return([]eth.Log{}, nil)
+	sub.On("Err").Return(nil)
+	sub.On("Unsubscribe").Return()
+
+	ethClient := cltest.NewRecordingClient(mockClient)
+	lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+	lb.Start()
+	defer lb.Stop()
+
+	addr := cltest.NewAddress()
+	listener := new(mocks.LogListener)
+	listener.On("OnConnect").Return()
+	listener.On("OnDisconnect").Return()
+	lb.Register(addr, listener)
+
+	require.Eventually(t, func() bool { return len(ethClient.Calls("GetLogs")) == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"SubscribeToLogs", "GetLatestBlock", "GetLogs"}, ethClient.Methods())
+
+	subscribeQuery := ethClient.Calls("SubscribeToLogs")[0].Args[0].(ethereum.FilterQuery)
+	require.Equal(t, []common.Address{addr}, subscribeQuery.Addresses)
+
+	backfillQuery := ethClient.Calls("GetLogs")[0].Args[0].(ethereum.FilterQuery)
+	require.Equal(t, []common.Address{addr}, backfillQuery.Addresses)
+	require.Equal(t, big.NewInt(int64(blockHeight-10)), backfillQuery.FromBlock)
+}
+
+func TestLogBroadcaster_ResubscribesWithAllAddresses(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(mocks.Client)
+	sub := new(mocks.Subscription)
+	mockClient.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).Return(sub, nil)
+	mockClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(123)}, nil)
+	mockClient.On("GetLogs", mock.Anything).Return([]eth.Log{}, nil)
+	sub.On("Err").Return(nil)
+	sub.On("Unsubscribe").Return()
+
+	ethClient := cltest.NewRecordingClient(mockClient)
+	lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+	lb.Start()
+	defer lb.Stop()
+
+	newListener := func() *mocks.LogListener {
+		listener := new(mocks.LogListener)
+		listener.On("OnConnect").Return()
+		listener.On("OnDisconnect").Return()
+		return listener
+	}
+
+	addr1, addr2 := cltest.NewAddress(), cltest.NewAddress()
+	lb.Register(addr1, newListener())
+	require.Eventually(t, func() bool { return len(ethClient.Calls("GetLogs")) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	ethClient.Reset()
+	lb.Register(addr2, newListener())
+	require.Eventually(t, func() bool { return len(ethClient.Calls("GetLogs")) == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"SubscribeToLogs", "GetLatestBlock", "GetLogs"}, ethClient.Methods())
+
+	subscribeQuery := ethClient.Calls("SubscribeToLogs")[0].Args[0].(ethereum.FilterQuery)
+	require.ElementsMatch(t, []common.Address{addr1, addr2}, subscribeQuery.Addresses)
+}
+
+func TestLogBroadcaster_HealthReport(t *testing.T) {
+	t.Parallel()
+
+	ethClient := cltest.NewSimulatedEthClient()
+	addr := cltest.NewAddress()
+	for i := 0; i < 5; i++ {
+		ethClient.PushBlock()
+	}
+	ethClient.PushBlock(eth.Log{Address: addr})
+
+	lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+	health := lb.HealthReport()
+	require.False(t, health.Subscribed)
+	require.Equal(t, ethsvc.BackfillStatusIdle, health.BackfillStatus)
+	require.True(t, health.LastLogReceivedAt.IsZero())
+
+	lb.Start()
+	defer lb.Stop()
+
+	chReceived := make(chan eth.Log, 1)
+	listener := simpleLogListner{
+		func(lb ethsvc.LogBroadcast, err error) { chReceived <- *lb.Log().(*eth.Log) },
+		models.ID{},
+	}
+	beforeBackfill := time.Now()
+	lb.Register(addr, &listener)
+
+	// The log in block 6 is backfilled
+	require.Equal(t, uint64(6), (<-chReceived).BlockNumber)
+	require.True(t, lb.HealthReport().Subscribed)
+	require.Eventually(t, func() bool {
+		return lb.HealthReport().BackfillStatus == ethsvc.BackfillStatusComplete
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, uint64(6), lb.HealthReport().LastBlockSeen)
+	require.False(t, lb.HealthReport().LastLogReceivedAt.Before(beforeBackfill))
+
+	// The log in block 7 arrives through the subscription
+	ethClient.PushBlock(eth.Log{Address: addr})
+	require.Equal(t, uint64(7), (<-chReceived).BlockNumber)
+
+	health = lb.HealthReport()
+	require.True(t, health.Subscribed)
+	require.Equal(t, uint64(7), health.LastBlockSeen)
+	require.Equal(t, ethsvc.BackfillStatusComplete, health.BackfillStatus)
+
+	serialized, err := json.Marshal(health)
+	require.NoError(t, err)
+	require.Contains(t, string(serialized), `"lastBlockSeen":7`)
+	require.Contains(t, string(serialized), `"backfillStatus":"complete"`)
+}
+
+func TestLogBroadcaster_RecoversFromPanickingListener(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		policy            ethsvc.ListenerPanicPolicy
+		expectedPanicking int
+	}{
+		{"never unregisters", ethsvc.DefaultListenerPanicPolicy, 5},
+		{"unregisters after 2 panics", ethsvc.ListenerPanicPolicy{MaxConsecutivePanics: 2}, 2},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			ethClient := new(mocks.Client)
+			sub := new(mocks.Subscription)
+
+			chchRawLogs := make(chan chan<- eth.Log, 10)
+			ethClient.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { chchRawLogs <- args.Get(1).(chan<- eth.Log) }).
+				Return(sub, nil)
+			ethClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(123)}, nil)
+			ethClient.On("GetLogs", mock.Anything).Return([]eth.Log{}, nil)
+			sub.On("Err").Return(nil)
+			sub.On("Unsubscribe").Return()
+
+			opts := ethsvc.DefaultLogBroadcasterOptions
+			opts.PanicPolicy = test.policy
+			lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+			lb.Start()
+			defer lb.Stop()
+
+			addr := cltest.NewAddress()
+			var panicking int
+			panickingListener := simpleLogListner{
+				func(ethsvc.LogBroadcast, error) {
+					panicking++
+					panic("listener is broken")
+				},
+				*models.NewID(),
+			}
+			chHealthy := make(chan struct{}, 10)
+			healthyListener := simpleLogListner{
+				func(ethsvc.LogBroadcast, error) { chHealthy <- struct{}{} },
+				*models.NewID(),
+			}
+			lb.Register(addr, &panickingListener)
+			lb.Register(addr, &healthyListener)
+
+			chRawLogs := <-chchRawLogs
+			for i := 0; i < 5; i++ {
+				chRawLogs <- eth.Log{Address: addr, BlockHash: cltest.NewHash(), BlockNumber: uint64(i)}
+				select {
+				case <-chHealthy:
+				case <-time.After(5 * time.Second):
+					t.Fatalf("healthy listener did not receive log %d", i)
+				}
+			}
+
+			// Registrations are handled by the same goroutine as logs, so once
+			// this returns, every log has been passed to both listeners
+			lb.Register(addr, &simpleLogListner{func(ethsvc.LogBroadcast, error) {}, *models.NewID()})
+			require.Equal(t, test.expectedPanicking, panicking)
+		})
+	}
+}
+
+func TestLogBroadcaster_ResubscribesWhenSubscriptionIsStale(t *testing.T) {
+	t.Parallel()
+
+	const stalenessTimeout = 100 * time.Millisecond
+
+	simulatedClient := cltest.NewSimulatedEthClient()
+	ethClient := cltest.NewRecordingClient(simulatedClient)
+	lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, ethsvc.LogBroadcasterOptions{
+		StalenessTimeout: stalenessTimeout,
+	})
+	lb.Start()
+	defer lb.Stop()
+
+	listener := new(mocks.LogListener)
+	listener.On("OnConnect").Return()
+	listener.On("OnDisconnect").Return()
+	lb.Register(cltest.NewAddress(), listener)
+
+	require.Eventually(t, func() bool { return lb.HealthReport().Subscribed }, 5*time.Second, 10*time.Millisecond)
+	subscriptions := func() int { return len(ethClient.Calls("SubscribeToLogs")) }
+	require.Equal(t, 1, subscriptions())
+
+	// A quiet feed on a live chain is not stale
+	deadline := time.Now().Add(10 * stalenessTimeout)
+	for time.Now().Before(deadline) {
+		simulatedClient.PushBlock()
+		time.Sleep(stalenessTimeout / 5)
+	}
+	require.Equal(t, 1, subscriptions())
+
+	// Once the head stops advancing too, the subscription is recreated
+	require.Eventually(t, func() bool { return subscriptions() > 1 }, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return simulatedClient.LogSubscriptionCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLogBroadcaster_BackfillAccountsForHeadSafetyDepth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		head              uint64
+		headSafetyDepth   uint64
+		expectedFromBlock int64
+	}{
+		{"no safety depth", 100, 0, 90},
+		{"safety depth", 100, 12, 78},
+		{"safety depth beyond genesis", 5, 12, 0},
+		{"backfill depth beyond genesis", 15, 12, 0},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ethClient := new(mocks.Client)
+			ethClient.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(test.head)}, nil)
+			ethClient.On("GetLogs", mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+				return q.FromBlock.Int64() == test.expectedFromBlock && q.ToBlock.Uint64() == test.head
+			})).Return([]eth.Log{}, nil).Once()
+
+			opts := ethsvc.DefaultLogBroadcasterOptions
+			opts.HeadSafetyDepth = test.headSafetyDepth
+			lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+			_, err := ethsvc.ExposedFetchBackfillLogs(lb)
+			require.NoError(t, err)
+
+			ethClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLogBroadcaster_HoldsLogsUntilBeyondHeadSafetyDepth(t *testing.T) {
+	t.Parallel()
+
+	const headSafetyDepth = 2
+
+	ethClient := cltest.NewSimulatedEthClient()
+	opts := ethsvc.DefaultLogBroadcasterOptions
+	opts.HeadSafetyDepth = headSafetyDepth
+	lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+	lb.Start()
+	defer lb.Stop()
+
+	addr := cltest.NewAddress()
+	var delivered int32
+	listener := new(mocks.LogListener)
+	listener.On("OnConnect").Return()
+	listener.On("OnDisconnect").Return()
+	listener.On("Consumer").Return(models.LogConsumer{})
+	listener.On("HandleLog", mock.Anything, nil).Run(func(mock.Arguments) {
+		atomic.AddInt32(&delivered, 1)
+	}).Return()
+	lb.Register(addr, listener)
+	require.Eventually(t, func() bool { return ethClient.LogSubscriptionCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// A log which is reorged out before it's deep enough is never delivered
+	ethClient.PushBlock(eth.Log{Address: addr})
+	ethClient.Reorg(1)
+	ethClient.PushBlock()
+
+	ethClient.PushBlock(eth.Log{Address: addr})
+	ethClient.PushBlock()
+	require.Never(t, func() bool { return atomic.LoadInt32(&delivered) > 0 }, 1500*time.Millisecond, 10*time.Millisecond)
+
+	ethClient.PushBlock()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&delivered) == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.Never(t, func() bool { return atomic.LoadInt32(&delivered) > 1 }, 1500*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestLogBroadcaster_DropsBufferedLogsOfUnregisteredListener(t *testing.T) {
+	t.Parallel()
+
+	const headSafetyDepth = 2
+
+	ethClient := cltest.NewSimulatedEthClient()
+	opts := ethsvc.DefaultLogBroadcasterOptions
+	opts.HeadSafetyDepth = headSafetyDepth
+	opts.ReconciliationWindowSize = 10
+	lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+	lb.Start()
+	defer lb.Stop()
+
+	removedAddr, otherAddr := cltest.NewAddress(), cltest.NewAddress()
+	removed, other := new(lifecycleRecordingListener), new(lifecycleRecordingListener)
+	lb.Register(removedAddr, removed)
+	lb.Register(otherAddr, other)
+	require.Eventually(t, func() bool { return ethClient.LogSubscriptionCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// The log is held, as it isn't deep enough yet, when its listener unregisters
+	ethClient.PushBlock(eth.Log{Address: removedAddr})
+	require.Eventually(t, func() bool { return lb.HealthReport().LastBlockSeen == 1 }, 5*time.Second, 10*time.Millisecond)
+	lb.Unregister(removedAddr, removed)
+	require.Eventually(t, func() bool {
+		events := removed.Events()
+		return len(events) > 0 && events[len(events)-1] == "OnDisconnect"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The head moves well past it, as the other listener's log shows
+	ethClient.PushBlock(eth.Log{Address: otherAddr})
+	for i := 0; i < headSafetyDepth; i++ {
+		ethClient.PushBlock()
+	}
+	require.Eventually(t, func() bool {
+		for _, event := range other.Events() {
+			if event == "HandleLog(2)" {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for _, event := range removed.Events() {
+		assert.NotContains(t, event, "HandleLog")
+	}
+	delivered, consumed, pendingKeys := lb.ReconciliationReport()
+	assert.Equal(t, uint64(1), delivered)
+	assert.Zero(t, consumed)
+	require.Len(t, pendingKeys, 1)
+	assert.Equal(t, other.Consumer(), pendingKeys[0].Consumer)
+}
+
+func TestLogBroadcaster_CallsListenerLifecycleInOrder(t *testing.T) {
+	t.Parallel()
+
+	ethClient := cltest.NewSimulatedEthClient()
+	addr := cltest.NewAddress()
+	ethClient.PushBlock(eth.Log{Address: addr})
+	ethClient.PushBlock(eth.Log{Address: addr})
+
+	lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+	lb.Start()
+	defer lb.Stop()
+
+	listener := new(lifecycleRecordingListener)
+	lb.Register(addr, listener)
+
+	backfillComplete := []string{"OnConnect", "HandleLog(1)", "HandleLog(2)", "OnBackfillComplete"}
+	require.Eventually(t, func() bool { return len(listener.Events()) == len(backfillComplete) }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, backfillComplete, listener.Events())
+
+	ethClient.PushBlock(eth.Log{Address: addr})
+	live := append(backfillComplete, "HandleLog(3)")
+	require.Eventually(t, func() bool { return len(listener.Events()) == len(live) }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, live, listener.Events())
+}
+
+func TestLogBroadcaster_TracksHeadFromNewHeadsSubscription(t *testing.T) {
+	t.Parallel()
+
+	const headSafetyDepth = 2
+
+	simulatedClient := cltest.NewSimulatedEthClient()
+	ethClient := cltest.NewRecordingClient(simulatedClient)
+	opts := ethsvc.DefaultLogBroadcasterOptions
+	opts.HeadSafetyDepth = headSafetyDepth
+	opts.SubscribeToHeads = true
+	lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+	lb.Start()
+	defer lb.Stop()
+
+	addr := cltest.NewAddress()
+	var delivered int32
+	listener := new(mocks.LogListener)
+	listener.On("OnConnect").Return()
+	listener.On("OnDisconnect").Return()
+	listener.On("Consumer").Return(models.LogConsumer{})
+	listener.On("HandleLog", mock.Anything, nil).Run(func(mock.Arguments) {
+		atomic.AddInt32(&delivered, 1)
+	}).Return()
+	lb.Register(addr, listener)
+	require.Eventually(t, func() bool { return simulatedClient.HeadSubscriptionCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	polls := len(ethClient.Calls("GetLatestBlock"))
+
+	simulatedClient.PushBlock(eth.Log{Address: addr})
+	simulatedClient.PushBlock()
+	require.Never(t, func() bool { return atomic.LoadInt32(&delivered) > 0 }, 1500*time.Millisecond, 10*time.Millisecond)
+
+	// The held log is released by the new head, without polling for it
+	simulatedClient.PushBlock()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&delivered) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, polls, len(ethClient.Calls("GetLatestBlock")))
+}
+
+func TestLogBroadcaster_NotifiesHeadListenersOfNewHeads(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		subscribeToHeads bool
+	}{
+		{"polling", false},
+		{"new heads subscription", true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ethClient := cltest.NewSimulatedEthClient()
+			ethClient.PushBlock()
+			opts := ethsvc.DefaultLogBroadcasterOptions
+			opts.SubscribeToHeads = test.subscribeToHeads
+			lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+			lb.Start()
+			defer lb.Stop()
+
+			// Registered for two addresses, but told about each head once
+			listener := new(headRecordingListener)
+			lb.Register(cltest.NewAddress(), listener)
+			lb.Register(cltest.NewAddress(), listener)
+			require.Eventually(t, func() bool { return ethClient.LogSubscriptionCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+			if test.subscribeToHeads {
+				require.Eventually(t, func() bool { return ethClient.HeadSubscriptionCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+			}
+
+			var expected []uint64
+			for i := 0; i < 3; i++ {
+				head := ethClient.PushBlock()
+				expected = append(expected, head)
+				require.Eventually(t, func() bool {
+					heads := listener.Heads()
+					return len(heads) > 0 && heads[len(heads)-1] == head
+				}, 5*time.Second, 10*time.Millisecond)
+			}
+
+			// Polling may also have seen the head from before the first push
+			heads := listener.Heads()
+			if len(heads) > len(expected) {
+				assert.Equal(t, []uint64{1}, heads[:len(heads)-len(expected)])
+				heads = heads[len(heads)-len(expected):]
+			}
+			assert.Equal(t, expected, heads)
+		})
+	}
+}
+
+// headPollClock is a fake clock which records the durations it's asked to wait
+// for, and only fires when ticked
+type headPollClock struct {
+	mutex     sync.Mutex
+	durations []time.Duration
+	chTicks   chan time.Time
+}
+
+func newHeadPollClock() *headPollClock {
+	return &headPollClock{chTicks: make(chan time.Time)}
+}
+
+func (c *headPollClock) After(d time.Duration) <-chan time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.durations = append(c.durations, d)
+	return c.chTicks
+}
+
+func (c *headPollClock) Durations() []time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]time.Duration{}, c.durations...)
+}
+
+// tick fires the clock, reporting whether anything was waiting on it
+func (c *headPollClock) tick() bool {
+	select {
+	case c.chTicks <- time.Now():
+		return true
+	case <-time.After(500 * time.Millisecond):
+		return false
+	}
+}
+
+func TestLogBroadcaster_PollsHeadAtHeadPollInterval(t *testing.T) {
+	t.Parallel()
+
+	const headPollInterval = 7 * time.Second
+
+	tests := []struct {
+		name             string
+		subscribeToHeads bool
+	}{
+		{"polling", false},
+		{"new heads subscription", true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			simulatedClient := cltest.NewSimulatedEthClient()
+			simulatedClient.PushBlock()
+			ethClient := cltest.NewRecordingClient(simulatedClient)
+			clock := newHeadPollClock()
+			opts := ethsvc.DefaultLogBroadcasterOptions
+			opts.SubscribeToHeads = test.subscribeToHeads
+			opts.HeadPollInterval = headPollInterval
+			opts.Clock = clock
+			lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+			lb.Start()
+			defer lb.Stop()
+
+			listener := new(headRecordingListener)
+			lb.Register(cltest.NewAddress(), listener)
+			require.Eventually(t, func() bool {
+				return simulatedClient.LogSubscriptionCount() == 1 &&
+					lb.HealthReport().BackfillStatus == ethsvc.BackfillStatusComplete
+			}, 5*time.Second, 10*time.Millisecond)
+			if test.subscribeToHeads {
+				require.Eventually(t, func() bool { return simulatedClient.HeadSubscriptionCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+			}
+			polls := len(ethClient.Calls("GetLatestBlock"))
+
+			if test.subscribeToHeads {
+				// Heads arrive without polling, and no poll is even scheduled
+				head := simulatedClient.PushBlock()
+				require.Eventually(t, func() bool {
+					heads := listener.Heads()
+					return len(heads) > 0 && heads[len(heads)-1] == head
+				}, 5*time.Second, 10*time.Millisecond)
+				assert.False(t, clock.tick())
+				assert.Equal(t, polls, len(ethClient.Calls("GetLatestBlock")))
+				return
+			}
+
+			// One poll per tick, each scheduled HeadPollInterval after the last
+			for i := 1; i <= 3; i++ {
+				require.True(t, clock.tick())
+				require.Eventually(t, func() bool {
+					return len(ethClient.Calls("GetLatestBlock")) == polls+i
+				}, 5*time.Second, 10*time.Millisecond)
+			}
+			for _, d := range clock.Durations() {
+				assert.Equal(t, headPollInterval, d)
+			}
+		})
+	}
+}
+
+func TestLogBroadcaster_FailsOverToNextClient(t *testing.T) {
+	t.Parallel()
+
+	addr := cltest.NewAddress()
+
+	// The primary serves one live log, and then its subscription fails
+	primary := new(mocks.Client)
+	primarySub := new(mocks.Subscription)
+	chPrimaryErr := make(chan error, 1)
+	chchPrimaryLogs := make(chan chan<- eth.Log, 1)
+	primary.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			chchPrimaryLogs <- args.Get(1).(chan<- eth.Log)
+		}).
+		Return(primarySub, nil).
+		Once()
+	primary.On("GetLatestBlock").Return(eth.Block{Number: 1}, nil)
+	primary.On("GetLogs", mock.Anything).Return(nil, nil)
+	primarySub.On("Err").Return((<-chan error)(chPrimaryErr))
+	primarySub.On("Unsubscribe").Return()
+
+	secondary := cltest.NewSimulatedEthClient()
+	secondary.PushBlock()
+
+	lb, err := ethsvc.NewLogBroadcasterWithClients([]eth.Client{primary, secondary}, nil, 10,
+		ethsvc.DefaultLogBroadcasterOptions)
+	require.NoError(t, err)
+	lb.Start()
+	defer lb.Stop()
+
+	listener := new(lifecycleRecordingListener)
+	lb.Register(addr, listener)
+	chPrimaryLogs := <-chchPrimaryLogs
+	chPrimaryLogs <- eth.Log{Address: addr, BlockNumber: 1, BlockHash: cltest.NewHash()}
+	require.Eventually(t, func() bool { return len(listener.Events()) == 3 }, 5*time.Second, 10*time.Millisecond)
+
+	chPrimaryErr <- errors.New("primary node went away")
+	require.Eventually(t, func() bool { return secondary.LogSubscriptionCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// Logs keep being delivered, now by the secondary
+	secondary.PushBlock(eth.Log{Address: addr})
+	expected := []string{"OnConnect", "OnBackfillComplete", "HandleLog(1)",
+		"OnDisconnect", "OnConnect", "OnBackfillComplete", "HandleLog(2)"}
+	require.Eventually(t, func() bool { return len(listener.Events()) == len(expected) }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, expected, listener.Events())
+	primary.AssertExpectations(t)
+}
+
+func TestNewLogBroadcasterWithClients_NeedsAClient(t *testing.T) {
+	_, err := ethsvc.NewLogBroadcasterWithClients(nil, nil, 10, ethsvc.DefaultLogBroadcasterOptions)
+	assert.Error(t, err)
+}
+
+func TestLogBroadcaster_BackfillsInBoundedPages(t *testing.T) {
+	t.Parallel()
+
+	const numLogs = 10000
+	const pageSize = 100
+
+	ethClient := cltest.NewSimulatedEthClient()
+	addr := cltest.NewAddress()
+	// Vary the density of the logs, so that the page ranges have to adapt
+	for pushed, block := 0, 0; pushed < numLogs; block++ {
+		var logs []eth.Log
+		for j := 0; j < block%7 && pushed < numLogs; j++ {
+			logs = append(logs, eth.Log{Address: addr})
+			pushed++
+		}
+		ethClient.PushBlock(logs...)
+	}
+
+	var pagesMutex sync.Mutex
+	var pageSizes []int
+	opts := ethsvc.DefaultLogBroadcasterOptions
+	opts.BackfillPageSize = pageSize
+	opts.OnBackfillPage = func(page []eth.Log) {
+		pagesMutex.Lock()
+		defer pagesMutex.Unlock()
+		pageSizes = append(pageSizes, len(page))
+	}
+	lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, ethClient.Height(), opts)
+	lb.Start()
+	defer lb.Stop()
+
+	listener := new(lifecycleRecordingListener)
+	lb.Register(addr, listener)
+	require.Eventually(t, func() bool {
+		events := listener.Events()
+		return len(events) > 0 && events[len(events)-1] == "OnBackfillComplete"
+	}, 10*time.Second, 10*time.Millisecond)
+
+	var handled int
+	lastBlock := -1
+	for _, event := range listener.Events() {
+		var block int
+		if _, err := fmt.Sscanf(event, "HandleLog(%d)", &block); err == nil {
+			require.GreaterOrEqual(t, block, lastBlock, "logs must be delivered in block order")
+			lastBlock = block
+			handled++
+		}
+	}
+	assert.Equal(t, numLogs, handled)
+	assert.Equal(t, ethsvc.BackfillStatusComplete, lb.HealthReport().BackfillStatus)
+
+	pagesMutex.Lock()
+	defer pagesMutex.Unlock()
+	var total int
+	for _, size := range pageSizes {
+		require.LessOrEqual(t, size, pageSize)
+		total += size
+	}
+	assert.Equal(t, numLogs, total)
+	assert.Greater(t, len(pageSizes), numLogs/pageSize)
+}
+
+func TestLogBroadcaster_ReplayFromBlock_RedeliversOnlyToListener(t *testing.T) {
+	t.Parallel()
+
+	ethClient := cltest.NewSimulatedEthClient()
+	addr := cltest.NewAddress()
+	for i := 0; i < 3; i++ {
+		ethClient.PushBlock(eth.Log{Address: addr})
+	}
+
+	lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+	lb.Start()
+	defer lb.Stop()
+
+	replayed := new(lifecycleRecordingListener)
+	other := new(lifecycleRecordingListener)
+	lb.Register(addr, replayed)
+	lb.Register(addr, other)
+
+	backfillComplete := []string{"OnConnect", "HandleLog(1)", "HandleLog(2)", "HandleLog(3)", "OnBackfillComplete"}
+	for _, listener := range []*lifecycleRecordingListener{replayed, other} {
+		listener := listener
+		require.Eventually(t, func() bool { return len(listener.Events()) == len(backfillComplete) }, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, backfillComplete, listener.Events())
+	}
+
+	require.NoError(t, lb.ReplayFromBlock(replayed, 2))
+	assert.Equal(t, append(backfillComplete, "HandleLog(2)", "HandleLog(3)"), replayed.Events())
+	assert.Equal(t, backfillComplete, other.Events())
+
+	err := lb.ReplayFromBlock(new(lifecycleRecordingListener), 0)
+	assert.Equal(t, ethsvc.ErrListenerNotRegistered, err)
+}
+
+func TestLogBroadcaster_RegisterFromBlock_DeliversHistoricalLogsToListener(t *testing.T) {
+	t.Parallel()
+
+	simulated := cltest.NewSimulatedEthClient()
+	ethClient := cltest.NewRecordingClient(simulated)
+	addr, otherAddr := cltest.NewAddress(), cltest.NewAddress()
+	for i := 0; i < 5; i++ {
+		simulated.PushBlock(eth.Log{Address: addr}, eth.Log{Address: otherAddr})
+	}
+
+	lb := ethsvc.NewLogBroadcaster(ethClient, nil, 1)
+	require.NoError(t, lb.Start())
+	defer lb.Stop()
+
+	existing := new(lifecycleRecordingListener)
+	lb.Register(addr, existing)
+	backfillComplete := []string{"OnConnect", "HandleLog(4)", "HandleLog(5)", "OnBackfillComplete"}
+	require.Eventually(t, func() bool { return len(existing.Events()) == len(backfillComplete) }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, backfillComplete, existing.Events())
+
+	ethClient.Reset()
+	late := new(lifecycleRecordingListener)
+	lb.RegisterFromBlock(addr, late, 2)
+	historical := []string{"HandleLog(2)", "HandleLog(3)", "HandleLog(4)", "HandleLog(5)"}
+	require.Eventually(t, func() bool { return len(late.Events()) == len(historical) }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, historical, late.Events())
+	assert.Equal(t, backfillComplete, existing.Events())
+
+	calls := ethClient.Calls("GetLogs")
+	require.Len(t, calls, 1)
+	q := calls[0].Args[0].(ethereum.FilterQuery)
+	assert.Equal(t, []common.Address{addr}, q.Addresses)
+	assert.Equal(t, big.NewInt(2), q.FromBlock)
+}
+
+func TestLogBroadcaster_RecentDeliveries(t *testing.T) {
+	t.Parallel()
+
+	ethClient := cltest.NewSimulatedEthClient()
+	addr := cltest.NewAddress()
+	topic := common.HexToHash("0x0109fc6f55cf40689f02fbaad7af7fe7bbac8a3d2186600afc7d3e10cac60271")
+	for i := 0; i < 5; i++ {
+		ethClient.PushBlock(eth.Log{Address: addr, Topics: []common.Hash{topic}})
+	}
+
+	opts := ethsvc.DefaultLogBroadcasterOptions
+	opts.RecentDeliveriesSize = 3
+	lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+	assert.Empty(t, lb.RecentDeliveries())
+	require.NoError(t, lb.Start())
+	defer lb.Stop()
+
+	listener := new(lifecycleRecordingListener)
+	lb.Register(addr, listener)
+	require.Eventually(t, func() bool {
+		events := listener.Events()
+		return len(events) > 0 && events[len(events)-1] == "OnBackfillComplete"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	deliveries := lb.RecentDeliveries()
+	require.Len(t, deliveries, 3)
+	for i, delivery := range deliveries {
+		assert.Equal(t, uint64(5-i), delivery.BlockNumber)
+		assert.Equal(t, addr, delivery.Address)
+		assert.Equal(t, topic, delivery.Topic)
+		assert.Equal(t, listener.Consumer(), delivery.Consumer)
+	}
+
+	disabled := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+	assert.Nil(t, disabled.RecentDeliveries())
+}
+
+func TestLogBroadcaster_RemovedLogs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		notify bool
+		want   []string
+	}{
+		{"dropped by default", false,
+			[]string{"OnConnect", "OnBackfillComplete", "HandleLog(1)"}},
+		{"passed to OnLogRemoved when enabled", true,
+			[]string{"OnConnect", "OnBackfillComplete", "HandleLog(1)", "OnLogRemoved(1)"}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ethClient := cltest.NewSimulatedEthClient()
+			opts := ethsvc.DefaultLogBroadcasterOptions
+			opts.NotifyRemovedLogs = test.notify
+			lb := ethsvc.NewLogBroadcasterWithOptions(ethClient, nil, 10, opts)
+			require.NoError(t, lb.Start())
+			defer lb.Stop()
+
+			addr := cltest.NewAddress()
+			listener := new(lifecycleRecordingListener)
+			lb.Register(addr, listener)
+			require.Eventually(t, func() bool {
+				events := listener.Events()
+				return len(events) > 0 && events[len(events)-1] == "OnBackfillComplete"
+			}, 5*time.Second, 10*time.Millisecond)
+
+			ethClient.PushBlock(eth.Log{Address: addr})
+			require.Eventually(t, func() bool { return len(listener.Events()) == 3 }, 5*time.Second, 10*time.Millisecond)
+			ethClient.Reorg(1)
+
+			require.Eventually(t, func() bool { return len(listener.Events()) == len(test.want) }, 5*time.Second, 10*time.Millisecond)
+			require.Never(t, func() bool { return len(listener.Events()) > len(test.want) }, 500*time.Millisecond, 10*time.Millisecond)
+			assert.Equal(t, test.want, listener.Events())
+		})
+	}
+}
+
+func TestLogBroadcaster_RegisterWithFilter(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	ethClient := cltest.NewSimulatedEthClient()
+	addr := cltest.NewAddress()
+	wanted, unwanted := cltest.NewHash(), cltest.NewHash()
+	ethClient.PushBlock(eth.Log{Address: addr, Topics: []common.Hash{wanted}}, eth.Log{Address: addr, Topics: []common.Hash{unwanted}})
+
+	lb := ethsvc.NewLogBroadcaster(ethClient, store.ORM, 10)
+	lb.Start()
+	defer lb.Stop()
+
+	var mutex sync.Mutex
+	var filteredTopics []common.Hash
+	filteredJob := createJob(t, store)
+	filtered := simpleLogListner{func(lb ethsvc.LogBroadcast, err error) {
+		require.NoError(t, err)
+		require.NoError(t, lb.MarkConsumed())
+		mutex.Lock()
+		defer mutex.Unlock()
+		filteredTopics = append(filteredTopics, lb.Log().(*eth.Log).Topics[0])
+	}, *filteredJob.ID}
+	var unfilteredCount int
+	unfilteredJob := createJob(t, store)
+	unfiltered := simpleLogListner{func(lb ethsvc.LogBroadcast, err error) {
+		require.NoError(t, err)
+		require.NoError(t, lb.MarkConsumed())
+		mutex.Lock()
+		defer mutex.Unlock()
+		unfilteredCount++
+	}, *unfilteredJob.ID}
+
+	lb.RegisterWithFilter(addr, &filtered, func(log eth.Log) bool {
+		return len(log.Topics) > 0 && log.Topics[0] == wanted
+	})
+	lb.RegisterWithFilter(addr, &unfiltered, nil)
+
+	// Backfilled and live logs are filtered alike
+	ethClient.PushBlock(eth.Log{Address: addr, Topics: []common.Hash{unwanted}}, eth.Log{Address: addr, Topics: []common.Hash{wanted}})
+
+	handled := func() ([]common.Hash, int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]common.Hash{}, filteredTopics...), unfilteredCount
+	}
+	require.Eventually(t, func() bool {
+		topics, count := handled()
+		return len(topics) == 2 && count == 4
+	}, 5*time.Second, 10*time.Millisecond)
+	requireLogConsumptionCount(t, store, 6)
+	topics, _ := handled()
+	assert.Equal(t, []common.Hash{wanted, wanted}, topics)
+}
+
+func TestLogBroadcaster_ReplayFromBlock_LeavesConsumptionsUntouched(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	ethClient := cltest.NewSimulatedEthClient()
+	addr := cltest.NewAddress()
+	for i := 0; i < 3; i++ {
+		ethClient.PushBlock(eth.Log{Address: addr})
+	}
+
+	lb := ethsvc.NewLogBroadcaster(ethClient, store.ORM, 10)
+	lb.Start()
+	defer lb.Stop()
+
+	job := createJob(t, store)
+	var mutex sync.Mutex
+	var alreadyConsumed []bool
+	listener := simpleLogListner{func(lb ethsvc.LogBroadcast, err error) {
+		require.NoError(t, err)
+		consumed, err := lb.WasAlreadyConsumed()
+		require.NoError(t, err)
+		require.NoError(t, lb.MarkConsumed())
+		mutex.Lock()
+		defer mutex.Unlock()
+		alreadyConsumed = append(alreadyConsumed, consumed)
+	}, *job.ID}
+	lb.Register(addr, &listener)
+
+	handled := func() []bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]bool{}, alreadyConsumed...)
+	}
+	require.Eventually(t, func() bool { return len(handled()) == 3 }, 5*time.Second, 10*time.Millisecond)
+	requireLogConsumptionCount(t, store, 3)
+
+	findConsumptions := func() (consumptions []models.LogConsumption) {
+		require.NoError(t, store.ORM.RawDB(func(db *gorm.DB) error {
+			return db.Order("id asc").Find(&consumptions).Error
+		}))
+		return consumptions
+	}
+	before := findConsumptions()
+
+	// The consumed logs are redelivered as though they hadn't been consumed
+	require.NoError(t, lb.ReplayFromBlock(&listener, 1))
+	assert.Equal(t, []bool{false, false, false, false, false, false}, handled())
+
+	assert.Equal(t, before, findConsumptions())
+}
+
+func TestLogBroadcaster_UnconsumedLogsAreRedelivered(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	ethClient := cltest.NewSimulatedEthClient()
+	addr := cltest.NewAddress()
+	ethClient.PushBlock(eth.Log{Address: addr})
+
+	lb := ethsvc.NewLogBroadcaster(ethClient, store.ORM, 10)
+	lb.Start()
+	defer lb.Stop()
+
+	// The listener only consumes the log once it has seen it before, as though
+	// it failed a business rule the first time
+	job := createJob(t, store)
+	var mutex sync.Mutex
+	var deliveries int
+	listener := simpleLogListner{func(lb ethsvc.LogBroadcast, err error) {
+		require.NoError(t, err)
+		consumed, err := lb.WasAlreadyConsumed()
+		require.NoError(t, err)
+		require.False(t, consumed)
+		mutex.Lock()
+		defer mutex.Unlock()
+		deliveries++
+		if deliveries > 1 {
+			require.NoError(t, lb.MarkConsumed())
+		}
+	}, *job.ID}
+	lb.Register(addr, &listener)
+
+	delivered := func() int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return deliveries
+	}
+	require.Eventually(t, func() bool { return delivered() == 1 }, 5*time.Second, 10*time.Millisecond)
+	requireLogConsumptionCount(t, store, 0)
+
+	require.NoError(t, lb.ReplayFromBlock(&listener, 1))
+	assert.Equal(t, 2, delivered())
+	requireLogConsumptionCount(t, store, 1)
+}
+
+func TestLogBroadcaster_RegisterAndWaitForConnect(t *testing.T) {
+	t.Parallel()
+
+	addr := cltest.NewAddress()
+
+	t.Run("returns once connected", func(t *testing.T) {
+		ethClient := cltest.NewSimulatedEthClient()
+		ethClient.PushBlock(eth.Log{Address: addr})
+		lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+		require.NoError(t, lb.Start())
+		defer lb.Stop()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		listener := new(lifecycleRecordingListener)
+		require.NoError(t, lb.RegisterAndWaitForConnect(ctx, addr, listener))
+		require.NotEmpty(t, listener.Events())
+		assert.Equal(t, "OnConnect", listener.Events()[0])
+
+		// The subscription to addr is already live, so a second listener is
+		// connected as soon as it's added
+		second := new(lifecycleRecordingListener)
+		require.NoError(t, lb.RegisterAndWaitForConnect(ctx, addr, second))
+		assert.NotContains(t, second.Events(), "OnConnect")
+	})
+
+	t.Run("times out if never connected", func(t *testing.T) {
+		ethClient := new(mocks.Client)
+		ethClient.On("SubscribeToLogs", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("node unreachable"))
+		lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+		require.NoError(t, lb.Start())
+		defer lb.Stop()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		listener := new(lifecycleRecordingListener)
+		err := lb.RegisterAndWaitForConnect(ctx, addr, listener)
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Empty(t, listener.Events())
+	})
+}
+
+// unregisterCheckingListener counts the logs it's delivered, and those it's
+// delivered after being marked as unregistered
+type unregisterCheckingListener struct {
+	delivered    int32
+	unregistered int32
+	late         *int32
+}
+
+func (l *unregisterCheckingListener) HandleLog(lb ethsvc.LogBroadcast, err error) {
+	atomic.AddInt32(&l.delivered, 1)
+	if atomic.LoadInt32(&l.unregistered) == 1 {
+		atomic.AddInt32(l.late, 1)
+	}
+}
+func (l *unregisterCheckingListener) OnConnect()                   {}
+func (l *unregisterCheckingListener) OnDisconnect()                {}
+func (l *unregisterCheckingListener) Consumer() models.LogConsumer { return models.LogConsumer{} }
+
+func TestLogBroadcaster_ConcurrentRegisterAndUnregisterWhileBroadcasting(t *testing.T) {
+	t.Parallel()
+
+	const workers = 8
+	const duration = 1 * time.Second
+
+	ethClient := cltest.NewSimulatedEthClient()
+	lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+	require.NoError(t, lb.Start())
+	defer lb.Stop()
+
+	// A permanent listener keeps the subscription to addr alive throughout, so
+	// that the others are added to and removed from a live subscription
+	addr := cltest.NewAddress()
+	var late int32
+	permanent := &unregisterCheckingListener{late: &late}
+	require.NoError(t, lb.RegisterAndWaitForConnect(context.Background(), addr, permanent))
+
+	deadline := time.Now().Add(duration)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for time.Now().Before(deadline) {
+			ethClient.PushBlock(eth.Log{Address: addr}, eth.Log{Address: addr})
+		}
+	}()
+	var registrations int32
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				listener := &unregisterCheckingListener{late: &late}
+				lb.Register(addr, listener)
+				time.Sleep(time.Millisecond)
+				lb.Unregister(addr, listener)
+				atomic.StoreInt32(&listener.unregistered, 1)
+				atomic.AddInt32(&registrations, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Any late deliveries would have happened by the time this one arrives
+	delivered := atomic.LoadInt32(&permanent.delivered)
+	ethClient.PushBlock(eth.Log{Address: addr})
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&permanent.delivered) > delivered }, 5*time.Second, 10*time.Millisecond)
+
+	assert.NotZero(t, atomic.LoadInt32(&registrations))
+	assert.NotZero(t, delivered)
+	assert.Zero(t, atomic.LoadInt32(&late), "logs were delivered to unregistered listeners")
+}
+
+func TestLogBroadcaster_StartAndStopAreIdempotent(t *testing.T) {
+	t.Parallel()
+
+	addr := cltest.NewAddress()
+
+	t.Run("stopping twice", func(t *testing.T) {
+		ethClient := cltest.NewSimulatedEthClient()
+		lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+		require.NoError(t, lb.Start())
+		lb.Register(addr, new(lifecycleRecordingListener))
+		require.Eventually(t, func() bool { return ethClient.LogSubscriptionCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+		lb.Stop()
+		lb.Stop()
+		assert.Equal(t, 0, ethClient.LogSubscriptionCount())
+	})
+
+	t.Run("starting twice", func(t *testing.T) {
+		ethClient := cltest.NewSimulatedEthClient()
+		lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+		require.NoError(t, lb.Start())
+		require.NoError(t, lb.Start())
+		defer lb.Stop()
+
+		listener := new(lifecycleRecordingListener)
+		lb.Register(addr, listener)
+		require.Eventually(t, func() bool { return ethClient.LogSubscriptionCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+		ethClient.PushBlock(eth.Log{Address: addr})
+		require.Eventually(t, func() bool {
+			events := listener.Events()
+			return len(events) > 0 && events[len(events)-1] == "HandleLog(1)"
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, 1, ethClient.LogSubscriptionCount())
+	})
+
+	t.Run("starting after stopping", func(t *testing.T) {
+		ethClient := cltest.NewSimulatedEthClient()
+		lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+		require.NoError(t, lb.Start())
+		lb.Register(addr, new(lifecycleRecordingListener))
+		require.Eventually(t, func() bool { return ethClient.LogSubscriptionCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+		lb.Stop()
+
+		assert.Equal(t, ethsvc.ErrLogBroadcasterStopped, lb.Start())
+		lb.Register(addr, new(lifecycleRecordingListener))
+		assert.Equal(t, 0, ethClient.LogSubscriptionCount())
+		assert.Equal(t, ethsvc.ErrLogBroadcasterStopped, lb.ReplayFromBlock(new(lifecycleRecordingListener), 0))
+		lb.Stop()
+	})
+
+	t.Run("stopping without starting", func(t *testing.T) {
+		lb := ethsvc.NewLogBroadcaster(cltest.NewSimulatedEthClient(), nil, 10)
+		lb.Stop()
+		assert.Equal(t, ethsvc.ErrLogBroadcasterStopped, lb.Start())
+		lb.Stop()
+	})
+}
+
+func TestChannelLogListener_ReceivesLogsInOrder(t *testing.T) {
+	t.Parallel()
+
+	const numLogs = 5
+
+	ethClient := cltest.NewSimulatedEthClient()
+	addr := cltest.NewAddress()
+	for i := 0; i < numLogs; i++ {
+		ethClient.PushBlock(eth.Log{Address: addr})
+	}
+
+	lb := ethsvc.NewLogBroadcaster(ethClient, nil, 10)
+	require.NoError(t, lb.Start())
+	defer lb.Stop()
+
+	consumer := models.LogConsumer{Type: models.LogConsumerTypeService, ID: models.NewID()}
+	listener, chLogs := ethsvc.NewChannelLogListener(consumer, 2*numLogs)
+	assert.Equal(t, consumer, listener.Consumer())
+	lb.Register(addr, listener)
+
+	// Backfilled logs, then live ones
+	for i := 1; i <= 2*numLogs; i++ {
+		if i == numLogs+1 {
+			for j := 0; j < numLogs; j++ {
+				ethClient.PushBlock(eth.Log{Address: addr})
+			}
+		}
+		select {
+		case broadcast := <-chLogs:
+			assert.Equal(t, uint64(i), broadcast.Log().(*eth.Log).BlockNumber)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for log %d", i)
+		}
+	}
+	assert.Equal(t, uint64(0), listener.(*ethsvc.ChannelLogListener).Dropped())
+}
+
+func TestChannelLogListener_DropsLogsWhenFull(t *testing.T) {
+	t.Parallel()
+
+	listener, chLogs := ethsvc.NewChannelLogListener(models.LogConsumer{}, 1)
+	first, second := new(mocks.LogBroadcast), new(mocks.LogBroadcast)
+	listener.HandleLog(first, nil)
+	listener.HandleLog(second, nil)
+	listener.HandleLog(nil, errors.New("oh no!"))
+
+	assert.Equal(t, ethsvc.LogBroadcast(first), <-chLogs)
+	select {
+	case broadcast := <-chLogs:
+		t.Fatalf("unexpected broadcast %v", broadcast)
+	default:
+	}
+	assert.Equal(t, uint64(1), listener.(*ethsvc.ChannelLogListener).Dropped())
+}
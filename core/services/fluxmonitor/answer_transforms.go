@@ -0,0 +1,126 @@
+package fluxmonitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// AnswerTransform transforms a polled answer before it's compared with the
+// aggregator's latest answer and submitted, e.g. to invert a price or to
+// adjust its decimals
+type AnswerTransform func(answer decimal.Decimal) (decimal.Decimal, error)
+
+// The built-in answer transforms, as named in a job's answerTransforms
+const (
+	AnswerTransformMultiplyPow10 = "multiplyPow10"
+	AnswerTransformReciprocal    = "reciprocal"
+)
+
+var (
+	// ErrReciprocalOfZero is returned by the Reciprocal transform for a zero
+	// answer
+	ErrReciprocalOfZero = errors.New("reciprocal of zero answer")
+	// ErrAnswerOverflow is returned when a transformed answer, scaled by the
+	// job's precision, doesn't fit in the int256 it's submitted as
+	ErrAnswerOverflow = errors.New("transformed answer overflows int256")
+)
+
+// MultiplyByPowerOfTen returns an AnswerTransform multiplying answers by
+// 10^exponent.  A negative exponent divides them.
+func MultiplyByPowerOfTen(exponent int32) AnswerTransform {
+	return func(answer decimal.Decimal) (decimal.Decimal, error) {
+		return answer.Shift(exponent), nil
+	}
+}
+
+// Reciprocal returns an AnswerTransform replacing answers with their
+// reciprocals, rounded to precision decimal places, e.g. to turn an ETH/USD
+// price into a USD/ETH one.  It errors for a zero answer, and for answers so
+// close to zero that their reciprocals, scaled by precision, overflow int256.
+func Reciprocal(precision int32) AnswerTransform {
+	return func(answer decimal.Decimal) (decimal.Decimal, error) {
+		if answer.IsZero() {
+			return decimal.Decimal{}, ErrReciprocalOfZero
+		}
+		reciprocal := decimal.New(1, 0).DivRound(answer, precision)
+		if err := checkSubmittable(reciprocal, precision); err != nil {
+			return decimal.Decimal{}, errors.Wrapf(err, "reciprocal of %s", answer)
+		}
+		return reciprocal, nil
+	}
+}
+
+type answerTransformSpec struct {
+	Op       string `json:"op"`
+	Exponent *int32 `json:"exponent"`
+}
+
+// ParseAnswerTransforms returns the transforms described by a job's
+// answerTransforms, an array of objects each naming a built-in op, e.g.
+//
+//	[{"op": "multiplyPow10", "exponent": -2}, {"op": "reciprocal"}]
+//
+// precision is the job's precision, which reciprocals are rounded to.
+func ParseAnswerTransforms(spec models.AnswerTransforms, precision int32) ([]AnswerTransform, error) {
+	if !spec.Exists() {
+		return nil, nil
+	}
+	var specs []answerTransformSpec
+	if err := json.Unmarshal(spec.Bytes(), &specs); err != nil {
+		return nil, errors.Wrap(err, "answerTransforms must be an array of transforms")
+	}
+	transforms := make([]AnswerTransform, len(specs))
+	for i, s := range specs {
+		switch s.Op {
+		case AnswerTransformMultiplyPow10:
+			if s.Exponent == nil {
+				return nil, fmt.Errorf("answerTransforms[%d]: %s needs an exponent", i, s.Op)
+			}
+			transforms[i] = MultiplyByPowerOfTen(*s.Exponent)
+		case AnswerTransformReciprocal:
+			transforms[i] = Reciprocal(precision)
+		default:
+			return nil, fmt.Errorf("answerTransforms[%d]: unknown op %q", i, s.Op)
+		}
+	}
+	return transforms, nil
+}
+
+// applyAnswerTransforms applies transforms to answer in order, and checks
+// that the result can be submitted with the given precision
+func applyAnswerTransforms(answer decimal.Decimal, transforms []AnswerTransform, precision int32) (decimal.Decimal, error) {
+	if len(transforms) == 0 {
+		return answer, nil
+	}
+	for _, transform := range transforms {
+		var err error
+		if answer, err = transform(answer); err != nil {
+			return decimal.Decimal{}, err
+		}
+	}
+	if err := checkSubmittable(answer, precision); err != nil {
+		return decimal.Decimal{}, err
+	}
+	return answer, nil
+}
+
+var (
+	maxInt256 = decimal.NewFromBigInt(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1)), 0)
+	minInt256 = decimal.NewFromBigInt(new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255)), 0)
+)
+
+// checkSubmittable errors unless answer, scaled by precision, fits in the
+// int256 it's submitted as
+func checkSubmittable(answer decimal.Decimal, precision int32) error {
+	scaled := answer.Shift(precision).Truncate(0)
+	if scaled.GreaterThan(maxInt256) || scaled.LessThan(minInt256) {
+		return ErrAnswerOverflow
+	}
+	return nil
+}
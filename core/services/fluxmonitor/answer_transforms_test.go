@@ -0,0 +1,113 @@
+package fluxmonitor
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+func requireDecimalEqual(t *testing.T, expected string, actual decimal.Decimal) {
+	t.Helper()
+	require.True(t, decimal.RequireFromString(expected).Equal(actual), "expected %s, got %s", expected, actual)
+}
+
+func TestMultiplyByPowerOfTen(t *testing.T) {
+	tests := []struct {
+		name     string
+		exponent int32
+		answer   string
+		want     string
+	}{
+		{"positive exponent", 2, "1.2345", "123.45"},
+		{"negative exponent", -3, "1234.5", "1.2345"},
+		{"zero exponent", 0, "-7.5", "-7.5"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			transformed, err := MultiplyByPowerOfTen(test.exponent)(decimal.RequireFromString(test.answer))
+			require.NoError(t, err)
+			requireDecimalEqual(t, test.want, transformed)
+		})
+	}
+}
+
+func TestReciprocal(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision int32
+		answer    string
+		want      string
+	}{
+		{"exact", 2, "4", "0.25"},
+		{"rounded to precision", 4, "3", "0.3333"},
+		{"negative", 2, "-0.5", "-2"},
+		{"price pair", 8, "250", "0.004"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			transformed, err := Reciprocal(test.precision)(decimal.RequireFromString(test.answer))
+			require.NoError(t, err)
+			requireDecimalEqual(t, test.want, transformed)
+		})
+	}
+}
+
+func TestReciprocal_Errors(t *testing.T) {
+	_, err := Reciprocal(8)(decimal.Zero)
+	assert.Equal(t, ErrReciprocalOfZero, err)
+
+	// 10^80 * 10^8 doesn't fit in an int256
+	_, err = Reciprocal(8)(decimal.New(1, -80))
+	require.Error(t, err)
+	assert.Equal(t, ErrAnswerOverflow, errors.Cause(err))
+}
+
+func TestParseAnswerTransforms(t *testing.T) {
+	parse := func(spec string) ([]AnswerTransform, error) {
+		t.Helper()
+		j, err := models.ParseJSON([]byte(spec))
+		require.NoError(t, err)
+		return ParseAnswerTransforms(j, 4)
+	}
+
+	transforms, err := ParseAnswerTransforms(models.AnswerTransforms{}, 4)
+	require.NoError(t, err)
+	assert.Empty(t, transforms)
+
+	// Applied in order: 0.08 * 100 = 8, then 1/8
+	transforms, err = parse(`[{"op": "multiplyPow10", "exponent": 2}, {"op": "reciprocal"}]`)
+	require.NoError(t, err)
+	require.Len(t, transforms, 2)
+	transformed, err := applyAnswerTransforms(decimal.RequireFromString("0.08"), transforms, 4)
+	require.NoError(t, err)
+	requireDecimalEqual(t, "0.125", transformed)
+
+	for _, spec := range []string{
+		`[{"op": "square"}]`,
+		`[{"op": "multiplyPow10"}]`,
+		`{"op": "reciprocal"}`,
+	} {
+		_, err := parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestApplyAnswerTransforms_RejectsOverflowingAnswers(t *testing.T) {
+	transforms := []AnswerTransform{MultiplyByPowerOfTen(80)}
+	_, err := applyAnswerTransforms(decimal.NewFromInt(1), transforms, 0)
+	assert.Equal(t, ErrAnswerOverflow, err)
+
+	// Untransformed answers are submitted as before
+	answer, err := applyAnswerTransforms(decimal.New(1, 80), nil, 0)
+	require.NoError(t, err)
+	requireDecimalEqual(t, "1e80", answer)
+}
@@ -0,0 +1,65 @@
+package fluxmonitor
+
+import (
+	"time"
+)
+
+// circuitBreaker halts a job whose calls to the aggregator keep failing, e.g.
+// because the contract has self-destructed or the RPC endpoint is
+// misconfigured, so that the node stops hammering it.  It opens after
+// threshold consecutive failures, and allows a single trial call once cooldown
+// has passed: if the trial succeeds the breaker closes again, otherwise it
+// reopens for another cooldown.  A zero threshold never opens it.
+//
+// It is only accessed by the CSP consumer, so isn't safe for concurrent use.
+type circuitBreaker struct {
+	threshold uint32
+	cooldown  time.Duration
+
+	failures uint32
+	open     bool
+	retryAt  time.Time
+}
+
+func newCircuitBreaker(threshold uint32, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow reports whether a call may be made: always while the breaker is
+// closed, and once its cooldown has passed while it's open
+func (b *circuitBreaker) allow() bool {
+	return !b.open || !time.Now().Before(b.retryAt)
+}
+
+// isOpen reports whether the breaker has halted the job
+func (b *circuitBreaker) isOpen() bool {
+	return b.open
+}
+
+// recordSuccess closes the breaker, and reports whether it had been open
+func (b *circuitBreaker) recordSuccess() (closed bool) {
+	closed = b.open
+	b.failures = 0
+	b.open = false
+	return closed
+}
+
+// recordFailure counts a failed call, and reports whether it opened the
+// breaker.  A failed trial reopens it for another cooldown, but isn't
+// reported, as the job was already halted.
+func (b *circuitBreaker) recordFailure() (opened bool) {
+	if b.threshold == 0 {
+		return false
+	}
+	b.failures++
+	if b.open {
+		b.retryAt = time.Now().Add(b.cooldown)
+		return false
+	}
+	if b.failures < b.threshold {
+		return false
+	}
+	b.open = true
+	b.retryAt = time.Now().Add(b.cooldown)
+	return true
+}
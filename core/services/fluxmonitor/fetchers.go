@@ -7,6 +7,8 @@ import (
 	"net/url"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -191,3 +193,41 @@ func (m *medianFetcher) String() string {
 	}
 	return fmt.Sprintf("median fetcher: %s", strings.Join(fetcherDescriptions, ","))
 }
+
+// sharedFetcher wraps a Fetcher so that every caller within the given window
+// reuses a single fetched value, rather than querying the data sources once
+// per caller.  Concurrent callers wait on the in-flight fetch.  Errors are not
+// cached.
+type sharedFetcher struct {
+	fetcher Fetcher
+	window  time.Duration
+
+	mu        sync.Mutex
+	value     decimal.Decimal
+	fetchedAt time.Time
+}
+
+func newSharedFetcher(fetcher Fetcher, window time.Duration) *sharedFetcher {
+	return &sharedFetcher{fetcher: fetcher, window: window}
+}
+
+func (s *sharedFetcher) Fetch() (decimal.Decimal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.fetchedAt.IsZero() && time.Since(s.fetchedAt) < s.window {
+		return s.value, nil
+	}
+
+	value, err := s.fetcher.Fetch()
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	s.value = value
+	s.fetchedAt = time.Now()
+	return value, nil
+}
+
+func (s *sharedFetcher) String() string {
+	return fmt.Sprintf("shared fetcher: %s", s.fetcher)
+}
@@ -279,3 +279,43 @@ func TestMedianFetcher_MinorityErrors(t *testing.T) {
 		})
 	}
 }
+
+type countingFetcher struct {
+	Fetcher
+	count int
+}
+
+func (c *countingFetcher) Fetch() (decimal.Decimal, error) {
+	c.count++
+	return c.Fetcher.Fetch()
+}
+
+func TestSharedFetcher_ReusesValueWithinWindow(t *testing.T) {
+	inner := &countingFetcher{Fetcher: newFixedPricedFetcher(decimal.NewFromInt(100))}
+	shared := newSharedFetcher(inner, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		price, err := shared.Fetch()
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(100).Equal(price))
+	}
+	assert.Equal(t, 1, inner.count)
+
+	expired := newSharedFetcher(inner, 0)
+	_, err := expired.Fetch()
+	require.NoError(t, err)
+	_, err = expired.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, 3, inner.count)
+}
+
+func TestSharedFetcher_DoesNotCacheErrors(t *testing.T) {
+	inner := &countingFetcher{Fetcher: newErroringPricedFetcher()}
+	shared := newSharedFetcher(inner, time.Hour)
+
+	_, err := shared.Fetch()
+	assert.Error(t, err)
+	_, err = shared.Fetch()
+	assert.Error(t, err)
+	assert.Equal(t, 2, inner.count)
+}
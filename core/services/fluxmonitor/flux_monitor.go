@@ -1,6 +1,7 @@
 package fluxmonitor
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -16,6 +17,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
@@ -36,15 +38,151 @@ type RunManager interface {
 	) (*models.JobRun, error)
 }
 
+// limitedRunManager is a RunManager which allows at most a fixed number of
+// calls to Create to be in flight at once, queueing the rest.  It is shared by
+// all of a flux monitor's checkers, so that a market-wide price move doesn't
+// trigger a burst of submissions from every job at the same time.  Only run
+// creation is limited; the checkers still poll and evaluate concurrently.
+type limitedRunManager struct {
+	runManager RunManager
+	semaphore  chan struct{}
+}
+
+// newLimitedRunManager returns runManager, wrapped to allow at most
+// maxConcurrent concurrent calls to Create.  If maxConcurrent is zero,
+// runManager is returned as is.
+func newLimitedRunManager(runManager RunManager, maxConcurrent uint32) RunManager {
+	if maxConcurrent == 0 {
+		return runManager
+	}
+	return &limitedRunManager{
+		runManager: runManager,
+		semaphore:  make(chan struct{}, maxConcurrent),
+	}
+}
+
+func (rm *limitedRunManager) Create(
+	jobSpecID *models.ID,
+	initiator *models.Initiator,
+	creationHeight *big.Int,
+	runRequest *models.RunRequest,
+) (*models.JobRun, error) {
+	rm.semaphore <- struct{}{}
+	defer func() { <-rm.semaphore }()
+	return rm.runManager.Create(jobSpecID, initiator, creationHeight, runRequest)
+}
+
+// ErrFluxMonitorStopping is returned by the RunManager the flux monitor gives its
+// checkers, once Stop has been called, so that no new submissions are started
+// during shutdown.
+var ErrFluxMonitorStopping = errors.New("flux monitor is stopping")
+
+// inFlightSubmissions tracks the job runs being created by a flux monitor's
+// checkers, so that Stop can wait for them to be recorded rather than
+// abandoning them mid-creation.
+type inFlightSubmissions struct {
+	mutex    sync.Mutex
+	stopping bool
+	wg       sync.WaitGroup
+}
+
+// begin records the start of a submission, or returns ErrFluxMonitorStopping
+func (s *inFlightSubmissions) begin() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.stopping {
+		return ErrFluxMonitorStopping
+	}
+	s.wg.Add(1)
+	return nil
+}
+
+func (s *inFlightSubmissions) end() {
+	s.wg.Done()
+}
+
+// await refuses any new submissions, and waits for those in flight to finish.
+// It returns false if ctx is done first.
+func (s *inFlightSubmissions) await(ctx context.Context) (drained bool) {
+	s.mutex.Lock()
+	s.stopping = true
+	s.mutex.Unlock()
+
+	chDrained := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(chDrained)
+	}()
+	select {
+	case <-chDrained:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// trackedRunManager is a RunManager which records each call to Create in
+// submissions
+type trackedRunManager struct {
+	runManager  RunManager
+	submissions *inFlightSubmissions
+}
+
+func (rm trackedRunManager) Create(
+	jobSpecID *models.ID,
+	initiator *models.Initiator,
+	creationHeight *big.Int,
+	runRequest *models.RunRequest,
+) (*models.JobRun, error) {
+	if err := rm.submissions.begin(); err != nil {
+		return nil, err
+	}
+	defer rm.submissions.end()
+	return rm.runManager.Create(jobSpecID, initiator, creationHeight, runRequest)
+}
+
 // Service is the interface encapsulating all functionality
 // needed to listen to price deviations and new round requests.
 type Service interface {
 	AddJob(models.JobSpec) error
 	RemoveJob(*models.ID)
+	JobMetrics(models.ID) (FluxMonitorJobMetrics, bool)
 	Start() error
 	Stop()
 }
 
+// FluxMonitorJobMetrics describes the recent activity of a job's deviation
+// checkers, for operator introspection.  Times are zero if the corresponding
+// event hasn't happened, or isn't scheduled.
+type FluxMonitorJobMetrics struct {
+	// LastSubmittedAt is the time the last job run to submit an answer was created
+	LastSubmittedAt time.Time `json:"lastSubmittedAt"`
+	// LastSubmittedAnswer is the answer submitted at LastSubmittedAt
+	LastSubmittedAnswer decimal.Decimal `json:"lastSubmittedAnswer"`
+	// LatestAnswer is the last answer observed on chain
+	LatestAnswer decimal.Decimal `json:"latestAnswer"`
+	// NextPollAt is the time the next poll is scheduled for
+	NextPollAt time.Time `json:"nextPollAt"`
+}
+
+// mergeJobMetrics combines the metrics of the checkers belonging to a single
+// job, keeping the most recent submission and the earliest scheduled poll.
+func mergeJobMetrics(checkers []DeviationChecker) (merged FluxMonitorJobMetrics) {
+	for i, checker := range checkers {
+		metrics := checker.Metrics()
+		if i == 0 || metrics.LastSubmittedAt.After(merged.LastSubmittedAt) {
+			merged.LastSubmittedAt = metrics.LastSubmittedAt
+			merged.LastSubmittedAnswer = metrics.LastSubmittedAnswer
+			merged.LatestAnswer = metrics.LatestAnswer
+		}
+		if merged.NextPollAt.IsZero() ||
+			(!metrics.NextPollAt.IsZero() && metrics.NextPollAt.Before(merged.NextPollAt)) {
+			merged.NextPollAt = metrics.NextPollAt
+		}
+	}
+	return merged
+}
+
 type concreteFluxMonitor struct {
 	store          *store.Store
 	runManager     RunManager
@@ -52,11 +190,13 @@ type concreteFluxMonitor struct {
 	checkerFactory DeviationCheckerFactory
 	chAdd          chan addEntry
 	chRemove       chan models.ID
+	chJobMetrics   chan jobMetricsRequest
 	chConnect      chan *models.Head
 	chDisconnect   chan struct{}
 	chStop         chan struct{}
 	chDone         chan struct{}
 	disabled       bool
+	submissions    *inFlightSubmissions
 }
 
 type addEntry struct {
@@ -64,31 +204,67 @@ type addEntry struct {
 	checkers []DeviationChecker
 }
 
+type jobMetricsRequest struct {
+	jobID    models.ID
+	chResult chan jobMetricsResult
+}
+
+type jobMetricsResult struct {
+	metrics FluxMonitorJobMetrics
+	ok      bool
+}
+
+// logBroadcasterStalenessTimeout is how long the flux monitor's log
+// subscription may be silent, while the head stands still, before it's
+// presumed dead and recreated
+const logBroadcasterStalenessTimeout = 5 * time.Minute
+
 // New creates a service that manages a collection of DeviationCheckers,
 // one per initiator of type InitiatorFluxMonitor for added jobs.
 func New(
 	store *store.Store,
 	runManager RunManager,
+) Service {
+	return NewWithInstrumenter(store, runManager, NoopInstrumenter)
+}
+
+// NewWithInstrumenter is New, but reports the submission activity of every
+// job to instrumenter.
+func NewWithInstrumenter(
+	store *store.Store,
+	runManager RunManager,
+	instrumenter Instrumenter,
 ) Service {
 	if store.Config.EthereumDisabled() {
 		return &concreteFluxMonitor{disabled: true}
 	}
 
-	logBroadcaster := eth.NewLogBroadcaster(store.TxManager, store.ORM, 10)
+	logBroadcasterOptions := eth.DefaultLogBroadcasterOptions
+	logBroadcasterOptions.ChainID = store.Config.ChainID()
+	logBroadcasterOptions.DeleteReorgedConsumptions = true
+	// There's no DependentsTimeout, as a node loading many jobs mustn't
+	// subscribe before all of their checkers are ready
+	logBroadcasterOptions.StalenessTimeout = logBroadcasterStalenessTimeout
+	logBroadcaster := eth.NewLogBroadcasterWithOptions(store.TxManager, store.ORM, 10, logBroadcasterOptions)
+	submissions := &inFlightSubmissions{}
 	return &concreteFluxMonitor{
 		store:          store,
-		runManager:     runManager,
+		runManager:     newLimitedRunManager(runManager, store.Config.FluxMonitorMaxSubmissions()),
 		logBroadcaster: logBroadcaster,
 		checkerFactory: pollingDeviationCheckerFactory{
 			store:          store,
 			logBroadcaster: logBroadcaster,
+			submissions:    submissions,
+			instrumenter:   instrumenter,
 		},
 		chAdd:        make(chan addEntry),
 		chRemove:     make(chan models.ID),
+		chJobMetrics: make(chan jobMetricsRequest),
 		chConnect:    make(chan *models.Head),
 		chDisconnect: make(chan struct{}),
 		chStop:       make(chan struct{}),
 		chDone:       make(chan struct{}),
+		submissions:  submissions,
 	}
 }
 
@@ -122,21 +298,47 @@ func (fm *concreteFluxMonitor) Start() error {
 	}, models.InitiatorFluxMonitor)
 
 	wg.Wait()
-	fm.logBroadcaster.Start()
+	if startErr := fm.logBroadcaster.Start(); startErr != nil {
+		return startErr
+	}
 
 	return err
 }
 
-// Disconnect cleans up running deviation checkers.
+// Stop waits for any in-flight submissions to be recorded, refusing new ones,
+// then cleans up running deviation checkers.  It waits at most
+// FluxMonitorShutdownTimeout in all, or indefinitely if that is zero.
 func (fm *concreteFluxMonitor) Stop() {
 	if fm.disabled {
 		logger.Info("Flux monitor disabled: cannot stop")
 		return
 	}
 
+	timeout := fm.store.Config.FluxMonitorShutdownTimeout().Duration()
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	defer cancel()
+
+	if !fm.submissions.await(ctx) {
+		logger.Warnw("Flux monitor timed out waiting for in-flight submissions, stopping anyway",
+			"timeout", timeout,
+		)
+	}
+
 	fm.logBroadcaster.Stop()
 	close(fm.chStop)
-	<-fm.chDone
+	select {
+	case <-fm.chDone:
+	case <-ctx.Done():
+		logger.Warnw("Flux monitor timed out waiting for deviation checkers to stop",
+			"timeout", timeout,
+		)
+	}
 }
 
 // serveInternalRequests handles internal requests for state change via
@@ -170,6 +372,10 @@ func (fm *concreteFluxMonitor) serveInternalRequests() {
 			}
 			delete(jobMap, jobID)
 
+		case req := <-fm.chJobMetrics:
+			checkers, ok := jobMap[req.jobID]
+			req.chResult <- jobMetricsResult{mergeJobMetrics(checkers), ok}
+
 		case <-fm.chStop:
 			for _, checkers := range jobMap {
 				for _, checker := range checkers {
@@ -203,6 +409,14 @@ func (fm *concreteFluxMonitor) AddJob(job models.JobSpec) error {
 		if err != nil {
 			return errors.Wrap(err, "factory unable to create checker")
 		}
+		if job.MinPayment != nil {
+			if setter, ok := checker.(minPaymentSetter); ok {
+				setter.SetMinPayment(job.MinPayment.ToInt())
+			}
+		}
+		if setter, ok := checker.(onJobHaltedSetter); ok {
+			setter.SetOnJobHalted(onFluxMonitorJobHalted)
+		}
 		validCheckers = append(validCheckers, checker)
 	}
 	if len(validCheckers) == 0 {
@@ -213,6 +427,16 @@ func (fm *concreteFluxMonitor) AddJob(job models.JobSpec) error {
 	return nil
 }
 
+// onFluxMonitorJobHalted records that a checker's circuit breaker halted the
+// job with the given ID.
+func onFluxMonitorJobHalted(jobID *models.ID, reason error) {
+	logger.Errorw("Flux monitor job halted",
+		"jobID", jobID,
+		"error", reason,
+	)
+	promFMJobsHalted.WithLabelValues(jobID.String()).Inc()
+}
+
 // RemoveJob stops and removes the checker for all Flux Monitor initiators belonging
 // to the passed job ID.
 func (fm *concreteFluxMonitor) RemoveJob(id *models.ID) {
@@ -223,6 +447,23 @@ func (fm *concreteFluxMonitor) RemoveJob(id *models.ID) {
 	fm.chRemove <- *id
 }
 
+// JobMetrics returns the metrics of the job with the given ID, and false if
+// the job isn't running in the flux monitor.
+func (fm *concreteFluxMonitor) JobMetrics(jobID models.ID) (FluxMonitorJobMetrics, bool) {
+	if fm.disabled {
+		return FluxMonitorJobMetrics{}, false
+	}
+
+	req := jobMetricsRequest{jobID, make(chan jobMetricsResult, 1)}
+	select {
+	case fm.chJobMetrics <- req:
+	case <-fm.chDone:
+		return FluxMonitorJobMetrics{}, false
+	}
+	result := <-req.chResult
+	return result.metrics, result.ok
+}
+
 // DeviationCheckerFactory holds the New method needed to create a new instance
 // of a DeviationChecker.
 type DeviationCheckerFactory interface {
@@ -232,6 +473,10 @@ type DeviationCheckerFactory interface {
 type pollingDeviationCheckerFactory struct {
 	store          *store.Store
 	logBroadcaster eth.LogBroadcaster
+	// submissions, if set, tracks the job runs created by the checkers
+	submissions *inFlightSubmissions
+	// instrumenter, if set, is passed to the checkers
+	instrumenter Instrumenter
 }
 
 func (f pollingDeviationCheckerFactory) New(
@@ -241,6 +486,9 @@ func (f pollingDeviationCheckerFactory) New(
 	timeout models.Duration,
 ) (DeviationChecker, error) {
 	minimumPollingInterval := models.Duration(f.store.Config.DefaultHTTPTimeout())
+	if f.submissions != nil {
+		runManager = trackedRunManager{runManager, f.submissions}
+	}
 
 	if initr.InitiatorParams.PollingInterval.Shorter(minimumPollingInterval) {
 		return nil, fmt.Errorf("pollingInterval must be equal or greater than %s",
@@ -260,21 +508,126 @@ func (f pollingDeviationCheckerFactory) New(
 		return nil, err
 	}
 
-	f.logBroadcaster.AddDependents(1)
-	fluxAggregator, err := contracts.NewFluxAggregator(initr.InitiatorParams.Address, f.store.TxManager, f.logBroadcaster)
+	account, err := f.store.KeyStore.GetFirstAccount()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to determine node's oracle address")
+	}
+
+	// Every aggregator is checked before any checker is counted as a
+	// dependent of the log broadcaster, so that a refused job leaves no
+	// dependent which will never be ready.
+	addresses := initr.InitiatorParams.AggregatorAddresses()
+	fluxAggregators := make(map[common.Address]contracts.FluxAggregator, len(addresses))
+	for _, address := range addresses {
+		fluxAggregator, err := contracts.NewFluxAggregator(address, f.store.TxManager, f.logBroadcaster)
+		if err != nil {
+			return nil, err
+		}
+		err = checkOracleAuthorized(fluxAggregator, account.Address)
+		if errors.Cause(err) == ErrOracleNotAuthorized {
+			logger.Errorw("Flux monitor refusing to start job",
+				"job", initr.JobSpecID.String(),
+				"aggregator", address.Hex(),
+				"oracle", account.Address.Hex(),
+				"error", err,
+			)
+			return nil, err
+		} else if err != nil {
+			// The aggregator may just be briefly unreachable, and refusing
+			// the job would stop it until the node restarts
+			logger.Warnw("Flux monitor unable to check oracle is authorized, starting job anyway",
+				"job", initr.JobSpecID.String(),
+				"aggregator", address.Hex(),
+				"oracle", account.Address.Hex(),
+				"error", err,
+			)
+		}
+		fluxAggregators[address] = fluxAggregator
+	}
+	newFluxAggregator := func(address common.Address) (contracts.FluxAggregator, error) {
+		fluxAggregator, exists := fluxAggregators[address]
+		if !exists {
+			return nil, fmt.Errorf("no FluxAggregator for %s", address.Hex())
+		}
+		return fluxAggregator, nil
+	}
+	readyForLogs := func() { f.logBroadcaster.DependentReady() }
+
+	var flags contracts.Flags
+	if flagsAddress := initr.InitiatorParams.FlagsAddress; flagsAddress != (common.Address{}) {
+		flags, err = contracts.NewFlags(flagsAddress, f.store.TxManager, f.logBroadcaster)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to create Flags for %s", flagsAddress.Hex())
+		}
+	}
+
+	if len(addresses) > 1 {
+		checker, err := NewMultiDeviationChecker(
+			f.store,
+			newFluxAggregator,
+			initr,
+			runManager,
+			fetcher,
+			initr.InitiatorParams.PollingInterval,
+			readyForLogs,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if f.instrumenter != nil {
+			checker.SetInstrumenter(f.instrumenter)
+		}
+		if flags != nil {
+			checker.SetFlags(flags)
+		}
+		f.logBroadcaster.AddDependents(len(addresses))
+		return checker, nil
+	}
+
+	fluxAggregator, err := newFluxAggregator(initr.InitiatorParams.Address)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewPollingDeviationChecker(
+	checker, err := NewPollingDeviationChecker(
 		f.store,
 		fluxAggregator,
 		initr,
 		runManager,
 		fetcher,
 		initr.InitiatorParams.PollingInterval,
-		func() { f.logBroadcaster.DependentReady() },
+		readyForLogs,
 	)
+	if err != nil {
+		return nil, err
+	}
+	if f.instrumenter != nil {
+		checker.SetInstrumenter(f.instrumenter)
+	}
+	if flags != nil {
+		checker.SetFlags(flags)
+	}
+	f.logBroadcaster.AddDependents(1)
+	return checker, nil
+}
+
+// ErrOracleNotAuthorized is returned when the node's account is not in an
+// aggregator's oracle set, so that any submissions it made would be rejected.
+var ErrOracleNotAuthorized = errors.New("node's account is not an authorized oracle on the aggregator")
+
+// checkOracleAuthorized returns ErrOracleNotAuthorized if oracle is not one of
+// fluxAggregator's oracles.
+func checkOracleAuthorized(fluxAggregator contracts.FluxAggregator, oracle common.Address) error {
+	oracles, err := fluxAggregator.GetOracles()
+	if err != nil {
+		return errors.Wrap(err, "unable to fetch aggregator's oracles")
+	}
+	for _, authorized := range oracles {
+		if authorized == oracle {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrOracleNotAuthorized, "%s is not one of the %d oracles", oracle.Hex(), len(oracles))
 }
 
 // ExtractFeedURLs extracts a list of url.URLs from the feeds parameter of the initiator params
@@ -326,6 +679,127 @@ func GetBridgeURLFromName(name string, orm *orm.ORM) (*url.URL, error) {
 type DeviationChecker interface {
 	Start()
 	Stop()
+	Metrics() FluxMonitorJobMetrics
+}
+
+// minPaymentSetter is implemented by DeviationCheckers which honour a job's
+// MinPayment.
+type minPaymentSetter interface {
+	SetMinPayment(minPayment *big.Int)
+}
+
+// onJobHaltedSetter is implemented by DeviationCheckers which can halt their
+// job with a circuit breaker.
+type onJobHaltedSetter interface {
+	SetOnJobHalted(onJobHalted func(jobID *models.ID, reason error))
+}
+
+// MultiDeviationChecker runs one PollingDeviationChecker for each aggregator
+// address of a Flux Monitor initiator.  The checkers share a single fetcher, so
+// that the off-chain value is computed once and reused across aggregators, but
+// eligibility, rounds and submissions are tracked independently per aggregator.
+// A failure to submit to one aggregator does not affect the others.
+type MultiDeviationChecker struct {
+	checkers []*PollingDeviationChecker
+}
+
+// NewMultiDeviationChecker returns a new instance of MultiDeviationChecker.
+// newFluxAggregator is called once for each of the initiator's aggregator
+// addresses.
+func NewMultiDeviationChecker(
+	store *store.Store,
+	newFluxAggregator func(common.Address) (contracts.FluxAggregator, error),
+	initr models.Initiator,
+	runManager RunManager,
+	fetcher Fetcher,
+	pollDelay models.Duration,
+	readyForLogs func(),
+) (*MultiDeviationChecker, error) {
+	// The minimum polling interval is the HTTP timeout, so a shared value is
+	// never reused across polls.
+	shared := newSharedFetcher(fetcher, store.Config.DefaultHTTPTimeout().Duration())
+
+	var checkers []*PollingDeviationChecker
+	for _, address := range initr.InitiatorParams.AggregatorAddresses() {
+		fluxAggregator, err := newFluxAggregator(address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to create FluxAggregator for %s", address.Hex())
+		}
+
+		// Each checker sees the initiator as if it only targeted its own aggregator
+		aggregatorInitr := initr
+		aggregatorInitr.InitiatorParams.Address = address
+
+		checker, err := NewPollingDeviationChecker(
+			store,
+			fluxAggregator,
+			aggregatorInitr,
+			runManager,
+			shared,
+			pollDelay,
+			readyForLogs,
+		)
+		if err != nil {
+			return nil, err
+		}
+		checkers = append(checkers, checker)
+	}
+	return &MultiDeviationChecker{checkers}, nil
+}
+
+// Start starts the checkers for every aggregator.
+func (m *MultiDeviationChecker) Start() {
+	for _, checker := range m.checkers {
+		checker.Start()
+	}
+}
+
+// Stop stops the checkers for every aggregator.
+func (m *MultiDeviationChecker) Stop() {
+	for _, checker := range m.checkers {
+		checker.Stop()
+	}
+}
+
+// SetMinPayment sets the minimum round payment for the checkers for every
+// aggregator.
+func (m *MultiDeviationChecker) SetMinPayment(minPayment *big.Int) {
+	for _, checker := range m.checkers {
+		checker.SetMinPayment(minPayment)
+	}
+}
+
+// SetInstrumenter sets the instrumenter notified of the submission activity of
+// the checkers for every aggregator.
+func (m *MultiDeviationChecker) SetInstrumenter(instrumenter Instrumenter) {
+	for _, checker := range m.checkers {
+		checker.SetInstrumenter(instrumenter)
+	}
+}
+
+// SetOnJobHalted sets the hook called when the checker for any aggregator
+// halts the job.
+func (m *MultiDeviationChecker) SetOnJobHalted(onJobHalted func(jobID *models.ID, reason error)) {
+	for _, checker := range m.checkers {
+		checker.SetOnJobHalted(onJobHalted)
+	}
+}
+
+// SetFlags sets the Flags contract the checkers for every aggregator watch,
+// each for its own aggregator's flag.
+func (m *MultiDeviationChecker) SetFlags(flags contracts.Flags) {
+	for _, checker := range m.checkers {
+		checker.SetFlags(flags)
+	}
+}
+
+// Metrics returns the combined metrics of the checkers for every aggregator.
+func (m *MultiDeviationChecker) Metrics() FluxMonitorJobMetrics {
+	checkers := make([]DeviationChecker, len(m.checkers))
+	for i, checker := range m.checkers {
+		checkers[i] = checker
+	}
+	return mergeJobMetrics(checkers)
 }
 
 // PollingDeviationChecker polls external price adapters via HTTP to check for price swings.
@@ -337,18 +811,68 @@ type PollingDeviationChecker struct {
 
 	initr         models.Initiator
 	requestData   models.JSON
-	threshold     float64
+	thresholds    DeviationThresholds
 	precision     int32
 	idleThreshold models.Duration
+	minPayment    *big.Int
+
+	// submissionMethod is the aggregator method answers are submitted with
+	submissionMethod string
+	// answerTransforms are applied to each polled answer
+	answerTransforms []AnswerTransform
+
+	// oracleRevoked is set while an OraclePermissionsUpdated log shows that
+	// the node has been removed from the aggregator's oracles.  It is only
+	// accessed by the CSP consumer.
+	oracleRevoked bool
+
+	// flags, if set, is the Flags contract watched for the aggregator being
+	// flagged.  flagRaised is set while its flag is raised, and is only
+	// accessed by the CSP consumer.
+	flags      contracts.Flags
+	flagRaised bool
 
 	connected                  *abool.AtomicBool
 	backlog                    *utils.BoundedPriorityQueue
 	chProcessLogs              chan struct{}
+	logDebounce                time.Duration
+	logDebounceTimer           <-chan time.Time
 	reportableRoundID          *big.Int
 	mostRecentSubmittedRoundID uint64
 	pollTicker                 *ResettableTicker
 	idleTicker                 <-chan time.Time
 	roundTimeoutTicker         <-chan time.Time
+	nextPollTickAt             time.Time
+	nextIdleTickAt             time.Time
+
+	// lastRoundState is the round state most recently read from the
+	// aggregator, against which changes are logged
+	lastRoundState *contracts.FluxAggregatorRoundState
+
+	// latestAnswer is the aggregator's answer, as read by seedLatestAnswer on
+	// start or from the latest AnswerUpdated log, against which deviation is
+	// measured.  While it's nil, the node's own latest submission is used
+	// instead.  seedLatestAnswerPending is set while seedLatestAnswer has
+	// failed, so is retried before each poll.  They are only accessed by the
+	// CSP consumer.
+	latestAnswer            *big.Int
+	seedLatestAnswerPending bool
+
+	// instrumenter is notified of the checker's submission activity.
+	// pendingSubmissions are the rounds submitted to which the aggregator
+	// hasn't yet been seen to record, and are only accessed by the CSP
+	// consumer.
+	instrumenter       Instrumenter
+	pendingSubmissions map[uint64]struct{}
+
+	// circuitBreaker halts the job after repeated RoundState failures, when
+	// onJobHalted, if set, is called.  They are only accessed by the CSP
+	// consumer.
+	circuitBreaker *circuitBreaker
+	onJobHalted    func(jobID *models.ID, reason error)
+
+	metrics      FluxMonitorJobMetrics
+	metricsMutex sync.RWMutex
 
 	readyForLogs func()
 	chStop       chan struct{}
@@ -373,27 +897,43 @@ func NewPollingDeviationChecker(
 	pollDelay models.Duration,
 	readyForLogs func(),
 ) (*PollingDeviationChecker, error) {
-	// If the threshold is zero, disable pollTicker
-	if initr.InitiatorParams.Threshold == 0 {
+	// If both thresholds are zero, disable pollTicker
+	if initr.InitiatorParams.Threshold == 0 && initr.InitiatorParams.AbsoluteThreshold == 0 {
 		if !pollDelay.IsInstant() {
 			logger.Infow("NewPollingDeviationChecker: disabling pollTicker (pollDelay is forced to 0) since deviation threshold is 0",
 				"initr", initr.ID,
 				"threshold", initr.InitiatorParams.Threshold,
+				"absoluteThreshold", initr.InitiatorParams.AbsoluteThreshold,
 				"pollDelay", pollDelay,
 			)
 		}
 		pollDelay = models.Duration{}
 	}
 
+	submissionMethod := initr.InitiatorParams.SubmissionMethod
+	if submissionMethod == "" {
+		submissionMethod = DefaultSubmissionMethod
+	}
+	answerTransforms, err := ParseAnswerTransforms(
+		initr.InitiatorParams.AnswerTransforms, initr.InitiatorParams.Precision)
+	if err != nil {
+		return nil, err
+	}
+
 	return &PollingDeviationChecker{
-		readyForLogs:       readyForLogs,
-		store:              store,
-		fluxAggregator:     fluxAggregator,
-		initr:              initr,
-		requestData:        initr.InitiatorParams.RequestData,
-		idleThreshold:      initr.InitiatorParams.IdleThreshold,
-		threshold:          float64(initr.InitiatorParams.Threshold),
+		readyForLogs:   readyForLogs,
+		store:          store,
+		fluxAggregator: fluxAggregator,
+		initr:          initr,
+		requestData:    initr.InitiatorParams.RequestData,
+		idleThreshold:  initr.InitiatorParams.IdleThreshold,
+		thresholds: DeviationThresholds{
+			Relative: float64(initr.InitiatorParams.Threshold),
+			Absolute: float64(initr.InitiatorParams.AbsoluteThreshold),
+		},
 		precision:          initr.InitiatorParams.Precision,
+		submissionMethod:   submissionMethod,
+		answerTransforms:   answerTransforms,
 		runManager:         runManager,
 		fetcher:            fetcher,
 		pollTicker:         NewResettableTicker(pollDelay),
@@ -403,18 +943,32 @@ func NewPollingDeviationChecker(
 		backlog: utils.NewBoundedPriorityQueue(map[uint]uint{
 			// We want reconnecting nodes to be able to submit to a round
 			// that hasn't hit maxAnswers yet, as well as the newest round.
-			priorityNewRoundLog:      2,
-			priorityAnswerUpdatedLog: 1,
+			// Only the latest flag change matters.
+			priorityFlagLog:                     1,
+			priorityOraclePermissionsUpdatedLog: 1,
+			priorityNewRoundLog:                 2,
+			priorityAnswerUpdatedLog:            1,
+			prioritySubmissionReceivedLog:       1,
 		}),
-		chProcessLogs: make(chan struct{}, 1),
-		chStop:        make(chan struct{}),
-		waitOnStop:    make(chan struct{}),
+		chProcessLogs:      make(chan struct{}, 1),
+		logDebounce:        store.Config.FluxMonitorLogDebounce().Duration(),
+		instrumenter:       NoopInstrumenter,
+		pendingSubmissions: make(map[uint64]struct{}),
+		circuitBreaker: newCircuitBreaker(
+			store.Config.FluxMonitorBreakerThreshold(),
+			store.Config.FluxMonitorBreakerCooldown().Duration(),
+		),
+		chStop:     make(chan struct{}),
+		waitOnStop: make(chan struct{}),
 	}, nil
 }
 
 const (
-	priorityNewRoundLog      uint = 0
-	priorityAnswerUpdatedLog uint = 1
+	priorityFlagLog                     uint = 0
+	priorityOraclePermissionsUpdatedLog uint = 1
+	priorityNewRoundLog                 uint = 2
+	priorityAnswerUpdatedLog            uint = 3
+	prioritySubmissionReceivedLog       uint = 4
 )
 
 // Start begins the CSP consumer in a single goroutine to
@@ -427,12 +981,82 @@ func (p *PollingDeviationChecker) Start() {
 	go p.consume()
 }
 
+// SetMinPayment sets the minimum round payment the checker will submit for,
+// overriding the node's MINIMUM_CONTRACT_PAYMENT. A zero minPayment means
+// every round is worth submitting to. It must be called before Start.
+func (p *PollingDeviationChecker) SetMinPayment(minPayment *big.Int) {
+	p.minPayment = minPayment
+}
+
+// SetInstrumenter sets the instrumenter notified of the checker's submission
+// activity.  It must be called before Start.
+func (p *PollingDeviationChecker) SetInstrumenter(instrumenter Instrumenter) {
+	p.instrumenter = instrumenter
+}
+
+// SetOnJobHalted sets the hook called when the checker halts its job after
+// repeated failures to read the aggregator's round state, with the last
+// failure.  It's called from the checker's goroutine, so should return
+// promptly.  It must be called before Start.
+func (p *PollingDeviationChecker) SetOnJobHalted(onJobHalted func(jobID *models.ID, reason error)) {
+	p.onJobHalted = onJobHalted
+}
+
+// SetFlags sets the Flags contract watched for the aggregator being flagged.
+// The checker doesn't submit while the aggregator's flag is raised.  It must
+// be called before Start.
+func (p *PollingDeviationChecker) SetFlags(flags contracts.Flags) {
+	p.flags = flags
+}
+
 // Stop stops this instance from polling, cleaning up resources.
 func (p *PollingDeviationChecker) Stop() {
 	close(p.chStop)
 	<-p.waitOnStop
 }
 
+// Metrics returns a snapshot of the checker's recent activity.
+func (p *PollingDeviationChecker) Metrics() FluxMonitorJobMetrics {
+	p.metricsMutex.RLock()
+	defer p.metricsMutex.RUnlock()
+	return p.metrics
+}
+
+func (p *PollingDeviationChecker) updateMetrics(update func(metrics *FluxMonitorJobMetrics)) {
+	p.metricsMutex.Lock()
+	defer p.metricsMutex.Unlock()
+	update(&p.metrics)
+}
+
+// updateNextPollAt records the earliest of the poll and idle tickers' next
+// ticks.
+//
+// Only invoked by the CSP consumer on the single goroutine for thread safety.
+func (p *PollingDeviationChecker) updateNextPollAt() {
+	next := p.nextPollTickAt
+	if next.IsZero() || (!p.nextIdleTickAt.IsZero() && p.nextIdleTickAt.Before(next)) {
+		next = p.nextIdleTickAt
+	}
+	p.updateMetrics(func(metrics *FluxMonitorJobMetrics) { metrics.NextPollAt = next })
+}
+
+func (p *PollingDeviationChecker) resetPollTicker() {
+	p.pollTicker.Reset()
+	p.nextPollTickAt = time.Time{}
+	if !p.pollTicker.d.IsInstant() {
+		p.nextPollTickAt = time.Now().Add(p.pollTicker.d.Duration())
+	}
+	p.updateNextPollAt()
+}
+
+func (p *PollingDeviationChecker) resetIdleTicker() {
+	if !p.idleThreshold.IsInstant() {
+		p.idleTicker = time.After(p.idleThreshold.Duration())
+		p.nextIdleTickAt = time.Now().Add(p.idleThreshold.Duration())
+		p.updateNextPollAt()
+	}
+}
+
 func (p *PollingDeviationChecker) OnConnect() {
 	logger.Debugw("PollingDeviationChecker connected to Ethereum node",
 		"address", p.initr.InitiatorParams.Address.Hex(),
@@ -487,6 +1111,15 @@ func (p *PollingDeviationChecker) HandleLog(lb eth.LogBroadcast, err error) {
 	case *contracts.LogAnswerUpdated:
 		p.backlog.Add(priorityAnswerUpdatedLog, maybeLog{lb, err})
 
+	case *contracts.LogSubmissionReceived:
+		p.backlog.Add(prioritySubmissionReceivedLog, maybeLog{lb, err})
+
+	case *contracts.LogOraclePermissionsUpdated:
+		p.backlog.Add(priorityOraclePermissionsUpdatedLog, maybeLog{lb, err})
+
+	case *contracts.LogFlagRaised, *contracts.LogFlagLowered:
+		p.backlog.Add(priorityFlagLog, maybeLog{lb, err})
+
 	default:
 		logger.Warnf("unexpected log type %T", log)
 		return
@@ -506,6 +1139,12 @@ func (p *PollingDeviationChecker) consume() {
 	connected, unsubscribeLogs := p.fluxAggregator.SubscribeToLogs(p)
 	defer unsubscribeLogs()
 
+	if p.flags != nil {
+		_, unsubscribeFlagsLogs := p.flags.SubscribeToLogs(p)
+		defer unsubscribeFlagsLogs()
+		p.seedFlagRaised()
+	}
+
 	if connected {
 		p.connected.Set()
 	} else {
@@ -514,14 +1153,15 @@ func (p *PollingDeviationChecker) consume() {
 
 	p.readyForLogs()
 
+	// Measure the first deviation check against the answer already on chain
+	p.seedLatestAnswer()
+
 	// Try to do an initial poll
-	p.pollIfEligible(p.threshold)
-	p.pollTicker.Reset()
+	p.pollIfEligible(p.thresholds)
+	p.resetPollTicker()
 	defer p.pollTicker.Stop()
 
-	if !p.idleThreshold.IsInstant() {
-		p.idleTicker = time.After(p.idleThreshold.Duration())
-	}
+	p.resetIdleTicker()
 
 	for {
 		select {
@@ -529,6 +1169,15 @@ func (p *PollingDeviationChecker) consume() {
 			return
 
 		case <-p.chProcessLogs:
+			if p.logDebounce == 0 {
+				p.processLogs()
+			} else if p.logDebounceTimer == nil {
+				// Process this log along with any more received meanwhile
+				p.logDebounceTimer = time.After(p.logDebounce)
+			}
+
+		case <-p.logDebounceTimer:
+			p.logDebounceTimer = nil
 			p.processLogs()
 
 		case <-p.pollTicker.Tick():
@@ -539,7 +1188,9 @@ func (p *PollingDeviationChecker) consume() {
 				"reportableRoundID", p.reportableRoundID,
 				"contract", p.initr.InitiatorParams.Address.Hex(),
 			)
-			p.pollIfEligible(p.threshold)
+			p.nextPollTickAt = time.Now().Add(p.pollTicker.d.Duration())
+			p.updateNextPollAt()
+			p.pollIfEligible(p.thresholds)
 
 		case <-p.idleTicker:
 			logger.Debugw("Idle ticker fired",
@@ -549,7 +1200,9 @@ func (p *PollingDeviationChecker) consume() {
 				"reportableRoundID", p.reportableRoundID,
 				"contract", p.initr.InitiatorParams.Address.Hex(),
 			)
-			p.pollIfEligible(0)
+			p.nextIdleTickAt = time.Time{}
+			p.updateNextPollAt()
+			p.pollIfEligible(DeviationThresholds{})
 
 		case <-p.roundTimeoutTicker:
 			logger.Debugw("Round timeout ticker fired",
@@ -559,11 +1212,60 @@ func (p *PollingDeviationChecker) consume() {
 				"reportableRoundID", p.reportableRoundID,
 				"contract", p.initr.InitiatorParams.Address.Hex(),
 			)
-			p.pollIfEligible(p.threshold)
+			p.pollIfEligible(p.thresholds)
 		}
 	}
 }
 
+// seedFlagRaised reads whether the aggregator's flag is already raised.  If
+// the call fails, the flag is taken to be lowered until a log raises it, so
+// that an unreadable Flags contract doesn't stop the job.
+//
+// Only invoked by the CSP consumer on the single goroutine for thread safety.
+func (p *PollingDeviationChecker) seedFlagRaised() {
+	raised, err := p.flags.GetFlag(p.initr.InitiatorParams.Address)
+	if err != nil {
+		logger.Warnw(fmt.Sprintf("unable to read flag from Flags contract, assuming it's lowered: %v", err),
+			"jobID", p.initr.JobSpecID,
+			"contract", p.initr.InitiatorParams.Address.Hex(),
+			"flags", p.initr.InitiatorParams.FlagsAddress.Hex(),
+		)
+		return
+	}
+	if raised {
+		logger.Warnw("Aggregator is flagged, not submitting for job until its flag is lowered",
+			"jobID", p.initr.JobSpecID,
+			"contract", p.initr.InitiatorParams.Address.Hex(),
+		)
+	}
+	p.flagRaised = raised
+}
+
+// seedLatestAnswer reads the aggregator's latest answer.  If the call fails,
+// it's retried before each poll, until it succeeds or an AnswerUpdated log
+// supplies the answer.
+//
+// Only invoked by the CSP consumer on the single goroutine for thread safety.
+func (p *PollingDeviationChecker) seedLatestAnswer() {
+	answer, err := p.fluxAggregator.LatestAnswer()
+	if err != nil {
+		logger.Warnw(fmt.Sprintf("unable to read latest answer from FluxAggregator contract, will retry before next poll: %v", err),
+			"jobID", p.initr.JobSpecID,
+			"contract", p.initr.InitiatorParams.Address.Hex(),
+		)
+		p.seedLatestAnswerPending = true
+		return
+	}
+	p.setLatestAnswer(answer)
+}
+
+func (p *PollingDeviationChecker) setLatestAnswer(answer *big.Int) {
+	p.latestAnswer = answer
+	p.seedLatestAnswerPending = false
+	latestAnswer := decimal.NewFromBigInt(answer, -p.precision)
+	p.updateMetrics(func(metrics *FluxMonitorJobMetrics) { metrics.LatestAnswer = latestAnswer })
+}
+
 func (p *PollingDeviationChecker) determineMostRecentSubmittedRoundID() {
 	myAccount, err := p.store.KeyStore.GetFirstAccount()
 	if err != nil {
@@ -604,30 +1306,111 @@ func (p *PollingDeviationChecker) determineMostRecentSubmittedRoundID() {
 
 func (p *PollingDeviationChecker) processLogs() {
 	for !p.backlog.Empty() {
-		maybeLog := p.backlog.Take().(maybeLog)
-
-		if maybeLog.Err != nil {
-			logger.Errorf("error received from log broadcaster: %v", maybeLog.Err)
-			continue
+		var batch []maybeLog
+		for !p.backlog.Empty() {
+			batch = append(batch, p.backlog.Take().(maybeLog))
 		}
+		superseded := p.supersededNewRoundLogs(batch)
 
-		switch log := maybeLog.LogBroadcast.Log().(type) {
-		case *contracts.LogNewRound:
-			logger.Debugw("NewRound log", p.loggerFieldsForNewRound(log)...)
-			// maybeLog.LogBroadcast.Consume(func() { p.respondToNewRoundLog(log) })
-			// p.respondToNewRoundLog(log)
-			consumeLogBroadcast(maybeLog.LogBroadcast, func() { p.respondToNewRoundLog(log) })
+		for _, maybeLog := range batch {
+			if maybeLog.Err != nil {
+				logger.Errorf("error received from log broadcaster: %v", maybeLog.Err)
+				continue
+			}
 
-		case *contracts.LogAnswerUpdated:
-			logger.Debugw("AnswerUpdated log", p.loggerFieldsForAnswerUpdated(log)...)
-			// maybeLog.LogBroadcast.Consume(func() { p.respondToAnswerUpdatedLog(log) })
-			consumeLogBroadcast(maybeLog.LogBroadcast, func() { p.respondToAnswerUpdatedLog(log) })
+			switch log := maybeLog.LogBroadcast.Log().(type) {
+			case *contracts.LogNewRound:
+				logger.Debugw("NewRound log", p.loggerFieldsForNewRound(log)...)
+				if superseded[log] {
+					logger.Debugw("Coalescing NewRound log into a newer one", p.loggerFieldsForNewRound(log)...)
+					consumeLogBroadcast(maybeLog.LogBroadcast, func() {})
+					continue
+				}
+				// maybeLog.LogBroadcast.Consume(func() { p.respondToNewRoundLog(log) })
+				// p.respondToNewRoundLog(log)
+				consumeLogBroadcast(maybeLog.LogBroadcast, func() { p.respondToNewRoundLog(log) })
 
-			// p.respondToAnswerUpdatedLog(log)
+			case *contracts.LogAnswerUpdated:
+				logger.Debugw("AnswerUpdated log", p.loggerFieldsForAnswerUpdated(log)...)
+				// maybeLog.LogBroadcast.Consume(func() { p.respondToAnswerUpdatedLog(log) })
+				consumeLogBroadcast(maybeLog.LogBroadcast, func() { p.respondToAnswerUpdatedLog(log) })
 
-		default:
+				// p.respondToAnswerUpdatedLog(log)
+
+			case *contracts.LogSubmissionReceived:
+				consumeLogBroadcast(maybeLog.LogBroadcast, func() { p.respondToSubmissionReceivedLog(log) })
+
+			case *contracts.LogOraclePermissionsUpdated:
+				consumeLogBroadcast(maybeLog.LogBroadcast, func() { p.respondToOraclePermissionsUpdatedLog(log) })
+
+			case *contracts.LogFlagRaised:
+				consumeLogBroadcast(maybeLog.LogBroadcast, func() { p.respondToFlagLog(log.Subject, true, log.Address) })
+
+			case *contracts.LogFlagLowered:
+				consumeLogBroadcast(maybeLog.LogBroadcast, func() { p.respondToFlagLog(log.Subject, false, log.Address) })
+
+			default:
+			}
 		}
 	}
+	// Only checked once the whole backlog has been processed, as it's
+	// prioritized: SubmissionReceived logs are processed after any NewRound
+	// logs which move the reportable round on
+	p.reportRevertedSubmissions()
+}
+
+// supersededNewRoundLogs returns the NewRound logs in batch which are
+// superseded by a log for a later round in it, if logs are debounced.  Each
+// evaluation of a NewRound log reads the round state afresh, so answering only
+// the newest of them answers the round the aggregator is on.
+func (p *PollingDeviationChecker) supersededNewRoundLogs(batch []maybeLog) map[*contracts.LogNewRound]bool {
+	if p.logDebounce == 0 {
+		return nil
+	}
+	var newest *contracts.LogNewRound
+	superseded := make(map[*contracts.LogNewRound]bool)
+	for _, maybeLog := range batch {
+		if maybeLog.Err != nil {
+			continue
+		}
+		log, ok := maybeLog.LogBroadcast.Log().(*contracts.LogNewRound)
+		if !ok {
+			continue
+		}
+		if newest == nil {
+			newest = log
+		} else if log.RoundId.Cmp(newest.RoundId) >= 0 {
+			superseded[newest] = true
+			newest = log
+		} else {
+			superseded[log] = true
+		}
+	}
+	return superseded
+}
+
+// reportRevertedSubmissions reports the pending submissions to rounds before
+// the reportable round as reverted: the aggregator has moved on without
+// recording them.
+//
+// Only invoked by the CSP consumer on the single goroutine for thread safety.
+func (p *PollingDeviationChecker) reportRevertedSubmissions() {
+	if p.reportableRoundID == nil {
+		return
+	}
+	for roundID := range p.pendingSubmissions {
+		if roundID >= p.reportableRoundID.Uint64() {
+			continue
+		}
+		logger.Warnw("Aggregator moved on without recording our submission",
+			"jobID", p.initr.JobSpecID,
+			"round", roundID,
+			"reportableRound", p.reportableRoundID,
+			"contract", p.initr.InitiatorParams.Address.Hex(),
+		)
+		delete(p.pendingSubmissions, roundID)
+		p.instrumenter.SubmissionReverted(p.initr.JobSpecID, new(big.Int).SetUint64(roundID))
+	}
 }
 
 func consumeLogBroadcast(lb eth.LogBroadcast, callback func()) {
@@ -650,7 +1433,76 @@ func consumeLogBroadcast(lb eth.LogBroadcast, callback func()) {
 func (p *PollingDeviationChecker) respondToAnswerUpdatedLog(log *contracts.LogAnswerUpdated) {
 	if p.reportableRoundID != nil && log.RoundId.Cmp(p.reportableRoundID) < 0 {
 		logger.Debugw("Received stale AnswerUpdated log", p.loggerFieldsForAnswerUpdated(log)...)
+		return
 	}
+	p.setLatestAnswer(log.Current)
+}
+
+// The SubmissionReceived log tells us that an oracle's submission has been
+// recorded.  We only log our own submissions.
+//
+// Only invoked by the CSP consumer on the single goroutine for thread safety.
+func (p *PollingDeviationChecker) respondToSubmissionReceivedLog(log *contracts.LogSubmissionReceived) {
+	acct, err := p.store.KeyStore.GetFirstAccount()
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("error fetching account from keystore: %v", err), p.loggerFieldsForSubmissionReceived(log)...)
+		return
+	} else if log.Oracle != acct.Address {
+		return
+	}
+	logger.Infow("Submission recorded by aggregator", p.loggerFieldsForSubmissionReceived(log)...)
+	roundID := uint64(log.Round)
+	if _, pending := p.pendingSubmissions[roundID]; pending {
+		delete(p.pendingSubmissions, roundID)
+		p.instrumenter.SubmissionSucceeded(p.initr.JobSpecID, new(big.Int).SetUint64(roundID))
+	}
+}
+
+// The OraclePermissionsUpdated log tells us that an oracle has been added to
+// or removed from the aggregator.  While our node is removed, any submission
+// would revert, so the checker stops submitting for the job until it is added
+// back.
+//
+// Only invoked by the CSP consumer on the single goroutine for thread safety.
+func (p *PollingDeviationChecker) respondToOraclePermissionsUpdatedLog(log *contracts.LogOraclePermissionsUpdated) {
+	acct, err := p.store.KeyStore.GetFirstAccount()
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("error fetching account from keystore: %v", err), p.loggerFieldsForOraclePermissionsUpdated(log)...)
+		return
+	} else if log.Oracle != acct.Address {
+		return
+	}
+
+	if !log.Whitelisted {
+		logger.Errorw("Node's oracle permission revoked, no longer submitting for job", p.loggerFieldsForOraclePermissionsUpdated(log)...)
+	} else if p.oracleRevoked {
+		logger.Infow("Node's oracle permission restored, resuming submissions for job", p.loggerFieldsForOraclePermissionsUpdated(log)...)
+	}
+	p.oracleRevoked = !log.Whitelisted
+}
+
+// The FlagRaised and FlagLowered logs tell us that a subject of the Flags
+// contract has been flagged as being in a known-bad state, or has recovered.
+// While our aggregator is flagged, the checker stops submitting for the job.
+//
+// Only invoked by the CSP consumer on the single goroutine for thread safety.
+func (p *PollingDeviationChecker) respondToFlagLog(subject common.Address, raised bool, flags common.Address) {
+	if subject != p.initr.InitiatorParams.Address {
+		return
+	}
+
+	fields := []interface{}{
+		"subject", subject.Hex(),
+		"raised", raised,
+		"flags", flags.Hex(),
+		"job", p.initr.JobSpecID,
+	}
+	if raised && !p.flagRaised {
+		logger.Warnw("Aggregator flagged, no longer submitting for job", fields...)
+	} else if !raised && p.flagRaised {
+		logger.Infow("Aggregator's flag lowered, resuming submissions for job", fields...)
+	}
+	p.flagRaised = raised
 }
 
 // The NewRound log tells us that an oracle has initiated a new round.  This tells us that we
@@ -659,9 +1511,7 @@ func (p *PollingDeviationChecker) respondToAnswerUpdatedLog(log *contracts.LogAn
 // Only invoked by the CSP consumer on the single goroutine for thread safety.
 func (p *PollingDeviationChecker) respondToNewRoundLog(log *contracts.LogNewRound) {
 	// The idleThreshold resets when a new round starts
-	if !p.idleThreshold.IsInstant() {
-		p.idleTicker = time.After(p.idleThreshold.Duration())
-	}
+	p.resetIdleTicker()
 
 	jobSpecID := p.initr.JobSpecID.String()
 	promSetBigInt(promFMSeenRound.WithLabelValues(jobSpecID), log.RoundId)
@@ -680,7 +1530,10 @@ func (p *PollingDeviationChecker) respondToNewRoundLog(log *contracts.LogNewRoun
 	// (for example, if a large set of logs are delayed and arrive all at once).  We trust the value
 	// from RoundState() over the one in the log, and record it as the current ReportableRoundID.
 	roundState, err := p.roundState()
-	if err != nil {
+	if err == ErrJobHalted {
+		logger.Debugw(fmt.Sprintf("Ignoring new round request: %v", err), p.loggerFieldsForNewRound(log)...)
+		return
+	} else if err != nil {
 		logger.Errorw(fmt.Sprintf("Ignoring new round request: error fetching eligibility from contract: %v", err), p.loggerFieldsForNewRound(log)...)
 		return
 	}
@@ -710,6 +1563,11 @@ func (p *PollingDeviationChecker) respondToNewRoundLog(log *contracts.LogNewRoun
 		logger.Errorw(fmt.Sprintf("unable to fetch median price: %v", err), p.loggerFieldsForNewRound(log)...)
 		return
 	}
+	polledAnswer, err = applyAnswerTransforms(polledAnswer, p.answerTransforms, p.precision)
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("unable to transform median price: %v", err), p.loggerFieldsForNewRound(log)...)
+		return
+	}
 
 	p.createJobRun(polledAnswer, p.reportableRoundID)
 }
@@ -719,15 +1577,27 @@ var (
 	ErrUnderfunded      = errors.New("aggregator is underfunded")
 	ErrPaymentTooLow    = errors.New("round payment amount < minimum contract payment")
 	ErrAlreadySubmitted = errors.Errorf("already submitted for round")
+	ErrOracleRevoked    = errors.New("node's oracle permission has been revoked")
+	ErrFlagRaised       = errors.New("aggregator's flag is raised")
+	ErrInsufficientEth  = errors.New("node's ETH balance < minimum flux monitor ETH balance")
+	ErrJobHalted        = errors.New("job halted after repeated failures to read round state")
 )
 
 func (p *PollingDeviationChecker) checkEligibilityAndAggregatorFunding(roundState contracts.FluxAggregatorRoundState) error {
-	if !roundState.EligibleToSubmit {
+	if p.oracleRevoked {
+		return ErrOracleRevoked
+	} else if p.flagRaised {
+		return ErrFlagRaised
+	} else if !roundState.EligibleToSubmit {
 		return ErrNotEligible
 	} else if !p.SufficientFunds(roundState) {
 		return ErrUnderfunded
 	} else if !p.SufficientPayment(roundState.PaymentAmount) {
 		return ErrPaymentTooLow
+	} else if sufficient, err := p.SufficientEthBalance(); err != nil {
+		return errors.Wrap(err, "unable to check node's ETH balance")
+	} else if !sufficient {
+		return ErrInsufficientEth
 	} else if p.mostRecentSubmittedRoundID >= uint64(roundState.ReportableRoundID) {
 		return ErrAlreadySubmitted
 	}
@@ -744,25 +1614,73 @@ func (p *PollingDeviationChecker) SufficientFunds(state contracts.FluxAggregator
 	return state.AvailableFunds.Cmp(min) >= 0
 }
 
-// Checks if the available payment is enough to submit an answer.
+// Checks if the round payment is enough to submit an answer. The job's
+// MinPayment takes precedence over the node's MINIMUM_CONTRACT_PAYMENT, and a
+// nil or zero minimum accepts any payment.
 func (p *PollingDeviationChecker) SufficientPayment(payment *big.Int) bool {
-	return payment.Cmp(p.store.Config.MinimumContractPayment().ToInt()) >= 0
+	min := p.minPayment
+	if min == nil {
+		if configMin := p.store.Config.MinimumContractPayment(); configMin != nil {
+			min = configMin.ToInt()
+		}
+	}
+	if min == nil || min.Sign() == 0 {
+		return true
+	}
+	return payment != nil && payment.Cmp(min) >= 0
 }
 
-func (p *PollingDeviationChecker) pollIfEligible(threshold float64) (createdJobRun bool) {
+// Checks if the node's account holds enough ETH to pay the gas for a
+// submission.  A zero FLUX_MONITOR_MINIMUM_ETH_BALANCE_WEI skips the check.
+func (p *PollingDeviationChecker) SufficientEthBalance() (bool, error) {
+	min := p.store.Config.FluxMonitorMinimumEthBalanceWei()
+	if min == nil || min.Sign() == 0 {
+		return true, nil
+	}
+	acct, err := p.store.KeyStore.GetFirstAccount()
+	if err != nil {
+		return false, err
+	}
+	balance, err := p.store.TxManager.BalanceAt(acct.Address, nil)
+	if err != nil {
+		return false, err
+	}
+	if balance.Cmp(min) < 0 {
+		logger.Warnw("Node's ETH balance is too low to submit to flux monitor aggregator",
+			"account", acct.Address.Hex(),
+			"balance", balance.String(),
+			"minimum", min.String(),
+			"jobID", p.initr.JobSpecID,
+		)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *PollingDeviationChecker) pollIfEligible(thresholds DeviationThresholds) (createdJobRun bool) {
 	loggerFields := []interface{}{
 		"jobID", p.initr.JobSpecID,
 		"address", p.initr.InitiatorParams.Address,
-		"threshold", threshold,
+		"threshold", thresholds.Relative,
+		"absoluteThreshold", thresholds.Absolute,
 	}
 
 	if p.connected.IsSet() == false {
 		logger.Warnw("not connected to Ethereum node, skipping poll", loggerFields...)
 		return false
 	}
+	if lastSubmittedAt := p.Metrics().LastSubmittedAt; !lastSubmittedAt.IsZero() {
+		p.instrumenter.TimeSinceLastSubmission(p.initr.JobSpecID, time.Since(lastSubmittedAt))
+	}
+	if p.seedLatestAnswerPending {
+		p.seedLatestAnswer()
+	}
 
 	roundState, err := p.roundState()
-	if err != nil {
+	if err == ErrJobHalted {
+		logger.Debugw(fmt.Sprintf("skipping poll: %v", err), loggerFields...)
+		return false
+	} else if err != nil {
 		logger.Errorw(fmt.Sprintf("unable to determine eligibility to submit from FluxAggregator contract: %v", err), loggerFields...)
 		return false
 	}
@@ -782,16 +1700,25 @@ func (p *PollingDeviationChecker) pollIfEligible(threshold float64) (createdJobR
 		logger.Errorw(fmt.Sprintf("can't fetch answer: %v", err), loggerFields...)
 		return false
 	}
+	polledAnswer, err = applyAnswerTransforms(polledAnswer, p.answerTransforms, p.precision)
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("can't transform answer: %v", err), loggerFields...)
+		return false
+	}
 
 	jobSpecID := p.initr.JobSpecID.String()
-	latestAnswer := decimal.NewFromBigInt(roundState.LatestAnswer, -p.precision)
+	baseline := roundState.LatestAnswer
+	if p.latestAnswer != nil {
+		baseline = p.latestAnswer
+	}
+	latestAnswer := decimal.NewFromBigInt(baseline, -p.precision)
 
 	promSetDecimal(promFMSeenValue.WithLabelValues(jobSpecID), polledAnswer)
 	loggerFields = append(loggerFields,
 		"latestAnswer", latestAnswer,
 		"polledAnswer", polledAnswer,
 	)
-	if roundState.ReportableRoundID > 1 && !OutsideDeviation(latestAnswer, polledAnswer, threshold) {
+	if roundState.ReportableRoundID > 1 && !OutsideDeviationThresholds(latestAnswer, polledAnswer, thresholds) {
 		logger.Debugw("deviation < threshold, not submitting", loggerFields...)
 		return false
 	}
@@ -818,13 +1745,39 @@ func (p *PollingDeviationChecker) roundState() (contracts.FluxAggregatorRoundSta
 	if err != nil {
 		return contracts.FluxAggregatorRoundState{}, err
 	}
+	if !p.circuitBreaker.allow() {
+		return contracts.FluxAggregatorRoundState{}, ErrJobHalted
+	}
 	roundState, err := p.fluxAggregator.RoundState(acct.Address)
 	if err != nil {
+		p.recordRoundStateFailure(err)
 		return contracts.FluxAggregatorRoundState{}, err
 	}
+	if p.circuitBreaker.recordSuccess() {
+		logger.Infow("Flux monitor resuming job: read round state",
+			"jobID", p.initr.JobSpecID,
+			"contract", p.initr.InitiatorParams.Address.Hex(),
+		)
+	}
+	if p.lastRoundState != nil {
+		if changes := p.lastRoundState.Diff(roundState); len(changes) > 0 {
+			logger.Debugw("FluxAggregator round state changed",
+				"changes", changes,
+				"jobID", p.initr.JobSpecID,
+				"contract", p.initr.InitiatorParams.Address.Hex(),
+			)
+		}
+	}
+	p.lastRoundState = &roundState
 
 	// It's pointless to listen to logs from before the current reporting round
 	p.reportableRoundID = big.NewInt(int64(roundState.ReportableRoundID))
+	p.instrumenter.ReportableRound(p.initr.JobSpecID, big.NewInt(int64(roundState.ReportableRoundID)))
+
+	if roundState.LatestAnswer != nil && p.latestAnswer == nil {
+		latestAnswer := decimal.NewFromBigInt(roundState.LatestAnswer, -p.precision)
+		p.updateMetrics(func(metrics *FluxMonitorJobMetrics) { metrics.LatestAnswer = latestAnswer })
+	}
 
 	// Update the roundTimeoutTicker using the .TimesOutAt field describing the current round
 	if roundState.TimesOutAt() == 0 {
@@ -866,6 +1819,39 @@ func (p *PollingDeviationChecker) roundState() (contracts.FluxAggregatorRoundSta
 	return roundState, nil
 }
 
+// recordRoundStateFailure counts a failure to read the round state towards
+// the circuit breaker, halting the job if it opens.
+//
+// Only invoked by the CSP consumer on the single goroutine for thread safety.
+func (p *PollingDeviationChecker) recordRoundStateFailure(err error) {
+	wasOpen := p.circuitBreaker.isOpen()
+	if !p.circuitBreaker.recordFailure() {
+		if wasOpen {
+			logger.Warnw("Flux monitor job still halted: unable to read round state",
+				"jobID", p.initr.JobSpecID,
+				"contract", p.initr.InitiatorParams.Address.Hex(),
+				"cooldown", p.circuitBreaker.cooldown,
+				"error", err,
+			)
+		}
+		return
+	}
+	reason := errors.Wrapf(err, "%d consecutive failures to read round state", p.circuitBreaker.threshold)
+	logger.Errorw("Flux monitor halting job",
+		"jobID", p.initr.JobSpecID,
+		"contract", p.initr.InitiatorParams.Address.Hex(),
+		"cooldown", p.circuitBreaker.cooldown,
+		"error", reason,
+	)
+	if p.onJobHalted != nil {
+		p.onJobHalted(p.initr.JobSpecID, reason)
+	}
+}
+
+// DefaultSubmissionMethod is the aggregator method answers are submitted with,
+// unless a job's initiator names another with SubmissionMethod.
+const DefaultSubmissionMethod = "submit"
+
 // jobRunRequest is the request used to trigger a Job Run by the Flux Monitor.
 type jobRunRequest struct {
 	Result           decimal.Decimal `json:"result"`
@@ -875,9 +1861,9 @@ type jobRunRequest struct {
 }
 
 func (p *PollingDeviationChecker) createJobRun(polledAnswer decimal.Decimal, nextRound *big.Int) error {
-	methodID, err := p.fluxAggregator.GetMethodID("submit")
+	methodID, err := p.fluxAggregator.GetMethodID(p.submissionMethod)
 	if err != nil {
-		return err
+		return errors.Wrapf(err, "unable to encode call to submission method %s", p.submissionMethod)
 	}
 
 	nextRoundData, err := utils.EVMWordBigInt(nextRound)
@@ -900,12 +1886,19 @@ func (p *PollingDeviationChecker) createJobRun(polledAnswer decimal.Decimal, nex
 	}
 	runRequest := models.NewRunRequest(runData)
 
+	p.instrumenter.SubmissionAttempted(p.initr.JobSpecID, new(big.Int).Set(nextRound))
 	_, err = p.runManager.Create(p.initr.JobSpecID, &p.initr, nil, runRequest)
 	if err != nil {
 		return err
 	}
 
 	p.mostRecentSubmittedRoundID = nextRound.Uint64()
+	p.pendingSubmissions[nextRound.Uint64()] = struct{}{}
+	submittedAt := time.Now()
+	p.updateMetrics(func(metrics *FluxMonitorJobMetrics) {
+		metrics.LastSubmittedAt = submittedAt
+		metrics.LastSubmittedAnswer = polledAnswer
+	})
 
 	return nil
 }
@@ -931,6 +1924,25 @@ func (p *PollingDeviationChecker) loggerFieldsForAnswerUpdated(log *contracts.Lo
 	}
 }
 
+func (p *PollingDeviationChecker) loggerFieldsForSubmissionReceived(log *contracts.LogSubmissionReceived) []interface{} {
+	return []interface{}{
+		"round", log.Round,
+		"submission", log.Submission.String(),
+		"oracle", log.Oracle.Hex(),
+		"contract", log.Address.Hex(),
+		"job", p.initr.JobSpecID,
+	}
+}
+
+func (p *PollingDeviationChecker) loggerFieldsForOraclePermissionsUpdated(log *contracts.LogOraclePermissionsUpdated) []interface{} {
+	return []interface{}{
+		"oracle", log.Oracle.Hex(),
+		"whitelisted", log.Whitelisted,
+		"contract", log.Address.Hex(),
+		"job", p.initr.JobSpecID,
+	}
+}
+
 func (p *PollingDeviationChecker) Consumer() models.LogConsumer {
 	return models.LogConsumer{
 		Type: models.LogConsumerTypeJob,
@@ -938,6 +1950,49 @@ func (p *PollingDeviationChecker) Consumer() models.LogConsumer {
 	}
 }
 
+// DeviationThresholds are the changes in the answer which trigger a new
+// submission.  Relative is a percentage of the current answer, and Absolute is
+// a difference between the answers, which remains meaningful for answers
+// passing through zero.  Either may be zero, to disable it.
+type DeviationThresholds struct {
+	Relative float64
+	Absolute float64
+}
+
+// OutsideDeviationThresholds checks whether the next price is outside either
+// of the thresholds.  If both thresholds are zero, always returns true.
+//
+// A percentage of a zero current answer is meaningless, so if there's an
+// absolute threshold, it alone decides whether a move away from zero deviates.
+func OutsideDeviationThresholds(curAnswer, nextAnswer decimal.Decimal, thresholds DeviationThresholds) bool {
+	if thresholds.Relative == 0 && thresholds.Absolute == 0 {
+		return OutsideDeviation(curAnswer, nextAnswer, 0)
+	}
+
+	if thresholds.Absolute != 0 {
+		diff := curAnswer.Sub(nextAnswer).Abs()
+		if !diff.LessThan(decimal.NewFromFloat(thresholds.Absolute)) {
+			logger.Infow("Absolute deviation threshold met",
+				"absoluteThreshold", thresholds.Absolute,
+				"currentAnswer", curAnswer,
+				"nextAnswer", nextAnswer,
+				"difference", diff,
+			)
+			return true
+		}
+	}
+
+	if thresholds.Relative == 0 || (thresholds.Absolute != 0 && curAnswer.IsZero()) {
+		logger.Debugw("Absolute deviation threshold not met",
+			"absoluteThreshold", thresholds.Absolute,
+			"currentAnswer", curAnswer,
+			"nextAnswer", nextAnswer,
+		)
+		return false
+	}
+	return OutsideDeviation(curAnswer, nextAnswer, thresholds.Relative)
+}
+
 // OutsideDeviation checks whether the next price is outside the threshold.
 // If the threshold is zero, always returns true.
 func OutsideDeviation(curAnswer, nextAnswer decimal.Decimal, threshold float64) bool {
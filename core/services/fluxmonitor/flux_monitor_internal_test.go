@@ -1,6 +1,8 @@
 package fluxmonitor
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/utils"
@@ -17,14 +19,43 @@ type mockLogBroadcaster struct {
 	utils.DependentAwaiter
 }
 
-func (mlb *mockLogBroadcaster) Start() {
+func (mlb *mockLogBroadcaster) Start() error {
 	mlb.Started = true
+	return nil
 }
 func (mlb *mockLogBroadcaster) Register(common.Address, eth.LogListener) bool {
 	return false
 }
+func (mlb *mockLogBroadcaster) RegisterAndWaitForConnect(context.Context, common.Address, eth.LogListener) error {
+	return nil
+}
+func (mlb *mockLogBroadcaster) RegisterFromBlock(common.Address, eth.LogListener, uint64) bool {
+	return false
+}
+func (mlb *mockLogBroadcaster) RegisterWithFilter(common.Address, eth.LogListener, eth.LogFilter) bool {
+	return false
+}
 func (mlb *mockLogBroadcaster) Unregister(common.Address, eth.LogListener) {}
-func (mlb *mockLogBroadcaster) Stop()                                      {}
+func (mlb *mockLogBroadcaster) ReplayFromBlock(eth.LogListener, uint64) error {
+	return nil
+}
+func (mlb *mockLogBroadcaster) SnapshotRegistrations() []eth.Registration {
+	return nil
+}
+func (mlb *mockLogBroadcaster) RestoreRegistrations([]eth.Registration) {}
+func (mlb *mockLogBroadcaster) Stop()                                   {}
+func (mlb *mockLogBroadcaster) HealthReport() eth.LogBroadcasterHealth {
+	return eth.LogBroadcasterHealth{}
+}
+func (mlb *mockLogBroadcaster) RecentDeliveries() []eth.DeliveryRecord {
+	return nil
+}
+func (mlb *mockLogBroadcaster) ReorgHistory() []eth.ReorgEvent {
+	return nil
+}
+func (mlb *mockLogBroadcaster) ReconciliationReport() (uint64, uint64, []eth.LogKey) {
+	return 0, 0, nil
+}
 
 type MockableLogBroadcaster interface {
 	MockLogBroadcaster() *mockLogBroadcaster
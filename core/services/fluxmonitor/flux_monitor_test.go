@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"net/url"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -319,6 +321,7 @@ func TestPollingDeviationChecker_BuffersLogs(t *testing.T) {
 	fluxAggregator := new(mocks.FluxAggregator)
 	fluxAggregator.On("SubscribeToLogs", mock.Anything).Return(true, ethsvc.UnsubscribeFunc(func() {}), nil)
 	fluxAggregator.On("GetMethodID", "submit").Return(submitSelector, nil)
+	fluxAggregator.On("LatestAnswer").Return(makeRoundStateForRoundID(1).LatestAnswer, nil).Once()
 	fluxAggregator.On("RoundState", nodeAddr).
 		Return(makeRoundStateForRoundID(1), nil).
 		Run(func(mock.Arguments) { <-chBlock }).
@@ -374,6 +377,88 @@ func TestPollingDeviationChecker_BuffersLogs(t *testing.T) {
 	rm.AssertExpectations(t)
 }
 
+func TestPollingDeviationChecker_DebouncesLogs(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("FLUX_MONITOR_LOG_DEBOUNCE", "100ms")
+
+	nodeAddr := ensureAccount(t, store)
+
+	const fetchedValue = 100
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.ID = 1
+	initr.PollingInterval = models.MustMakeDuration(math.MaxInt64)
+	initr.IdleThreshold = models.MustMakeDuration(math.MaxInt64)
+	answer := big.NewInt(fetchedValue * int64(math.Pow10(int(initr.InitiatorParams.Precision))))
+
+	// Not connected, so that there's no initial poll
+	fluxAggregator := new(mocks.FluxAggregator)
+	fluxAggregator.On("SubscribeToLogs", mock.Anything).Return(false, ethsvc.UnsubscribeFunc(func() {}), nil)
+	fluxAggregator.On("GetMethodID", "submit").Return(submitSelector, nil)
+	fluxAggregator.On("LatestAnswer").Return(answer, nil).Once()
+	fluxAggregator.On("RoundState", nodeAddr).Return(contracts.FluxAggregatorRoundState{
+		ReportableRoundID: 3,
+		EligibleToSubmit:  true,
+		LatestAnswer:      answer,
+		AvailableFunds:    store.Config.MinimumContractPayment().ToInt(),
+		PaymentAmount:     store.Config.MinimumContractPayment().ToInt(),
+	}, nil).Once()
+
+	fetcher := new(mocks.Fetcher)
+	fetcher.On("Fetch").Return(decimal.NewFromInt(fetchedValue), nil).Once()
+
+	chSubmitted := make(chan struct{})
+	rm := new(mocks.RunManager)
+	run := cltest.NewJobRun(job)
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil).Once().
+		Run(func(mock.Arguments) { close(chSubmitted) })
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(
+		store,
+		fluxAggregator,
+		initr,
+		rm,
+		fetcher,
+		models.MustMakeDuration(math.MaxInt64),
+		func() {},
+	)
+	require.NoError(t, err)
+	checker.Start()
+
+	var logBroadcasts []*mocks.LogBroadcast
+	for _, log := range []interface{}{
+		&contracts.LogNewRound{RoundId: big.NewInt(2)},
+		&contracts.LogAnswerUpdated{RoundId: big.NewInt(2), Current: answer},
+		&contracts.LogNewRound{RoundId: big.NewInt(3)},
+	} {
+		logBroadcast := new(mocks.LogBroadcast)
+		logBroadcast.On("Log").Return(log)
+		logBroadcast.On("WasAlreadyConsumed").Return(false, nil)
+		logBroadcast.On("MarkConsumed").Return(nil).Once()
+		logBroadcasts = append(logBroadcasts, logBroadcast)
+		checker.HandleLog(logBroadcast, nil)
+	}
+
+	// The burst of logs results in a single evaluation, of the newest round
+	select {
+	case <-chSubmitted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no submission for the newest round")
+	}
+	// Stop returns once the backlog has been processed
+	checker.Stop()
+
+	fluxAggregator.AssertExpectations(t)
+	fluxAggregator.AssertNumberOfCalls(t, "RoundState", 1)
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+	for _, logBroadcast := range logBroadcasts {
+		logBroadcast.AssertExpectations(t)
+	}
+}
+
 func TestPollingDeviationChecker_TriggerIdleTimeThreshold(t *testing.T) {
 
 	tests := []struct {
@@ -408,6 +493,8 @@ func TestPollingDeviationChecker_TriggerIdleTimeThreshold(t *testing.T) {
 
 			fluxAggregator.On("SubscribeToLogs", mock.Anything).Return(true, ethsvc.UnsubscribeFunc(func() {}), nil)
 
+			fluxAggregator.On("LatestAnswer").Return(answerBigInt, nil).Once()
+
 			roundState1 := contracts.FluxAggregatorRoundState{ReportableRoundID: 1, EligibleToSubmit: false, LatestAnswer: answerBigInt} // Initial poll
 			roundState2 := contracts.FluxAggregatorRoundState{ReportableRoundID: 2, EligibleToSubmit: false, LatestAnswer: answerBigInt} // idleThreshold 1
 			roundState3 := contracts.FluxAggregatorRoundState{ReportableRoundID: 3, EligibleToSubmit: false, LatestAnswer: answerBigInt} // NewRound
@@ -496,6 +583,7 @@ func TestPollingDeviationChecker_RoundTimeoutCausesPoll(t *testing.T) {
 			answerBigInt := big.NewInt(fetchedAnswer * int64(math.Pow10(int(initr.InitiatorParams.Precision))))
 
 			fluxAggregator.On("SubscribeToLogs", mock.Anything).Return(true, ethsvc.UnsubscribeFunc(func() {}), nil)
+			fluxAggregator.On("LatestAnswer").Return(answerBigInt, nil).Once()
 
 			if test.expectedToTrigger {
 				fluxAggregator.On("RoundState", nodeAddr).Return(contracts.FluxAggregatorRoundState{
@@ -542,6 +630,191 @@ func TestPollingDeviationChecker_RoundTimeoutCausesPoll(t *testing.T) {
 	}
 }
 
+func TestPollingDeviationChecker_SeedsLatestAnswerOnStart(t *testing.T) {
+	tests := []struct {
+		name             string
+		latestAnswer     int64 // the aggregator's, read on start
+		latestSubmission int64 // the node's own
+		expectedToSubmit bool
+	}{
+		{"existing answer within threshold", 100, 0, false},
+		{"existing answer outside threshold", 50, 100, true},
+	}
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	nodeAddr := ensureAccount(t, store)
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			rm := new(mocks.RunManager)
+			fetcher := new(mocks.Fetcher)
+			fluxAggregator := new(mocks.FluxAggregator)
+
+			job := cltest.NewJobWithFluxMonitorInitiator()
+			initr := job.Initiators[0]
+			initr.ID = 1
+			initr.PollingInterval = models.MustMakeDuration(math.MaxInt64)
+			initr.IdleThreshold = models.MustMakeDuration(0)
+			precision := int64(math.Pow10(int(initr.InitiatorParams.Precision)))
+
+			paymentAmount := store.Config.MinimumContractPayment().ToInt()
+			fluxAggregator.On("SubscribeToLogs", mock.Anything).Return(true, ethsvc.UnsubscribeFunc(func() {}), nil)
+			fluxAggregator.On("LatestAnswer").Return(big.NewInt(test.latestAnswer*precision), nil).Once()
+			fluxAggregator.On("RoundState", nodeAddr).Return(contracts.FluxAggregatorRoundState{
+				ReportableRoundID: 2,
+				EligibleToSubmit:  true,
+				LatestAnswer:      big.NewInt(test.latestSubmission * precision),
+				AvailableFunds:    big.NewInt(1).Mul(paymentAmount, big.NewInt(1000)),
+				PaymentAmount:     paymentAmount,
+				OracleCount:       oracleCount,
+			}, nil).Once()
+
+			polled := make(chan struct{})
+			fetcher.On("Fetch").Return(decimal.NewFromInt(100), nil).Once().
+				Run(func(mock.Arguments) { close(polled) })
+
+			submitted := make(chan struct{})
+			if test.expectedToSubmit {
+				run := cltest.NewJobRun(job)
+				fluxAggregator.On("GetMethodID", "submit").Return(submitSelector, nil)
+				rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil).Once().
+					Run(func(mock.Arguments) { close(submitted) })
+			}
+
+			checker, err := fluxmonitor.NewPollingDeviationChecker(store,
+				fluxAggregator, initr, rm, fetcher, models.MustMakeDuration(math.MaxInt64), func() {})
+			require.NoError(t, err)
+
+			checker.OnConnect()
+			checker.Start()
+			cltest.CallbackOrTimeout(t, "initial poll", func() { <-polled })
+			if test.expectedToSubmit {
+				cltest.CallbackOrTimeout(t, "submission", func() { <-submitted })
+			}
+			checker.Stop()
+
+			assert.Equal(t, decimal.NewFromInt(test.latestAnswer).String(), checker.Metrics().LatestAnswer.String())
+			fluxAggregator.AssertExpectations(t)
+			fetcher.AssertExpectations(t)
+			rm.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPollingDeviationChecker_RetriesSeedingLatestAnswer(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	nodeAddr := ensureAccount(t, store)
+
+	rm := new(mocks.RunManager)
+	fetcher := new(mocks.Fetcher)
+	fluxAggregator := new(mocks.FluxAggregator)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.ID = 1
+	precision := int64(math.Pow10(int(initr.InitiatorParams.Precision)))
+
+	paymentAmount := store.Config.MinimumContractPayment().ToInt()
+	fluxAggregator.On("LatestAnswer").Return(nil, errors.New("connection refused")).Once()
+	fluxAggregator.On("LatestAnswer").Return(big.NewInt(100*precision), nil).Once()
+	fluxAggregator.On("RoundState", nodeAddr).Return(contracts.FluxAggregatorRoundState{
+		ReportableRoundID: 2,
+		EligibleToSubmit:  true,
+		LatestAnswer:      big.NewInt(0),
+		AvailableFunds:    big.NewInt(1).Mul(paymentAmount, big.NewInt(1000)),
+		PaymentAmount:     paymentAmount,
+		OracleCount:       oracleCount,
+	}, nil)
+	fetcher.On("Fetch").Return(decimal.NewFromInt(100), nil)
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store,
+		fluxAggregator, initr, rm, fetcher, models.MustMakeDuration(time.Second), func() {})
+	require.NoError(t, err)
+	checker.OnConnect()
+
+	checker.ExportedSeedLatestAnswer()
+	// The seed is retried before polling, so the answers don't deviate
+	assert.False(t, checker.ExportedPollIfEligible(0.1))
+	assert.False(t, checker.ExportedPollIfEligible(0.1))
+
+	fluxAggregator.AssertExpectations(t)
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+}
+
+func TestPollingDeviationChecker_HaltsAfterRepeatedRoundStateErrors(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	const threshold = 3
+	cooldown := 100 * time.Millisecond
+	store.Config.Set("FLUX_MONITOR_BREAKER_THRESHOLD", threshold)
+	store.Config.Set("FLUX_MONITOR_BREAKER_COOLDOWN", cooldown.String())
+	nodeAddr := ensureAccount(t, store)
+
+	rm := new(mocks.RunManager)
+	fetcher := new(mocks.Fetcher)
+	fluxAggregator := new(mocks.FluxAggregator)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.ID = 1
+
+	rpcErr := errors.New("execution reverted")
+	fluxAggregator.On("RoundState", nodeAddr).
+		Return(contracts.FluxAggregatorRoundState{}, rpcErr).
+		Times(threshold + 1)
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store,
+		fluxAggregator, initr, rm, fetcher, models.MustMakeDuration(time.Second), func() {})
+	require.NoError(t, err)
+	var haltedJobIDs []*models.ID
+	var reasons []error
+	checker.SetOnJobHalted(func(jobID *models.ID, reason error) {
+		haltedJobIDs = append(haltedJobIDs, jobID)
+		reasons = append(reasons, reason)
+	})
+	checker.OnConnect()
+
+	for i := 0; i < threshold-1; i++ {
+		assert.False(t, checker.ExportedPollIfEligible(0.1))
+	}
+	assert.Empty(t, haltedJobIDs)
+
+	// The job halts at the threshold, and stops reading the round state
+	for i := 0; i < 3; i++ {
+		assert.False(t, checker.ExportedPollIfEligible(0.1))
+	}
+	fluxAggregator.AssertNumberOfCalls(t, "RoundState", threshold)
+	require.Equal(t, []*models.ID{initr.JobSpecID}, haltedJobIDs)
+	assert.Equal(t, rpcErr, errors.Cause(reasons[0]))
+
+	// After the cooldown a single trial is made, whose failure halts the job
+	// again without firing the hook
+	time.Sleep(cooldown)
+	assert.False(t, checker.ExportedPollIfEligible(0.1))
+	assert.False(t, checker.ExportedPollIfEligible(0.1))
+	fluxAggregator.AssertNumberOfCalls(t, "RoundState", threshold+1)
+	assert.Len(t, haltedJobIDs, 1)
+
+	// A successful trial resumes the job
+	fluxAggregator.On("RoundState", nodeAddr).
+		Return(contracts.FluxAggregatorRoundState{ReportableRoundID: 2}, nil)
+	time.Sleep(cooldown)
+	assert.False(t, checker.ExportedPollIfEligible(0.1))
+	assert.False(t, checker.ExportedPollIfEligible(0.1))
+	fluxAggregator.AssertNumberOfCalls(t, "RoundState", threshold+3)
+
+	fluxAggregator.AssertExpectations(t)
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+}
+
 func TestPollingDeviationChecker_RespondToNewRound(t *testing.T) {
 
 	type roundIDCase struct {
@@ -921,6 +1194,46 @@ func TestOutsideDeviation(t *testing.T) {
 	}
 }
 
+func TestOutsideDeviationThresholds(t *testing.T) {
+	tests := []struct {
+		name                string
+		curPrice, nextPrice decimal.Decimal
+		thresholds          fluxmonitor.DeviationThresholds
+		expectation         bool
+	}{
+		{"crosses 0, caught only by absolute", decimal.NewFromFloat(0.1), decimal.NewFromFloat(-0.1),
+			fluxmonitor.DeviationThresholds{Relative: 201, Absolute: 0.2}, true},
+		{"crosses 0, neither threshold", decimal.NewFromFloat(0.1), decimal.NewFromFloat(-0.1),
+			fluxmonitor.DeviationThresholds{Relative: 201, Absolute: 0.3}, false},
+		{"crosses 0, absolute only", decimal.NewFromFloat(-0.1), decimal.NewFromFloat(0.1),
+			fluxmonitor.DeviationThresholds{Absolute: 0.2}, true},
+		{"normal move, caught by percentage", decimal.NewFromInt(100), decimal.NewFromInt(103),
+			fluxmonitor.DeviationThresholds{Relative: 2, Absolute: 10}, true},
+		{"normal move, percentage only", decimal.NewFromInt(100), decimal.NewFromInt(103),
+			fluxmonitor.DeviationThresholds{Relative: 2}, true},
+		{"normal move, neither threshold", decimal.NewFromInt(100), decimal.NewFromInt(101),
+			fluxmonitor.DeviationThresholds{Relative: 2, Absolute: 10}, false},
+
+		{"0 current price, inside absolute", decimal.NewFromInt(0), decimal.NewFromFloat(0.5),
+			fluxmonitor.DeviationThresholds{Relative: 2, Absolute: 1}, false},
+		{"0 current price, outside absolute", decimal.NewFromInt(0), decimal.NewFromInt(1),
+			fluxmonitor.DeviationThresholds{Relative: 2, Absolute: 1}, true},
+		{"0 current price, percentage only", decimal.NewFromInt(0), decimal.NewFromFloat(0.5),
+			fluxmonitor.DeviationThresholds{Relative: 2}, true},
+
+		{"thresholds=0, no deviation", decimal.NewFromInt(100), decimal.NewFromInt(100),
+			fluxmonitor.DeviationThresholds{}, true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual := fluxmonitor.OutsideDeviationThresholds(test.curPrice, test.nextPrice, test.thresholds)
+			assert.Equal(t, test.expectation, actual)
+		})
+	}
+}
+
 func TestExtractFeedURLs(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -1003,35 +1316,931 @@ func TestPollingDeviationChecker_SufficientPayment(t *testing.T) {
 	}
 }
 
-func TestPollingDeviationChecker_SufficientFunds(t *testing.T) {
+func TestPollingDeviationChecker_SufficientPayment_JobMinPayment(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
-	checker := cltest.NewPollingDeviationChecker(t, store)
 
-	payment := 100
-	rounds := 3
-	oracleCount := 21
-	min := payment * rounds * oracleCount
+	configMin := store.Config.MinimumContractPayment().ToInt().Int64()
 
 	tests := []struct {
-		name  string
-		funds int
-		want  bool
+		name       string
+		minPayment *big.Int
+		payment    int64
+		want       bool
 	}{
-		{"above minimum", min + 1, true},
-		{"equal to minimum", min, true},
-		{"below minimum", min - 1, false},
+		{"above job minimum", big.NewInt(configMin + 10), configMin + 11, true},
+		{"equal to job minimum", big.NewInt(configMin + 10), configMin + 10, true},
+		{"below job minimum, above config minimum", big.NewInt(configMin + 10), configMin + 9, false},
+		{"below config minimum, above job minimum", big.NewInt(configMin - 10), configMin - 9, true},
+		{"zero job minimum", big.NewInt(0), 0, true},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
+			checker := cltest.NewPollingDeviationChecker(t, store)
+			checker.SetMinPayment(test.minPayment)
+			assert.Equal(t, test.want, checker.SufficientPayment(big.NewInt(test.payment)))
+		})
+	}
+}
 
-			state := contracts.FluxAggregatorRoundState{
-				AvailableFunds: big.NewInt(int64(test.funds)),
-				PaymentAmount:  big.NewInt(int64(payment)),
-				OracleCount:    uint32(oracleCount),
+func TestPollingDeviationChecker_PollIfEligible_JobMinPayment(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	nodeAddr := ensureAccount(t, store)
+
+	const (
+		reportableRoundID = 2
+		polledAnswer      = 100
+	)
+	minPayment := big.NewInt(1000)
+
+	tests := []struct {
+		name             string
+		paymentAmount    *big.Int
+		expectedToSubmit bool
+	}{
+		{"payment below job minimum", big.NewInt(999), false},
+		{"payment equal to job minimum", big.NewInt(1000), true},
+		{"payment above job minimum", big.NewInt(1001), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rm := new(mocks.RunManager)
+			fetcher := new(mocks.Fetcher)
+			fluxAggregator := new(mocks.FluxAggregator)
+
+			job := cltest.NewJobWithFluxMonitorInitiator()
+			initr := job.Initiators[0]
+			initr.ID = 1
+
+			roundState := contracts.FluxAggregatorRoundState{
+				ReportableRoundID: reportableRoundID,
+				EligibleToSubmit:  true,
+				LatestAnswer:      big.NewInt(1),
+				AvailableFunds:    big.NewInt(1).Mul(test.paymentAmount, big.NewInt(1000)),
+				PaymentAmount:     test.paymentAmount,
+				OracleCount:       1,
+			}
+			fluxAggregator.On("RoundState", nodeAddr).Return(roundState, nil)
+
+			if test.expectedToSubmit {
+				run := cltest.NewJobRun(job)
+				fetcher.On("Fetch").Return(decimal.NewFromInt(polledAnswer), nil)
+				fluxAggregator.On("GetMethodID", "submit").Return(submitSelector, nil)
+				rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil)
+			}
+
+			checker, err := fluxmonitor.NewPollingDeviationChecker(store,
+				fluxAggregator, initr, rm, fetcher, models.MustMakeDuration(time.Second), func() {})
+			require.NoError(t, err)
+			checker.SetMinPayment(minPayment)
+			checker.OnConnect()
+
+			checker.ExportedPollIfEligible(0.1)
+
+			fluxAggregator.AssertExpectations(t)
+			fetcher.AssertExpectations(t)
+			rm.AssertExpectations(t)
+			if !test.expectedToSubmit {
+				fetcher.AssertNotCalled(t, "Fetch")
+				rm.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 			}
-			assert.Equal(t, test.want, checker.SufficientFunds(state))
 		})
 	}
 }
+
+func TestPollingDeviationChecker_StopsSubmittingWhileOracleRevoked(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	nodeAddr := ensureAccount(t, store)
+
+	rm := new(mocks.RunManager)
+	fetcher := new(mocks.Fetcher)
+	fluxAggregator := new(mocks.FluxAggregator)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.ID = 1
+
+	paymentAmount := store.Config.MinimumContractPayment().ToInt()
+	roundState := contracts.FluxAggregatorRoundState{
+		ReportableRoundID: 2,
+		EligibleToSubmit:  true,
+		LatestAnswer:      big.NewInt(1),
+		AvailableFunds:    big.NewInt(1).Mul(paymentAmount, big.NewInt(1000)),
+		PaymentAmount:     paymentAmount,
+		OracleCount:       1,
+	}
+	fluxAggregator.On("RoundState", nodeAddr).Return(roundState, nil)
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store,
+		fluxAggregator, initr, rm, fetcher, models.MustMakeDuration(time.Second), func() {})
+	require.NoError(t, err)
+	checker.OnConnect()
+
+	// Revoking another oracle's permission has no effect, but revoking ours
+	// stops submissions
+	checker.ExportedRespondToOraclePermissionsUpdatedLog(&contracts.LogOraclePermissionsUpdated{Oracle: cltest.NewAddress(), Whitelisted: false})
+	checker.ExportedRespondToOraclePermissionsUpdatedLog(&contracts.LogOraclePermissionsUpdated{Oracle: nodeAddr, Whitelisted: false})
+	assert.False(t, checker.ExportedPollIfEligible(0.1))
+	fetcher.AssertNotCalled(t, "Fetch")
+	rm.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// Restoring our permission resumes them
+	run := cltest.NewJobRun(job)
+	fetcher.On("Fetch").Return(decimal.NewFromInt(100), nil)
+	fluxAggregator.On("GetMethodID", "submit").Return(submitSelector, nil)
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil)
+
+	checker.ExportedRespondToOraclePermissionsUpdatedLog(&contracts.LogOraclePermissionsUpdated{Oracle: nodeAddr, Whitelisted: true})
+	assert.True(t, checker.ExportedPollIfEligible(0.1))
+
+	fluxAggregator.AssertExpectations(t)
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+}
+
+func TestPollingDeviationChecker_StopsSubmittingWhileFlagRaised(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	nodeAddr := ensureAccount(t, store)
+
+	rm := new(mocks.RunManager)
+	fetcher := new(mocks.Fetcher)
+	fluxAggregator := new(mocks.FluxAggregator)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.ID = 1
+	initr.InitiatorParams.FlagsAddress = cltest.NewAddress()
+
+	paymentAmount := store.Config.MinimumContractPayment().ToInt()
+	roundState := contracts.FluxAggregatorRoundState{
+		ReportableRoundID: 2,
+		EligibleToSubmit:  true,
+		LatestAnswer:      big.NewInt(1),
+		AvailableFunds:    big.NewInt(1).Mul(paymentAmount, big.NewInt(1000)),
+		PaymentAmount:     paymentAmount,
+		OracleCount:       1,
+	}
+	fluxAggregator.On("RoundState", nodeAddr).Return(roundState, nil)
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store,
+		fluxAggregator, initr, rm, fetcher, models.MustMakeDuration(time.Second), func() {})
+	require.NoError(t, err)
+	checker.SetFlags(new(mocks.Flags))
+	checker.OnConnect()
+
+	logBroadcast := func(log interface{}) *mocks.LogBroadcast {
+		lb := new(mocks.LogBroadcast)
+		lb.On("Log").Return(log)
+		lb.On("WasAlreadyConsumed").Return(false, nil)
+		lb.On("MarkConsumed").Return(nil)
+		return lb
+	}
+
+	// Flagging another aggregator has no effect, but flagging ours stops
+	// submissions
+	checker.HandleLog(logBroadcast(&contracts.LogFlagRaised{Subject: cltest.NewAddress()}), nil)
+	checker.ExportedProcessLogs()
+	checker.HandleLog(logBroadcast(&contracts.LogFlagRaised{Subject: initr.InitiatorParams.Address}), nil)
+	checker.ExportedProcessLogs()
+	assert.False(t, checker.ExportedPollIfEligible(0.1))
+	fetcher.AssertNotCalled(t, "Fetch")
+	rm.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// Lowering another aggregator's flag leaves ours raised
+	checker.HandleLog(logBroadcast(&contracts.LogFlagLowered{Subject: cltest.NewAddress()}), nil)
+	checker.ExportedProcessLogs()
+	assert.False(t, checker.ExportedPollIfEligible(0.1))
+	fetcher.AssertNotCalled(t, "Fetch")
+
+	// Lowering ours resumes them
+	run := cltest.NewJobRun(job)
+	fetcher.On("Fetch").Return(decimal.NewFromInt(100), nil)
+	fluxAggregator.On("GetMethodID", "submit").Return(submitSelector, nil)
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil)
+
+	checker.HandleLog(logBroadcast(&contracts.LogFlagLowered{Subject: initr.InitiatorParams.Address}), nil)
+	checker.ExportedProcessLogs()
+	assert.True(t, checker.ExportedPollIfEligible(0.1))
+
+	fluxAggregator.AssertExpectations(t)
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+}
+
+func TestPollingDeviationChecker_SubmitsWithConfiguredMethod(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	nodeAddr := ensureAccount(t, store)
+
+	rm := new(mocks.RunManager)
+	fetcher := new(mocks.Fetcher)
+	fluxAggregator := new(mocks.FluxAggregator)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.ID = 1
+	initr.InitiatorParams.SubmissionMethod = "updateAnswer"
+
+	paymentAmount := store.Config.MinimumContractPayment().ToInt()
+	fluxAggregator.On("RoundState", nodeAddr).Return(contracts.FluxAggregatorRoundState{
+		ReportableRoundID: 1,
+		EligibleToSubmit:  true,
+		LatestAnswer:      big.NewInt(0),
+		AvailableFunds:    big.NewInt(1).Mul(paymentAmount, big.NewInt(1000)),
+		PaymentAmount:     paymentAmount,
+		OracleCount:       oracleCount,
+	}, nil)
+	updateAnswerSelector := utils.MustHash("updateAnswer(uint256,int256)").Bytes()[:4]
+	fluxAggregator.On("GetMethodID", "updateAnswer").Return(updateAnswerSelector, nil)
+	fetcher.On("Fetch").Return(decimal.NewFromInt(100), nil)
+
+	run := cltest.NewJobRun(job)
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.MatchedBy(func(runRequest *models.RunRequest) bool {
+		params := runRequest.RequestParams
+		return params.Get("functionSelector").String() == hexutil.Encode(updateAnswerSelector) &&
+			params.Get("dataPrefix").String() == hexutil.Encode(common.BigToHash(big.NewInt(1)).Bytes())
+	})).Return(&run, nil)
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store,
+		fluxAggregator, initr, rm, fetcher, models.MustMakeDuration(time.Second), func() {})
+	require.NoError(t, err)
+	checker.OnConnect()
+
+	assert.True(t, checker.ExportedPollIfEligible(0.1))
+	fluxAggregator.AssertNotCalled(t, "GetMethodID", "submit")
+
+	fluxAggregator.AssertExpectations(t)
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+}
+
+func TestPollingDeviationChecker_PollIfEligible_MinimumEthBalance(t *testing.T) {
+	tests := []struct {
+		name             string
+		balance          int64
+		expectedToSubmit bool
+	}{
+		{"balance below minimum", 999, false},
+		{"balance equal to minimum", 1000, true},
+		{"balance above minimum", 1001, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store, cleanup := cltest.NewStore(t)
+			defer cleanup()
+			store.Config.Set("FLUX_MONITOR_MINIMUM_ETH_BALANCE_WEI", "1000")
+
+			nodeAddr := ensureAccount(t, store)
+			txm := new(mocks.TxManager)
+			store.TxManager = txm
+			txm.On("BalanceAt", nodeAddr, (*big.Int)(nil)).Return(big.NewInt(test.balance), nil)
+
+			rm := new(mocks.RunManager)
+			fetcher := new(mocks.Fetcher)
+			fluxAggregator := new(mocks.FluxAggregator)
+
+			job := cltest.NewJobWithFluxMonitorInitiator()
+			initr := job.Initiators[0]
+			initr.ID = 1
+
+			paymentAmount := store.Config.MinimumContractPayment().ToInt()
+			roundState := contracts.FluxAggregatorRoundState{
+				ReportableRoundID: 2,
+				EligibleToSubmit:  true,
+				LatestAnswer:      big.NewInt(1),
+				AvailableFunds:    big.NewInt(1).Mul(paymentAmount, big.NewInt(1000)),
+				PaymentAmount:     paymentAmount,
+				OracleCount:       1,
+			}
+			fluxAggregator.On("RoundState", nodeAddr).Return(roundState, nil)
+
+			if test.expectedToSubmit {
+				run := cltest.NewJobRun(job)
+				fetcher.On("Fetch").Return(decimal.NewFromInt(100), nil)
+				fluxAggregator.On("GetMethodID", "submit").Return(submitSelector, nil)
+				rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil)
+			}
+
+			checker, err := fluxmonitor.NewPollingDeviationChecker(store,
+				fluxAggregator, initr, rm, fetcher, models.MustMakeDuration(time.Second), func() {})
+			require.NoError(t, err)
+			checker.OnConnect()
+
+			assert.Equal(t, test.expectedToSubmit, checker.ExportedPollIfEligible(0.1))
+
+			txm.AssertExpectations(t)
+			fluxAggregator.AssertExpectations(t)
+			fetcher.AssertExpectations(t)
+			rm.AssertExpectations(t)
+			if !test.expectedToSubmit {
+				fetcher.AssertNotCalled(t, "Fetch")
+				rm.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestPollingDeviationChecker_SufficientFunds(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	checker := cltest.NewPollingDeviationChecker(t, store)
+
+	payment := 100
+	rounds := 3
+	oracleCount := 21
+	min := payment * rounds * oracleCount
+
+	tests := []struct {
+		name  string
+		funds int
+		want  bool
+	}{
+		{"above minimum", min + 1, true},
+		{"equal to minimum", min, true},
+		{"below minimum", min - 1, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			state := contracts.FluxAggregatorRoundState{
+				AvailableFunds: big.NewInt(int64(test.funds)),
+				PaymentAmount:  big.NewInt(int64(payment)),
+				OracleCount:    uint32(oracleCount),
+			}
+			assert.Equal(t, test.want, checker.SufficientFunds(state))
+		})
+	}
+}
+
+func TestMultiDeviationChecker_SharesFetchedValueAcrossAggregators(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	nodeAddr := ensureAccount(t, store)
+
+	const (
+		reportableRoundID = 2
+		polledAnswer      = 100
+	)
+	paymentAmount := store.Config.MinimumContractPayment().ToInt()
+	roundState := contracts.FluxAggregatorRoundState{
+		ReportableRoundID: reportableRoundID,
+		EligibleToSubmit:  true,
+		LatestAnswer:      big.NewInt(1),
+		AvailableFunds:    big.NewInt(1).Mul(paymentAmount, big.NewInt(1000)),
+		PaymentAmount:     paymentAmount,
+		OracleCount:       oracleCount,
+	}
+
+	matchRunRequestForAddress := func(address common.Address) interface{} {
+		return mock.MatchedBy(func(runRequest *models.RunRequest) bool {
+			return runRequest.RequestParams.Get("address").String() == address.Hex()
+		})
+	}
+
+	tests := []struct {
+		name            string
+		secondReverts   bool
+		expectedSubmits int
+	}{
+		{"both aggregators eligible", false, 2},
+		{"second aggregator reverts", true, 1},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			job := cltest.NewJobWithFluxMonitorInitiator()
+			initr := job.Initiators[0]
+			initr.ID = 1
+			addr1 := initr.InitiatorParams.Address
+			addr2 := cltest.NewAddress()
+			initr.InitiatorParams.Addresses = models.AddressCollection{addr2}
+
+			fluxAggregators := map[common.Address]*mocks.FluxAggregator{
+				addr1: new(mocks.FluxAggregator),
+				addr2: new(mocks.FluxAggregator),
+			}
+			fluxAggregators[addr1].On("RoundState", nodeAddr).Return(roundState, nil)
+			fluxAggregators[addr1].On("GetMethodID", "submit").Return(submitSelector, nil)
+			if test.secondReverts {
+				fluxAggregators[addr2].On("RoundState", nodeAddr).
+					Return(contracts.FluxAggregatorRoundState{}, errors.New("execution reverted"))
+			} else {
+				fluxAggregators[addr2].On("RoundState", nodeAddr).Return(roundState, nil)
+				fluxAggregators[addr2].On("GetMethodID", "submit").Return(submitSelector, nil)
+			}
+
+			fetcher := new(mocks.Fetcher)
+			fetcher.On("Fetch").Return(decimal.NewFromInt(polledAnswer), nil).Once()
+
+			rm := new(mocks.RunManager)
+			run := cltest.NewJobRun(job)
+			rm.On("Create", job.ID, mock.Anything, mock.Anything, matchRunRequestForAddress(addr1)).
+				Return(&run, nil).Once()
+			if !test.secondReverts {
+				rm.On("Create", job.ID, mock.Anything, mock.Anything, matchRunRequestForAddress(addr2)).
+					Return(&run, nil).Once()
+			}
+
+			multiChecker, err := fluxmonitor.NewMultiDeviationChecker(
+				store,
+				func(address common.Address) (contracts.FluxAggregator, error) {
+					return fluxAggregators[address], nil
+				},
+				initr,
+				rm,
+				fetcher,
+				models.MustMakeDuration(time.Second),
+				func() {},
+			)
+			require.NoError(t, err)
+
+			checkers := multiChecker.ExportedCheckers()
+			require.Len(t, checkers, 2)
+
+			var submits int
+			for _, checker := range checkers {
+				checker.OnConnect()
+				if checker.ExportedPollIfEligible(0.1) {
+					submits++
+				}
+			}
+			assert.Equal(t, test.expectedSubmits, submits)
+
+			fetcher.AssertExpectations(t)
+			rm.AssertExpectations(t)
+			for _, fluxAggregator := range fluxAggregators {
+				fluxAggregator.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestCheckOracleAuthorized(t *testing.T) {
+	nodeAddr := cltest.NewAddress()
+
+	tests := []struct {
+		name        string
+		oracles     []common.Address
+		oraclesErr  error
+		wantErr     bool
+		wantUnauthz bool
+	}{
+		{"authorized", []common.Address{cltest.NewAddress(), nodeAddr}, nil, false, false},
+		{"unauthorized", []common.Address{cltest.NewAddress(), cltest.NewAddress()}, nil, true, true},
+		{"no oracles", []common.Address{}, nil, true, true},
+		{"call fails", nil, errors.New("execution reverted"), true, false},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			fluxAggregator := new(mocks.FluxAggregator)
+			fluxAggregator.On("GetOracles").Return(test.oracles, test.oraclesErr)
+
+			err := fluxmonitor.ExportedCheckOracleAuthorized(fluxAggregator, nodeAddr)
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.wantUnauthz, errors.Cause(err) == fluxmonitor.ErrOracleNotAuthorized)
+
+			fluxAggregator.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPollingDeviationCheckerFactory_RefusesUnauthorizedOracle(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	ensureAccount(t, store)
+
+	ethMock := cltest.MockEthOnStore(t, store)
+	ethMock.Register("eth_call", cltest.MakeGetOraclesReturnData(cltest.NewAddress()))
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.InitiatorParams.Feeds = cltest.JSONFromString(t, `["https://example.com"]`)
+
+	logBroadcaster := new(mocks.LogBroadcaster)
+	factory := fluxmonitor.ExportedNewCheckerFactory(store, logBroadcaster)
+
+	_, err := factory.New(initr, new(mocks.RunManager), store.ORM, store.Config.DefaultHTTPTimeout())
+	require.Error(t, err)
+	assert.Equal(t, fluxmonitor.ErrOracleNotAuthorized, errors.Cause(err))
+
+	logBroadcaster.AssertNotCalled(t, "AddDependents", mock.Anything)
+	ethMock.AssertAllCalled()
+}
+
+func TestPollingDeviationCheckerFactory_StartsJobWhenOraclesUnreadable(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	ensureAccount(t, store)
+
+	ethMock := cltest.MockEthOnStore(t, store)
+	ethMock.RegisterError("eth_call", "connection refused")
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.InitiatorParams.Feeds = cltest.JSONFromString(t, `["https://example.com"]`)
+
+	logBroadcaster := new(mocks.LogBroadcaster)
+	logBroadcaster.On("AddDependents", 1)
+	factory := fluxmonitor.ExportedNewCheckerFactory(store, logBroadcaster)
+
+	// A failure to read the aggregator's oracles doesn't refuse the job
+	checker, err := factory.New(initr, new(mocks.RunManager), store.ORM, store.Config.DefaultHTTPTimeout())
+	require.NoError(t, err)
+	assert.NotNil(t, checker)
+
+	logBroadcaster.AssertExpectations(t)
+	ethMock.AssertAllCalled()
+}
+
+func TestPollingDeviationCheckerFactory_RefusesJobWithAnyUnauthorizedAggregator(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	nodeAddr := ensureAccount(t, store)
+
+	// The node is authorized on the first aggregator, but not the second
+	ethMock := cltest.MockEthOnStore(t, store)
+	ethMock.Register("eth_call", cltest.MakeGetOraclesReturnData(nodeAddr))
+	ethMock.Register("eth_call", cltest.MakeGetOraclesReturnData(cltest.NewAddress()))
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.InitiatorParams.Feeds = cltest.JSONFromString(t, `["https://example.com"]`)
+	initr.InitiatorParams.Addresses = models.AddressCollection{cltest.NewAddress()}
+
+	logBroadcaster := new(mocks.LogBroadcaster)
+	factory := fluxmonitor.ExportedNewCheckerFactory(store, logBroadcaster)
+
+	_, err := factory.New(initr, new(mocks.RunManager), store.ORM, store.Config.DefaultHTTPTimeout())
+	require.Error(t, err)
+	assert.Equal(t, fluxmonitor.ErrOracleNotAuthorized, errors.Cause(err))
+
+	// No aggregator is left counted as a dependent which will never be ready
+	logBroadcaster.AssertNotCalled(t, "AddDependents", mock.Anything)
+	ethMock.AssertAllCalled()
+}
+
+func TestPollingDeviationChecker_MetricsReflectSubmission(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	nodeAddr := ensureAccount(t, store)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.ID = 1
+	precision := initr.InitiatorParams.Precision
+
+	paymentAmount := store.Config.MinimumContractPayment().ToInt()
+	roundState := contracts.FluxAggregatorRoundState{
+		ReportableRoundID: 2,
+		EligibleToSubmit:  true,
+		LatestAnswer:      big.NewInt(int64(math.Pow10(int(precision)))),
+		AvailableFunds:    big.NewInt(1).Mul(paymentAmount, big.NewInt(1000)),
+		PaymentAmount:     paymentAmount,
+		OracleCount:       oracleCount,
+	}
+	fluxAggregator := new(mocks.FluxAggregator)
+	fluxAggregator.On("RoundState", nodeAddr).Return(roundState, nil)
+	fluxAggregator.On("GetMethodID", "submit").Return(submitSelector, nil)
+
+	fetcher := new(mocks.Fetcher)
+	fetcher.On("Fetch").Return(decimal.NewFromInt(100), nil)
+
+	rm := new(mocks.RunManager)
+	run := cltest.NewJobRun(job)
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil)
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store,
+		fluxAggregator, initr, rm, fetcher, models.MustMakeDuration(time.Second), func() {})
+	require.NoError(t, err)
+	require.True(t, checker.Metrics().LastSubmittedAt.IsZero())
+
+	checker.OnConnect()
+	beforeSubmission := time.Now()
+	require.True(t, checker.ExportedPollIfEligible(0.1))
+
+	metrics := checker.Metrics()
+	assert.False(t, metrics.LastSubmittedAt.Before(beforeSubmission))
+	assert.True(t, decimal.NewFromInt(100).Equal(metrics.LastSubmittedAnswer))
+	assert.True(t, decimal.NewFromInt(1).Equal(metrics.LatestAnswer))
+
+	fluxAggregator.AssertExpectations(t)
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+}
+
+// recordingInstrumenter records the submission activity it's notified of
+type recordingInstrumenter struct {
+	mutex            sync.Mutex
+	submissions      []string
+	reportableRounds []int64
+	sinceSubmission  int
+	jobIDs           map[models.ID]struct{}
+}
+
+func (i *recordingInstrumenter) record(jobID *models.ID, event string) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if i.jobIDs == nil {
+		i.jobIDs = make(map[models.ID]struct{})
+	}
+	i.jobIDs[*jobID] = struct{}{}
+	if event != "" {
+		i.submissions = append(i.submissions, event)
+	}
+}
+
+func (i *recordingInstrumenter) SubmissionAttempted(jobID *models.ID, roundID *big.Int) {
+	i.record(jobID, fmt.Sprintf("attempted %v", roundID))
+}
+func (i *recordingInstrumenter) SubmissionSucceeded(jobID *models.ID, roundID *big.Int) {
+	i.record(jobID, fmt.Sprintf("succeeded %v", roundID))
+}
+func (i *recordingInstrumenter) SubmissionReverted(jobID *models.ID, roundID *big.Int) {
+	i.record(jobID, fmt.Sprintf("reverted %v", roundID))
+}
+func (i *recordingInstrumenter) ReportableRound(jobID *models.ID, roundID *big.Int) {
+	i.record(jobID, "")
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.reportableRounds = append(i.reportableRounds, roundID.Int64())
+}
+func (i *recordingInstrumenter) TimeSinceLastSubmission(jobID *models.ID, elapsed time.Duration) {
+	i.record(jobID, "")
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.sinceSubmission++
+}
+
+func TestPollingDeviationChecker_InstrumentsSubmissions(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	nodeAddr := ensureAccount(t, store)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.ID = 1
+	precision := initr.InitiatorParams.Precision
+
+	paymentAmount := store.Config.MinimumContractPayment().ToInt()
+	roundState := func(roundID uint32, latestAnswer int64) contracts.FluxAggregatorRoundState {
+		return contracts.FluxAggregatorRoundState{
+			ReportableRoundID: roundID,
+			EligibleToSubmit:  true,
+			LatestAnswer:      big.NewInt(latestAnswer * int64(math.Pow10(int(precision)))),
+			AvailableFunds:    big.NewInt(1).Mul(paymentAmount, big.NewInt(1000)),
+			PaymentAmount:     paymentAmount,
+			OracleCount:       oracleCount,
+		}
+	}
+	fluxAggregator := new(mocks.FluxAggregator)
+	fluxAggregator.On("RoundState", nodeAddr).Return(roundState(2, 1), nil).Once()
+	fluxAggregator.On("RoundState", nodeAddr).Return(roundState(3, 1), nil).Once()
+	// The round moves on to 4 without our submission to 3, and the answer
+	// hasn't deviated since
+	fluxAggregator.On("RoundState", nodeAddr).Return(roundState(4, 100), nil).Once()
+	fluxAggregator.On("GetMethodID", "submit").Return(submitSelector, nil)
+
+	fetcher := new(mocks.Fetcher)
+	fetcher.On("Fetch").Return(decimal.NewFromInt(100), nil)
+
+	rm := new(mocks.RunManager)
+	run := cltest.NewJobRun(job)
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil).Twice()
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store,
+		fluxAggregator, initr, rm, fetcher, models.MustMakeDuration(time.Second), func() {})
+	require.NoError(t, err)
+	instrumenter := new(recordingInstrumenter)
+	checker.SetInstrumenter(instrumenter)
+	checker.OnConnect()
+
+	logBroadcast := func(log interface{}) *mocks.LogBroadcast {
+		lb := new(mocks.LogBroadcast)
+		lb.On("Log").Return(log)
+		lb.On("WasAlreadyConsumed").Return(false, nil)
+		lb.On("MarkConsumed").Return(nil)
+		return lb
+	}
+
+	require.True(t, checker.ExportedPollIfEligible(0.1))
+	// Another oracle's submission for the round doesn't count as ours
+	checker.HandleLog(logBroadcast(&contracts.LogSubmissionReceived{Round: 2, Oracle: cltest.NewAddress()}), nil)
+	checker.ExportedProcessLogs()
+	checker.HandleLog(logBroadcast(&contracts.LogSubmissionReceived{Round: 2, Oracle: nodeAddr}), nil)
+	checker.ExportedProcessLogs()
+
+	require.True(t, checker.ExportedPollIfEligible(0.1))
+	require.False(t, checker.ExportedPollIfEligible(0.1))
+	checker.HandleLog(logBroadcast(&contracts.LogAnswerUpdated{RoundId: big.NewInt(4), Current: big.NewInt(100)}), nil)
+	checker.ExportedProcessLogs()
+
+	assert.Equal(t, []string{"attempted 2", "succeeded 2", "attempted 3", "reverted 3"}, instrumenter.submissions)
+	assert.Equal(t, []int64{2, 3, 4}, instrumenter.reportableRounds)
+	assert.Equal(t, 2, instrumenter.sinceSubmission)
+	assert.Equal(t, map[models.ID]struct{}{*job.ID: {}}, instrumenter.jobIDs)
+
+	fluxAggregator.AssertExpectations(t)
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+}
+
+func TestConcreteFluxMonitor_JobMetrics(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	txm := new(mocks.TxManager)
+	store.TxManager = txm
+	txm.On("GetLatestBlock").Return(eth.Block{Number: hexutil.Uint64(123)}, nil)
+	txm.On("GetLogs", mock.Anything).Return([]eth.Log{}, nil)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	runManager := new(mocks.RunManager)
+
+	expected := fluxmonitor.FluxMonitorJobMetrics{
+		LastSubmittedAt:     time.Now(),
+		LastSubmittedAnswer: decimal.NewFromInt(100),
+		LatestAnswer:        decimal.NewFromInt(99),
+		NextPollAt:          time.Now().Add(time.Minute),
+	}
+	started := make(chan struct{}, 1)
+	dc := new(mocks.DeviationChecker)
+	dc.On("Start").Return().Run(func(mock.Arguments) { started <- struct{}{} })
+	dc.On("Stop").Return()
+	dc.On("Metrics").Return(expected)
+
+	checkerFactory := new(mocks.DeviationCheckerFactory)
+	checkerFactory.On("New", job.Initiators[0], runManager, store.ORM, store.Config.DefaultHTTPTimeout()).Return(dc, nil)
+	fm := fluxmonitor.New(store, runManager)
+	fluxmonitor.ExportedSetCheckerFactory(fm, checkerFactory)
+	require.NoError(t, fm.Start())
+	defer fm.Stop()
+
+	_, ok := fm.JobMetrics(*job.ID)
+	assert.False(t, ok)
+
+	require.NoError(t, fm.AddJob(job))
+	cltest.CallbackOrTimeout(t, "deviation checker started", func() {
+		<-started
+	})
+
+	metrics, ok := fm.JobMetrics(*job.ID)
+	require.True(t, ok)
+	assert.Equal(t, expected, metrics)
+}
+
+// concurrencyRecordingRunManager records the largest number of concurrent
+// calls to Create, each of which blocks until it's released
+type concurrencyRecordingRunManager struct {
+	mutex       sync.Mutex
+	inFlight    int
+	maxInFlight int
+	chStarted   chan struct{}
+	chRelease   chan struct{}
+}
+
+func (rm *concurrencyRecordingRunManager) Create(
+	jobSpecID *models.ID,
+	initiator *models.Initiator,
+	creationHeight *big.Int,
+	runRequest *models.RunRequest,
+) (*models.JobRun, error) {
+	rm.mutex.Lock()
+	rm.inFlight++
+	if rm.inFlight > rm.maxInFlight {
+		rm.maxInFlight = rm.inFlight
+	}
+	rm.mutex.Unlock()
+
+	rm.chStarted <- struct{}{}
+	<-rm.chRelease
+
+	rm.mutex.Lock()
+	rm.inFlight--
+	rm.mutex.Unlock()
+	return &models.JobRun{}, nil
+}
+
+func TestLimitedRunManager_CapsConcurrentSubmissions(t *testing.T) {
+	const maxConcurrent, submissions = 3, 20
+
+	recorder := &concurrencyRecordingRunManager{
+		chStarted: make(chan struct{}, submissions),
+		chRelease: make(chan struct{}),
+	}
+	runManager := fluxmonitor.ExportedNewLimitedRunManager(recorder, maxConcurrent)
+
+	var wg sync.WaitGroup
+	for i := 0; i < submissions; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := runManager.Create(models.NewID(), &models.Initiator{}, nil, nil)
+			assert.NoError(t, err)
+		}()
+	}
+
+	// Only maxConcurrent submissions get through until one is released
+	for i := 0; i < maxConcurrent; i++ {
+		<-recorder.chStarted
+	}
+	select {
+	case <-recorder.chStarted:
+		t.Fatal("more than the maximum number of submissions started")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	go func() {
+		for i := 0; i < submissions; i++ {
+			recorder.chRelease <- struct{}{}
+		}
+	}()
+	wg.Wait()
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	assert.Equal(t, maxConcurrent, recorder.maxInFlight)
+	assert.Equal(t, 0, recorder.inFlight)
+}
+
+func TestFluxMonitor_StopWaitsForInFlightSubmissions(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	startSubmission := func(t *testing.T, timeout string) (fluxmonitor.Service, fluxmonitor.RunManager, *concurrencyRecordingRunManager) {
+		store.Config.Set("FLUX_MONITOR_SHUTDOWN_TIMEOUT", timeout)
+		recorder := &concurrencyRecordingRunManager{
+			chStarted: make(chan struct{}, 1),
+			chRelease: make(chan struct{}),
+		}
+		fm := fluxmonitor.New(store, recorder)
+		require.NoError(t, fm.Start())
+		runManager := fluxmonitor.ExportedTrackedRunManager(fm, recorder)
+		go func() {
+			_, err := runManager.Create(models.NewID(), &models.Initiator{}, nil, nil)
+			assert.NoError(t, err)
+		}()
+		<-recorder.chStarted
+		return fm, runManager, recorder
+	}
+
+	t.Run("blocks until the submission completes", func(t *testing.T) {
+		fm, runManager, recorder := startSubmission(t, "10s")
+
+		stopped := make(chan struct{})
+		go func() {
+			fm.Stop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+			t.Fatal("Stop returned while a submission was in flight")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		recorder.chRelease <- struct{}{}
+		cltest.CallbackOrTimeout(t, "flux monitor stopped", func() {
+			<-stopped
+		})
+
+		_, err := runManager.Create(models.NewID(), &models.Initiator{}, nil, nil)
+		assert.Equal(t, fluxmonitor.ErrFluxMonitorStopping, err)
+	})
+
+	t.Run("gives up after the shutdown timeout", func(t *testing.T) {
+		const timeout = 200 * time.Millisecond
+		fm, _, recorder := startSubmission(t, timeout.String())
+		defer func() { recorder.chRelease <- struct{}{} }()
+
+		start := time.Now()
+		cltest.CallbackOrTimeout(t, "flux monitor stopped", func() {
+			fm.Stop()
+		})
+		assert.True(t, time.Since(start) >= timeout)
+	})
+}
+
+func TestLimitedRunManager_ZeroIsUnlimited(t *testing.T) {
+	recorder := &concurrencyRecordingRunManager{}
+	assert.Equal(t, fluxmonitor.RunManager(recorder), fluxmonitor.ExportedNewLimitedRunManager(recorder, 0))
+}
@@ -8,12 +8,15 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
+	"github.com/smartcontractkit/chainlink/core/store"
 )
 
 func ExportedSetCheckerFactory(fm Service, fac DeviationCheckerFactory) {
@@ -22,7 +25,7 @@ func ExportedSetCheckerFactory(fm Service, fac DeviationCheckerFactory) {
 }
 
 func (p *PollingDeviationChecker) ExportedPollIfEligible(threshold float64) bool {
-	return p.pollIfEligible(threshold)
+	return p.pollIfEligible(DeviationThresholds{Relative: threshold})
 }
 
 func (p *PollingDeviationChecker) ExportedSetStoredReportableRoundID(roundID *big.Int) {
@@ -33,6 +36,18 @@ func (p *PollingDeviationChecker) ExportedRespondToNewRoundLog(log *contracts.Lo
 	p.respondToNewRoundLog(log)
 }
 
+func (p *PollingDeviationChecker) ExportedRespondToOraclePermissionsUpdatedLog(log *contracts.LogOraclePermissionsUpdated) {
+	p.respondToOraclePermissionsUpdatedLog(log)
+}
+
+func (p *PollingDeviationChecker) ExportedProcessLogs() {
+	p.processLogs()
+}
+
+func (p *PollingDeviationChecker) ExportedSeedLatestAnswer() {
+	p.seedLatestAnswer()
+}
+
 func mustReadFile(t testing.TB, file string) string {
 	t.Helper()
 
@@ -85,3 +100,23 @@ func dataWithResult(t *testing.T, result decimal.Decimal) adapterResponseData {
 	require.NoError(t, json.Unmarshal(body, &data))
 	return data
 }
+
+func (m *MultiDeviationChecker) ExportedCheckers() []*PollingDeviationChecker {
+	return m.checkers
+}
+
+func ExportedCheckOracleAuthorized(fluxAggregator contracts.FluxAggregator, oracle common.Address) error {
+	return checkOracleAuthorized(fluxAggregator, oracle)
+}
+
+func ExportedNewLimitedRunManager(runManager RunManager, maxConcurrent uint32) RunManager {
+	return newLimitedRunManager(runManager, maxConcurrent)
+}
+
+func ExportedNewCheckerFactory(store *store.Store, logBroadcaster eth.LogBroadcaster) DeviationCheckerFactory {
+	return pollingDeviationCheckerFactory{store: store, logBroadcaster: logBroadcaster}
+}
+
+func ExportedTrackedRunManager(fm Service, runManager RunManager) RunManager {
+	return trackedRunManager{runManager, fm.(*concreteFluxMonitor).submissions}
+}
@@ -0,0 +1,43 @@
+package fluxmonitor
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// Instrumenter is notified of the submission activity of each flux monitor
+// job, so that operators can export it, e.g. as prometheus metrics.  Its
+// methods are called from the goroutines of every job's checkers, so must be
+// safe for concurrent use, and should return promptly.
+type Instrumenter interface {
+	// SubmissionAttempted is called before a job run is created to submit an
+	// answer to roundID
+	SubmissionAttempted(jobID *models.ID, roundID *big.Int)
+	// SubmissionSucceeded is called when the aggregator records the node's
+	// submission to roundID
+	SubmissionSucceeded(jobID *models.ID, roundID *big.Int)
+	// SubmissionReverted is called when the aggregator has moved on past
+	// roundID without recording the node's submission to it, e.g. because its
+	// transaction reverted
+	SubmissionReverted(jobID *models.ID, roundID *big.Int)
+	// ReportableRound is called with the round the node can currently submit
+	// to, each time it's read from the aggregator
+	ReportableRound(jobID *models.ID, roundID *big.Int)
+	// TimeSinceLastSubmission is called each time a job polls, with the time
+	// since it last created a job run to submit an answer.  It isn't called
+	// before the first submission.
+	TimeSinceLastSubmission(jobID *models.ID, elapsed time.Duration)
+}
+
+// NoopInstrumenter ignores all flux monitor activity
+var NoopInstrumenter Instrumenter = noopInstrumenter{}
+
+type noopInstrumenter struct{}
+
+func (noopInstrumenter) SubmissionAttempted(*models.ID, *big.Int)          {}
+func (noopInstrumenter) SubmissionSucceeded(*models.ID, *big.Int)          {}
+func (noopInstrumenter) SubmissionReverted(*models.ID, *big.Int)           {}
+func (noopInstrumenter) ReportableRound(*models.ID, *big.Int)              {}
+func (noopInstrumenter) TimeSinceLastSubmission(*models.ID, time.Duration) {}
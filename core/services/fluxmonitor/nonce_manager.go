@@ -0,0 +1,123 @@
+package fluxmonitor
+
+import (
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockery -name NonceManager -output ../../internal/mocks/ -case=underscore
+
+// NonceManager allocates the nonces of the transactions sent from each key, so
+// that submissions sent directly with an eth.Client, rather than through a job
+// run, don't collide.  Its methods must be safe for concurrent use.
+type NonceManager interface {
+	// NextNonce reserves and returns the nonce for the next transaction from
+	// address
+	NextNonce(address common.Address) (uint64, error)
+	// Resync discards the nonces reserved for address, so that they're
+	// allocated again from the node's pending transaction count
+	Resync(address common.Address) error
+}
+
+// nonceResyncLimit is the number of times SendTransaction resyncs its nonce
+// and retries a transaction rejected for having a stale one
+const nonceResyncLimit = 3
+
+type sequentialNonceManager struct {
+	client eth.Client
+	mutex  sync.Mutex
+	nonces map[common.Address]uint64 // the next nonce to allocate, by key
+}
+
+// NewNonceManager returns a NonceManager which allocates each key's nonces
+// sequentially, starting from its pending transaction count on the node
+// client is connected to
+func NewNonceManager(client eth.Client) NonceManager {
+	return &sequentialNonceManager{
+		client: client,
+		nonces: make(map[common.Address]uint64),
+	}
+}
+
+func (m *sequentialNonceManager) NextNonce(address common.Address) (uint64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	nonce, ok := m.nonces[address]
+	if !ok {
+		var err error
+		nonce, err = m.client.GetNonce(address)
+		if err != nil {
+			return 0, errors.Wrapf(err, "while fetching pending nonce of %s", address.Hex())
+		}
+	}
+	m.nonces[address] = nonce + 1
+	return nonce, nil
+}
+
+func (m *sequentialNonceManager) Resync(address common.Address) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	nonce, err := m.client.GetNonce(address)
+	if err != nil {
+		delete(m.nonces, address)
+		return errors.Wrapf(err, "while fetching pending nonce of %s", address.Hex())
+	}
+	m.nonces[address] = nonce
+	return nil
+}
+
+// SendTransaction signs a transaction from address with the next nonce nonces
+// allocates to it, and sends it with client.  If the node rejects it because
+// the nonce is too low, nonces is resynced and the transaction signed and sent
+// again, up to nonceResyncLimit times.  Any other failure also resyncs nonces,
+// since the nonce reserved for the transaction was never used.
+func SendTransaction(
+	client eth.Client,
+	nonces NonceManager,
+	address common.Address,
+	sign func(nonce uint64) (*types.Transaction, error),
+) (common.Hash, error) {
+	for attempt := 0; ; attempt++ {
+		nonce, err := nonces.NextNonce(address)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		tx, err := sign(nonce)
+		if err != nil {
+			resyncNonce(nonces, address)
+			return common.Hash{}, errors.Wrap(err, "while signing transaction")
+		}
+		hash, err := client.SendRawTransaction(tx)
+		if err == nil {
+			return hash, nil
+		}
+		if !store.IsNonceTooLowError(err) || attempt >= nonceResyncLimit {
+			resyncNonce(nonces, address)
+			return common.Hash{}, errors.Wrapf(err, "while sending transaction with nonce %d", nonce)
+		}
+		logger.Warnw("Transaction nonce too low, resyncing nonce and retrying",
+			"address", address.Hex(),
+			"nonce", nonce,
+			"error", err,
+		)
+		if err := nonces.Resync(address); err != nil {
+			return common.Hash{}, err
+		}
+	}
+}
+
+func resyncNonce(nonces NonceManager, address common.Address) {
+	if err := nonces.Resync(address); err != nil {
+		logger.Errorw("Unable to resync transaction nonce",
+			"address", address.Hex(),
+			"error", err,
+		)
+	}
+}
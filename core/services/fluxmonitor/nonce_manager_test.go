@@ -0,0 +1,153 @@
+package fluxmonitor_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceManager_NextNonce_AllocatesSequentiallyPerKey(t *testing.T) {
+	t.Parallel()
+
+	ethClient := new(mocks.Client)
+	key1, key2 := cltest.NewAddress(), cltest.NewAddress()
+	ethClient.On("GetNonce", key1).Return(uint64(5), nil).Once()
+	ethClient.On("GetNonce", key2).Return(uint64(0), nil).Once()
+
+	nonces := fluxmonitor.NewNonceManager(ethClient)
+	for _, want := range []uint64{5, 6, 7} {
+		nonce, err := nonces.NextNonce(key1)
+		require.NoError(t, err)
+		assert.Equal(t, want, nonce)
+	}
+	nonce, err := nonces.NextNonce(key2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), nonce)
+	nonce, err = nonces.NextNonce(key1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), nonce)
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestNonceManager_NextNonce_RetriesFailedFetch(t *testing.T) {
+	t.Parallel()
+
+	ethClient := new(mocks.Client)
+	key := cltest.NewAddress()
+	ethClient.On("GetNonce", key).Return(uint64(0), errors.New("connection refused")).Once()
+	ethClient.On("GetNonce", key).Return(uint64(3), nil).Once()
+
+	nonces := fluxmonitor.NewNonceManager(ethClient)
+	_, err := nonces.NextNonce(key)
+	require.Error(t, err)
+	nonce, err := nonces.NextNonce(key)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), nonce)
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestSendTransaction_SendsWithAllocatedNonce(t *testing.T) {
+	t.Parallel()
+
+	ethClient := new(mocks.Client)
+	key, hash := cltest.NewAddress(), cltest.NewHash()
+	ethClient.On("GetNonce", key).Return(uint64(2), nil).Once()
+	ethClient.On("SendRawTransaction", mock.Anything).Return(hash, nil).Twice()
+
+	var signed []uint64
+	sign := func(nonce uint64) (*types.Transaction, error) {
+		signed = append(signed, nonce)
+		return types.NewTransaction(nonce, cltest.NewAddress(), big.NewInt(0), 21000, big.NewInt(1), nil), nil
+	}
+	nonces := fluxmonitor.NewNonceManager(ethClient)
+	for i := 0; i < 2; i++ {
+		sent, err := fluxmonitor.SendTransaction(ethClient, nonces, key, sign)
+		require.NoError(t, err)
+		assert.Equal(t, hash, sent)
+	}
+	assert.Equal(t, []uint64{2, 3}, signed)
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestSendTransaction_RecoversFromStaleNonce(t *testing.T) {
+	t.Parallel()
+
+	ethClient := new(mocks.Client)
+	key, hash := cltest.NewAddress(), cltest.NewHash()
+	ethClient.On("GetNonce", key).Return(uint64(2), nil).Once()
+	// Another client sent transactions from key, so the node is ahead of us
+	ethClient.On("GetNonce", key).Return(uint64(9), nil).Once()
+	withNonce := func(nonce uint64) interface{} {
+		return mock.MatchedBy(func(tx *types.Transaction) bool { return tx.Nonce() == nonce })
+	}
+	ethClient.On("SendRawTransaction", withNonce(2)).Return(common.Hash{}, errors.New("nonce too low")).Once()
+	ethClient.On("SendRawTransaction", withNonce(9)).Return(hash, nil).Once()
+	ethClient.On("SendRawTransaction", withNonce(10)).Return(hash, nil).Once()
+
+	sign := func(nonce uint64) (*types.Transaction, error) {
+		return types.NewTransaction(nonce, cltest.NewAddress(), big.NewInt(0), 21000, big.NewInt(1), nil), nil
+	}
+	nonces := fluxmonitor.NewNonceManager(ethClient)
+	sent, err := fluxmonitor.SendTransaction(ethClient, nonces, key, sign)
+	require.NoError(t, err)
+	assert.Equal(t, hash, sent)
+	// The resynced nonce carries on from the node's
+	_, err = fluxmonitor.SendTransaction(ethClient, nonces, key, sign)
+	require.NoError(t, err)
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestSendTransaction_GivesUpAfterResyncLimit(t *testing.T) {
+	t.Parallel()
+
+	ethClient := new(mocks.Client)
+	key := cltest.NewAddress()
+	ethClient.On("GetNonce", key).Return(uint64(2), nil)
+	ethClient.On("SendRawTransaction", mock.Anything).Return(common.Hash{}, errors.New("nonce too low"))
+
+	sign := func(nonce uint64) (*types.Transaction, error) {
+		return types.NewTransaction(nonce, cltest.NewAddress(), big.NewInt(0), 21000, big.NewInt(1), nil), nil
+	}
+	_, err := fluxmonitor.SendTransaction(ethClient, fluxmonitor.NewNonceManager(ethClient), key, sign)
+	require.Error(t, err)
+	ethClient.AssertNumberOfCalls(t, "SendRawTransaction", 4)
+}
+
+func TestSendTransaction_ResyncsAfterOtherFailures(t *testing.T) {
+	t.Parallel()
+
+	ethClient := new(mocks.Client)
+	key, hash := cltest.NewAddress(), cltest.NewHash()
+	ethClient.On("GetNonce", key).Return(uint64(2), nil).Twice()
+	ethClient.On("SendRawTransaction", mock.Anything).Return(common.Hash{}, errors.New("insufficient funds")).Once()
+	ethClient.On("SendRawTransaction", mock.Anything).Return(hash, nil).Once()
+
+	var signed []uint64
+	sign := func(nonce uint64) (*types.Transaction, error) {
+		signed = append(signed, nonce)
+		return types.NewTransaction(nonce, cltest.NewAddress(), big.NewInt(0), 21000, big.NewInt(1), nil), nil
+	}
+	nonces := fluxmonitor.NewNonceManager(ethClient)
+	_, err := fluxmonitor.SendTransaction(ethClient, nonces, key, sign)
+	require.Error(t, err)
+	_, err = fluxmonitor.SendTransaction(ethClient, nonces, key, sign)
+	require.NoError(t, err)
+	// The failed transaction's nonce wasn't used, so isn't skipped
+	assert.Equal(t, []uint64{2, 2}, signed)
+
+	ethClient.AssertExpectations(t)
+}
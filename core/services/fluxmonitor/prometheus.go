@@ -46,6 +46,13 @@ var (
 		},
 		[]string{"job_spec_id"},
 	)
+	promFMJobsHalted = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flux_monitor_jobs_halted",
+			Help: "Number of times flux monitor's circuit breaker halted a job",
+		},
+		[]string{"job_spec_id"},
+	)
 	promFMResponseTime = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "flux_monitor_request_duration_seconds",
@@ -18,6 +18,7 @@ import (
 	"io"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/util/key"
 	"golang.org/x/crypto/sha3"
@@ -312,20 +313,75 @@ func Coordinates(p kyber.Point) (*big.Int, *big.Int) {
 	return p.(*secp256k1Point).X.int(), p.(*secp256k1Point).Y.int()
 }
 
+// IsIdentity returns true iff p is the group identity, i.e. the point at
+// infinity, which is represented as (0,0)
+func IsIdentity(p kyber.Point) bool {
+	return p.Equal(newPoint().Null())
+}
+
 var halfQ = big.NewInt(0).Add(big.NewInt(0).Rsh(GroupOrder, 1),
 	big.NewInt(1)) // Half secp256k1 group order + 1
 
 // ValidPublicKey returns true iff p can be used in the optimized on-chain
 // Schnorr-signature verification. See SchnorrSECP256K1.sol for details.
+//
+// The identity (point at infinity) is never a valid public key.
 func ValidPublicKey(p kyber.Point) bool {
 	if p == (*secp256k1Point)(nil) || p == nil {
 		return false
 	}
 	P := p.(*secp256k1Point)
+	if IsIdentity(P) {
+		return false
+	}
 	maybeY := maybeSqrtInField(rightHandSide(P.X))
 	return maybeY != nil && (P.Y.Equal(maybeY) || P.Y.Equal(maybeY.Neg(maybeY)))
 }
 
+// InPrimeOrderSubgroup returns true iff p is a non-identity point of the
+// prime-order subgroup generated by the base point.
+//
+// secp256k1 has cofactor 1: the full group of curve points has prime order
+// GroupOrder, so the only proper subgroup is the trivial one. Every on-curve
+// point other than the identity therefore generates the whole group, and
+// membership reduces to checking that p is on the curve and is not the point
+// at infinity. This would not hold for a curve with a larger cofactor, where
+// points must also be checked for a zero multiple of the group order.
+func InPrimeOrderSubgroup(p kyber.Point) bool {
+	if !IsSecp256k1Point(p) {
+		return false
+	}
+	return ValidPublicKey(p)
+}
+
+// ExportPointHex returns p in compressed form, as 0x-hex, for backing up keys.
+// See ImportPointHex.
+func ExportPointHex(p kyber.Point) (string, error) {
+	raw, err := p.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return hexutil.Encode(raw), nil
+}
+
+// ImportPointHex returns the point represented by the 0x-hex compressed point
+// h, as produced by ExportPointHex, or an error if h is malformed or doesn't
+// represent a valid public key.
+func ImportPointHex(h string) (kyber.Point, error) {
+	raw, err := hexutil.Decode(h)
+	if err != nil {
+		return nil, fmt.Errorf("malformed point hex: %v", err)
+	}
+	p := newPoint()
+	if err := p.UnmarshalBinary(raw); err != nil {
+		return nil, err
+	}
+	if !ValidPublicKey(p) {
+		return nil, fmt.Errorf("%s is not a valid public key", p)
+	}
+	return p, nil
+}
+
 // Generate generates a public/private key pair, which can be verified cheaply
 // on-chain
 func Generate(random cipher.Stream) *key.Pair {
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
 	"go.dedis.ch/kyber/v3/group/curve25519"
@@ -220,9 +221,54 @@ func TestCoordinates(t *testing.T) {
 
 func TestValidPublicKey(t *testing.T) {
 	require.False(t, ValidPublicKey(newPoint()), "zero is not a valid key")
+	require.False(t, ValidPublicKey(newPoint().Null()), "identity is not a valid key")
 	require.True(t, ValidPublicKey(newPoint().Base()))
 }
 
+func TestInPrimeOrderSubgroup(t *testing.T) {
+	require.False(t, InPrimeOrderSubgroup(newPoint().Null()), "identity is excluded")
+	require.False(t, InPrimeOrderSubgroup(nil))
+	require.False(t, InPrimeOrderSubgroup(
+		curve25519.NewBlakeSHA256Curve25519(false).Point().Base()))
+	offCurve := newPoint().Base().(*secp256k1Point)
+	offCurve.Y = offCurve.Y.Add(offCurve.Y, fieldEltFromInt(1))
+	require.False(t, InPrimeOrderSubgroup(offCurve))
+	require.True(t, InPrimeOrderSubgroup(newPoint().Base()))
+	require.True(t, InPrimeOrderSubgroup(
+		newPoint().Mul(newScalar(big.NewInt(0x1337)), nil)))
+}
+
+func TestPoint_ExportImportHex(t *testing.T) {
+	for i := 0; i < numPointSamples; i++ {
+		p := newPoint().Pick(randomStreamPoint)
+		exported, err := ExportPointHex(p)
+		require.NoError(t, err)
+		imported, err := ImportPointHex(exported)
+		require.NoError(t, err)
+		assert.True(t, p.Equal(imported))
+	}
+
+	base, err := ExportPointHex(newPoint().Base())
+	require.NoError(t, err)
+	for name, h := range map[string]string{
+		"no prefix": base[2:],
+		"non-hex":   "0x" + strings.Repeat("zz", 33),
+		"short":     base[:len(base)-2],
+		"bad sign":  base[:len(base)-2] + "02",
+		"off curve": "0x" + strings.Repeat("00", 31) + "0500", // 5³+7 is no square
+		"empty":     "",
+	} {
+		_, err := ImportPointHex(h)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestIsIdentity(t *testing.T) {
+	require.True(t, IsIdentity(newPoint().Null()))
+	require.True(t, IsIdentity(newPoint().Mul(newScalar(big.NewInt(0)), nil)))
+	require.False(t, IsIdentity(newPoint().Base()))
+}
+
 func TestGenerate(t *testing.T) {
 	for {
 		if ValidPublicKey(Generate(randomStreamPoint).Public) {
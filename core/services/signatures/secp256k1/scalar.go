@@ -19,6 +19,7 @@ import (
 
 	secp256k1BTCD "github.com/btcsuite/btcd/btcec"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/util/random"
@@ -226,3 +227,27 @@ func ScalarToHash(s kyber.Scalar) common.Hash {
 func RepresentsScalar(i *big.Int) bool {
 	return i.Cmp(GroupOrder) == -1
 }
+
+// ExportScalarHex returns s as a 0x-hex uint256, for backing up keys. See
+// ImportScalarHex.
+func ExportScalarHex(s kyber.Scalar) string {
+	return hexutil.Encode(ScalarToHash(s).Bytes())
+}
+
+// ImportScalarHex returns the scalar represented by the 0x-hex uint256 h, as
+// produced by ExportScalarHex, or an error if h is malformed or out of range.
+// Unlike IntToScalar, it never reduces the value mod GroupOrder.
+func ImportScalarHex(h string) (kyber.Scalar, error) {
+	raw, err := hexutil.Decode(h)
+	if err != nil {
+		return nil, fmt.Errorf("malformed scalar hex: %v", err)
+	}
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("scalar hex must encode 32 bytes, got %d", len(raw))
+	}
+	i := zero().SetBytes(raw)
+	if !RepresentsScalar(i) {
+		return nil, fmt.Errorf("0x%x is not less than the group order", i)
+	}
+	return IntToScalar(i), nil
+}
@@ -3,7 +3,9 @@ package secp256k1
 import (
 	"bytes"
 	"encoding/hex"
+	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -181,6 +183,34 @@ func TestScalar_IsSecp256k1Scalar(t *testing.T) {
 	require.True(t, IsSecp256k1Scalar(newScalar(bigZero)))
 }
 
+func TestScalar_ExportImportHex(t *testing.T) {
+	for i := 0; i < numScalarSamples; i++ {
+		s := newScalar(big.NewInt(0)).Pick(randomStreamScalar)
+		exported := ExportScalarHex(s)
+		require.Len(t, exported, 66)
+		imported, err := ImportScalarHex(exported)
+		require.NoError(t, err)
+		assert.True(t, s.Equal(imported))
+	}
+	zeroScalar, err := ImportScalarHex(ExportScalarHex(newScalar(big.NewInt(0))))
+	require.NoError(t, err)
+	assert.True(t, zeroScalar.Equal(newScalar(big.NewInt(0))))
+
+	for name, h := range map[string]string{
+		"group order": fmt.Sprintf("0x%064x", GroupOrder),
+		"max uint256": "0x" + strings.Repeat("ff", 32),
+		"no prefix":   strings.Repeat("01", 32),
+		"odd length":  "0x" + strings.Repeat("01", 31) + "1",
+		"non-hex":     "0x" + strings.Repeat("zz", 32),
+		"short":       "0x01",
+		"empty":       "",
+		"too long":    "0x" + strings.Repeat("00", 33),
+	} {
+		_, err := ImportScalarHex(h)
+		assert.Error(t, err, name)
+	}
+}
+
 func TestScalar_IntToScalar(t *testing.T) {
 	u256Cardinality := zero().Lsh(big.NewInt(1), 256)
 	IntToScalar(u256Cardinality)
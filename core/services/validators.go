@@ -9,6 +9,9 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/adapters"
 	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
@@ -128,12 +131,21 @@ func validateFluxMonitor(i models.Initiator, j models.JobSpec, store *store.Stor
 	if i.Address == utils.ZeroAddress {
 		fe.Add("no address")
 	}
+	for _, address := range i.Addresses {
+		if address == utils.ZeroAddress {
+			fe.Add("zero address in addresses")
+			break
+		}
+	}
 	if !i.IdleThreshold.IsInstant() && i.IdleThreshold.Shorter(i.PollingInterval) {
 		fe.Add("idleThreshold must be equal or greater than the pollingInterval")
 	}
-	if i.Threshold <= 0 {
+	if i.Threshold < 0 || (i.Threshold == 0 && i.AbsoluteThreshold == 0) {
 		fe.Add("bad threshold")
 	}
+	if i.AbsoluteThreshold < 0 {
+		fe.Add("bad absoluteThreshold")
+	}
 	if i.RequestData.String() == "" {
 		fe.Add("no requestdata")
 	}
@@ -145,10 +157,31 @@ func validateFluxMonitor(i models.Initiator, j models.JobSpec, store *store.Stor
 	if err := validateFeeds(i.Feeds, store); err != nil {
 		fe.Add(err.Error())
 	}
+	if i.SubmissionMethod != "" {
+		if err := validateSubmissionMethod(i.SubmissionMethod); err != nil {
+			fe.Add(err.Error())
+		}
+	}
+	if _, err := fluxmonitor.ParseAnswerTransforms(i.AnswerTransforms, i.Precision); err != nil {
+		fe.Add(err.Error())
+	}
 
 	return fe.CoerceEmptyToNil()
 }
 
+// validateSubmissionMethod checks that the FluxAggregator contract, whose ABI
+// the flux monitor encodes its submissions with, has a method named method
+func validateSubmissionMethod(method string) error {
+	codec, err := eth.GetV6ContractCodec(contracts.FluxAggregatorName)
+	if err != nil {
+		return err
+	}
+	if _, err := codec.GetMethodID(method); err != nil {
+		return fmt.Errorf("submissionMethod %s is not a method of the %s contract", method, contracts.FluxAggregatorName)
+	}
+	return nil
+}
+
 func validateFeeds(feeds models.Feeds, store *store.Store) error {
 	var feedsData []interface{}
 	if err := json.Unmarshal(feeds.Bytes(), &feedsData); err != nil {
@@ -401,6 +401,23 @@ func TestValidateInitiator_FluxMonitorHappy(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestValidateInitiator_FluxMonitorAbsoluteThresholdOnly(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJob()
+	jsonStr := cltest.MustJSONSet(t,
+		cltest.MustJSONDel(t, validInitiator, "params.threshold"),
+		"params.absoluteThreshold", 0.01)
+	var initr models.Initiator
+	require.NoError(t, json.Unmarshal([]byte(jsonStr), &initr))
+	assert.Equal(t, float32(0.01), initr.AbsoluteThreshold)
+	err := services.ValidateInitiator(initr, job, store)
+	require.NoError(t, err)
+}
+
 func TestValidateInitiator_FluxMonitorErrors(t *testing.T) {
 	t.Parallel()
 
@@ -416,10 +433,14 @@ func TestValidateInitiator_FluxMonitorErrors(t *testing.T) {
 		{"feeds", cltest.MustJSONSet(t, validInitiator, "params.feeds", []string{})},
 		{"threshold", cltest.MustJSONDel(t, validInitiator, "params.threshold")},
 		{"threshold", cltest.MustJSONSet(t, validInitiator, "params.threshold", -5)},
+		{"absoluteThreshold", cltest.MustJSONSet(t, validInitiator, "params.absoluteThreshold", -5)},
 		{"requestdata", cltest.MustJSONDel(t, validInitiator, "params.requestdata")},
 		{"pollingInterval", cltest.MustJSONDel(t, validInitiator, "params.pollingInterval")},
 		{"pollingInterval", cltest.MustJSONSet(t, validInitiator, "params.pollingInterval", "1s")},
 		{"idleThreshold", cltest.MustJSONSet(t, validInitiator, "params.idleThreshold", "30s")},
+		{"submissionMethod", cltest.MustJSONSet(t, validInitiator, "params.submissionMethod", "noSuchMethod")},
+		{"answerTransforms", cltest.MustJSONSet(t, validInitiator, "params.answerTransforms", []interface{}{map[string]interface{}{"op": "square"}})},
+		{"answerTransforms", cltest.MustJSONSet(t, validInitiator, "params.answerTransforms", "reciprocal")},
 	}
 	for _, test := range tests {
 		t.Run("bad "+test.Field, func(t *testing.T) {
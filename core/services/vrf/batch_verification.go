@@ -0,0 +1,79 @@
+package vrf
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// VerifyProofs verifies proofs concurrently, on up to runtime.NumCPU workers.
+//
+// allValid is true iff every proof verified without error. Otherwise,
+// firstInvalidIndex is the lowest index of a proof which failed to verify or
+// whose verification errored, and is -1 if allValid. The result doesn't depend
+// on the order in which the workers happen to run.
+//
+// Proofs after the first failure found so far are skipped, since they can't
+// change firstInvalidIndex. err combines the errors of every proof which was
+// verified, so it always includes the error at firstInvalidIndex, if there was
+// one, but may or may not include errors from later proofs.
+func VerifyProofs(proofs []*Proof) (allValid bool, firstInvalidIndex int, err error) {
+	workers := runtime.NumCPU()
+	if workers > len(proofs) {
+		workers = len(proofs)
+	}
+
+	var (
+		next         int64 = -1                 // Index of the latest proof claimed by a worker
+		lowestFailed int64 = int64(len(proofs)) // Index of the first failure found so far
+		errs               = make([]error, len(proofs))
+		wg           sync.WaitGroup
+	)
+	recordFailure := func(idx int64) {
+		for {
+			current := atomic.LoadInt64(&lowestFailed)
+			if idx >= current || atomic.CompareAndSwapInt64(&lowestFailed, current, idx) {
+				return
+			}
+		}
+	}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				idx := atomic.AddInt64(&next, 1)
+				// Indices are claimed in increasing order, so no later proof can
+				// be the first failure either
+				if idx >= atomic.LoadInt64(&lowestFailed) {
+					return
+				}
+				proof := proofs[idx]
+				if proof == nil {
+					errs[idx] = errors.Errorf("proof %d is nil", idx)
+					recordFailure(idx)
+					continue
+				}
+				valid, verr := proof.VerifyVRFProof()
+				if verr != nil {
+					errs[idx] = errors.Wrapf(verr, "while verifying proof %d", idx)
+				}
+				if !valid || verr != nil {
+					recordFailure(idx)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, e := range errs {
+		err = multierr.Append(err, e)
+	}
+	if lowestFailed == int64(len(proofs)) {
+		return true, -1, nil
+	}
+	return false, int(lowestFailed), err
+}
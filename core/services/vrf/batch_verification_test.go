@@ -0,0 +1,73 @@
+package vrf
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestProofs(t *testing.T, n int) []*Proof {
+	var proofs []*Proof
+	for j := 0; j < n; j++ {
+		secretKey := common.BigToHash(big.NewInt(int64(j) + 1))
+		proof, err := GenerateProof(secretKey, common.BigToHash(big.NewInt(int64(j))))
+		require.NoError(t, err)
+		proofs = append(proofs, proof)
+	}
+	return proofs
+}
+
+func TestVRF_VerifyProofs(t *testing.T) {
+	const numProofs = 8
+	const tamperedIndex = 5
+
+	t.Run("all valid", func(t *testing.T) {
+		allValid, firstInvalidIndex, err := VerifyProofs(generateTestProofs(t, numProofs))
+		require.NoError(t, err)
+		assert.True(t, allValid)
+		assert.Equal(t, -1, firstInvalidIndex)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		allValid, firstInvalidIndex, err := VerifyProofs(nil)
+		require.NoError(t, err)
+		assert.True(t, allValid)
+		assert.Equal(t, -1, firstInvalidIndex)
+	})
+
+	t.Run("tampered proof", func(t *testing.T) {
+		proofs := generateTestProofs(t, numProofs)
+		proofs[tamperedIndex].Output = add(proofs[tamperedIndex].Output, one)
+		// A later tampered proof doesn't change the reported index
+		proofs[numProofs-1].Output = add(proofs[numProofs-1].Output, one)
+
+		allValid, firstInvalidIndex, err := VerifyProofs(proofs)
+		require.NoError(t, err, "a tampered proof is invalid, not erroneous")
+		assert.False(t, allValid)
+		assert.Equal(t, tamperedIndex, firstInvalidIndex)
+	})
+
+	t.Run("malformed proof", func(t *testing.T) {
+		proofs := generateTestProofs(t, numProofs)
+		proofs[tamperedIndex].Gamma = nil
+
+		allValid, firstInvalidIndex, err := VerifyProofs(proofs)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while verifying proof 5")
+		assert.False(t, allValid)
+		assert.Equal(t, tamperedIndex, firstInvalidIndex)
+	})
+
+	t.Run("nil proof", func(t *testing.T) {
+		proofs := generateTestProofs(t, numProofs)
+		proofs[tamperedIndex] = nil
+
+		allValid, firstInvalidIndex, err := VerifyProofs(proofs)
+		require.Error(t, err)
+		assert.False(t, allValid)
+		assert.Equal(t, tamperedIndex, firstInvalidIndex)
+	})
+}
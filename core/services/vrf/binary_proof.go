@@ -0,0 +1,88 @@
+package vrf
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// BinaryProofLength is the length of the compact binary form of a Proof, as
+// produced by Proof.MarshalBinary
+const BinaryProofLength = 33 + // PublicKey (compressed)
+	33 + // Gamma (compressed)
+	32 + // C
+	32 + // S
+	32 + // Seed
+	32 // Output
+
+// ProofMarshalLen is the exact length of a Proof's binary form, for sizing
+// buffers and validating inputs before unmarshaling them. UnmarshalBinary
+// rejects input of any other length.
+const ProofMarshalLen = BinaryProofLength
+
+// MarshalBinary renders p as a fixed-layout byte slice, for compact storage:
+// the compressed public key and gamma, followed by C, S, Seed and Output as
+// 32-byte big-endian words. Unlike MarshalForSolidityVerifier, it omits the
+// precomputed witnesses, so it is much shorter.
+//
+// The layout has no room for the domain-separation scheme, so only proofs with
+// LegacyDomainSeparation can be marshaled.
+func (p *Proof) MarshalBinary() ([]byte, error) {
+	if !p.WellFormed() || p.Seed == nil || p.Seed.Sign() < 0 ||
+		p.Seed.BitLen() > 256 || p.Output.Sign() < 0 {
+		return nil, fmt.Errorf("badly-formatted proof %s", p)
+	}
+	if p.DomainSeparation != LegacyDomainSeparation {
+		return nil, fmt.Errorf("can't marshal proof with %s domain separation",
+			p.DomainSeparation)
+	}
+	publicKey, err := p.PublicKey.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrapf(err, "while marshaling proof public key")
+	}
+	gamma, err := p.Gamma.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrapf(err, "while marshaling proof gamma")
+	}
+	rv := make([]byte, 0, BinaryProofLength)
+	rv = append(rv, publicKey...)
+	rv = append(rv, gamma...)
+	rv = append(rv, uint256ToBytes32(p.C)...)
+	rv = append(rv, uint256ToBytes32(p.S)...)
+	rv = append(rv, uint256ToBytes32(p.Seed)...)
+	rv = append(rv, uint256ToBytes32(p.Output)...)
+	if len(rv) != BinaryProofLength {
+		panic(fmt.Errorf("wrong binary proof length: %d", len(rv)))
+	}
+	return rv, nil
+}
+
+// UnmarshalBinary sets p to the proof represented by data, which must be in
+// the form produced by MarshalBinary, or returns a non-nil error
+func (p *Proof) UnmarshalBinary(data []byte) error {
+	if len(data) != BinaryProofLength {
+		return fmt.Errorf("binary VRF proof is %d bytes long, should be %d",
+			len(data), BinaryProofLength)
+	}
+	publicKey := secp256k1Curve.Point()
+	if err := publicKey.UnmarshalBinary(data[:33]); err != nil {
+		return errors.Wrapf(err, "while reading proof public key")
+	}
+	gamma := secp256k1Curve.Point()
+	if err := gamma.UnmarshalBinary(data[33:66]); err != nil {
+		return errors.Wrapf(err, "while reading proof gamma")
+	}
+	rv := Proof{
+		PublicKey: publicKey,
+		Gamma:     gamma,
+		C:         i().SetBytes(data[66:98]),
+		S:         i().SetBytes(data[98:130]),
+		Seed:      i().SetBytes(data[130:162]),
+		Output:    i().SetBytes(data[162:194]),
+	}
+	if !rv.WellFormed() {
+		return fmt.Errorf("badly-formatted binary proof %s", &rv)
+	}
+	*p = rv
+	return nil
+}
@@ -0,0 +1,164 @@
+package vrf
+
+// Best-effort constant-time arithmetic modulo the secp256k1 group order, for
+// the secret-dependent scalar operations in generateProofWithNonce.
+//
+// big.Int arithmetic takes time which depends on the magnitude of its
+// operands, which could leak information about the secret key. The helpers
+// here instead always operate on fixed-width 256-bit values, represented as
+// four little-endian 64-bit limbs, with a fixed sequence of operations and no
+// secret-dependent branches. Conversion to and from big.Int at the boundaries
+// is still not constant-time, and nor is the curve arithmetic in kyber, so this
+// is a mitigation rather than a guarantee.
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+
+	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
+)
+
+// word256 is a 256-bit unsigned integer, as little-endian 64-bit limbs
+type word256 [4]uint64
+
+func word256FromBig(x *big.Int) (w word256) {
+	b := uint256ToBytes32(x)
+	for j := 0; j < 4; j++ {
+		w[j] = binary.BigEndian.Uint64(b[32-8*(j+1) : 32-8*j])
+	}
+	return w
+}
+
+func (w word256) big() *big.Int {
+	b := make([]byte, 32)
+	for j := 0; j < 4; j++ {
+		binary.BigEndian.PutUint64(b[32-8*(j+1):32-8*j], w[j])
+	}
+	return i().SetBytes(b)
+}
+
+var (
+	// groupOrderWord is the secp256k1 group order, n
+	groupOrderWord = word256FromBig(secp256k1.GroupOrder)
+	// groupOrderComplement is 2^256-n, which is less than 2^129. Since
+	// 2^256≡2^256-n mod n, the high limbs of a product can be folded into the
+	// low limbs by multiplying them by this.
+	groupOrderComplement = func() []uint64 {
+		w := word256FromBig(sub(lsh(one, 256), secp256k1.GroupOrder))
+		return w[:3]
+	}()
+)
+
+// ctMask returns all ones if bit is 1, and all zeros if bit is 0
+func ctMask(bit uint64) uint64 { return -bit }
+
+// ctSelect returns a if mask is all ones, and b if mask is all zeros
+func ctSelect(mask uint64, a, b word256) (rv word256) {
+	for j := range rv {
+		rv[j] = (a[j] & mask) | (b[j] &^ mask)
+	}
+	return rv
+}
+
+// ctAdd returns a+b mod 2^256, and the carry-out bit
+func ctAdd(a, b word256) (sum word256, carry uint64) {
+	for j := range sum {
+		sum[j], carry = bits.Add64(a[j], b[j], carry)
+	}
+	return sum, carry
+}
+
+// ctSub returns a-b mod 2^256, and the borrow-out bit
+func ctSub(a, b word256) (diff word256, borrow uint64) {
+	for j := range diff {
+		diff[j], borrow = bits.Sub64(a[j], b[j], borrow)
+	}
+	return diff, borrow
+}
+
+// mulLimbs returns a*b, with len(a)+len(b) limbs
+func mulLimbs(a, b []uint64) []uint64 {
+	rv := make([]uint64, len(a)+len(b))
+	for j := range a {
+		var carry uint64
+		for k := range b {
+			hi, lo := bits.Mul64(a[j], b[k])
+			var c uint64
+			lo, c = bits.Add64(lo, rv[j+k], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			rv[j+k] = lo
+			carry = hi
+		}
+		rv[j+len(b)] = carry
+	}
+	return rv
+}
+
+// addLimbs adds b into a in place, and returns a. len(a) must be at least
+// len(b), and the sum must fit in len(a) limbs.
+func addLimbs(a, b []uint64) []uint64 {
+	var carry uint64
+	for j := range a {
+		var bj uint64
+		if j < len(b) {
+			bj = b[j]
+		}
+		a[j], carry = bits.Add64(a[j], bj, carry)
+	}
+	return a
+}
+
+// foldHigh returns an integer congruent mod n to x, by replacing the limbs
+// of x above 2^256 with their product with 2^256-n
+func foldHigh(x []uint64) []uint64 {
+	return addLimbs(mulLimbs(x[4:], groupOrderComplement), x[:4])
+}
+
+// ctReduceWord returns w mod n, for w < 2^256. Since 2^256 < 2n, this only
+// requires one conditional subtraction.
+func ctReduceWord(w word256) word256 {
+	diff, borrow := ctSub(w, groupOrderWord)
+	return ctSelect(ctMask(borrow), w, diff)
+}
+
+// ctReduceWide returns x mod n, for a 512-bit x, as eight little-endian limbs
+func ctReduceWide(x []uint64) word256 {
+	// Each fold shrinks the value by about 127 bits: 512 -> 386 -> 260 -> 257
+	x = foldHigh(x) // 7 limbs
+	x = foldHigh(x) // 6 limbs
+	x = foldHigh(x) // 5 limbs; x[4] is at most 1
+	var low, complement word256
+	copy(low[:], x[:4])
+	copy(complement[:], groupOrderComplement)
+	for j := range complement {
+		complement[j] &= ctMask(x[4])
+	}
+	// If x[4] is set, x[:4] is small, so this addition can't overflow
+	folded, _ := ctAdd(low, complement)
+	return ctReduceWord(folded)
+}
+
+// ctMulModOrder returns a*b mod n, for 256-bit a and b, in best-effort
+// constant time
+func ctMulModOrder(a, b *big.Int) *big.Int {
+	aw, bw := word256FromBig(a), word256FromBig(b)
+	return ctReduceWide(mulLimbs(aw[:], bw[:])).big()
+}
+
+// ctSubModOrder returns a-b mod n, for 256-bit a and b, in best-effort
+// constant time
+func ctSubModOrder(a, b *big.Int) *big.Int {
+	aw := ctReduceWord(word256FromBig(a))
+	bw := ctReduceWord(word256FromBig(b))
+	diff, borrow := ctSub(aw, bw)
+	// If a < b, diff is a-b+2^256, so add n, discarding the carry, to get a-b+n
+	orderMasked := groupOrderWord
+	for j := range orderMasked {
+		orderMasked[j] &= ctMask(borrow)
+	}
+	diff, _ = ctAdd(diff, orderMasked)
+	return diff.big()
+}
@@ -0,0 +1,97 @@
+package vrf
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var maxWord256 = sub(lsh(one, 256), one)
+
+func randomWord256(t testing.TB) *big.Int {
+	x, err := rand.Int(rand.Reader, lsh(one, 256))
+	require.NoError(t, err)
+	return x
+}
+
+func TestVRF_CTMulModOrder(t *testing.T) {
+	order := secp256k1.GroupOrder
+	edgeCases := []*big.Int{zero, one, two, sub(order, one), order,
+		add(order, one), maxWord256}
+	for _, a := range edgeCases {
+		for _, b := range edgeCases {
+			assert.Equal(t, mod(mul(a, b), order).String(), ctMulModOrder(a, b).String(),
+				"%x*%x", a, b)
+		}
+	}
+	for j := 0; j < 1000; j++ {
+		a, b := randomWord256(t), randomWord256(t)
+		require.Equal(t, mod(mul(a, b), order).String(), ctMulModOrder(a, b).String(),
+			"%x*%x", a, b)
+	}
+}
+
+func TestVRF_CTSubModOrder(t *testing.T) {
+	order := secp256k1.GroupOrder
+	edgeCases := []*big.Int{zero, one, two, sub(order, one), order,
+		add(order, one), maxWord256}
+	for _, a := range edgeCases {
+		for _, b := range edgeCases {
+			assert.Equal(t, mod(sub(a, b), order).String(), ctSubModOrder(a, b).String(),
+				"%x-%x", a, b)
+		}
+	}
+	for j := 0; j < 1000; j++ {
+		a, b := randomWord256(t), randomWord256(t)
+		require.Equal(t, mod(sub(a, b), order).String(), ctSubModOrder(a, b).String(),
+			"%x-%x", a, b)
+	}
+}
+
+func TestVRF_GenerateProofWithNonce_MatchesBigIntArithmetic(t *testing.T) {
+	for j := 0; j < 10; j++ {
+		secretKey := mod(randomWord256(t), secp256k1.GroupOrder)
+		nonce := mod(randomWord256(t), secp256k1.GroupOrder)
+		proof, err := generateProofWithNonce(secretKey, randomWord256(t), nonce)
+		require.NoError(t, err)
+		expectedS := mod(sub(nonce, mul(proof.C, secretKey)), secp256k1.GroupOrder)
+		assert.Equal(t, expectedS.String(), proof.S.String())
+	}
+}
+
+// BenchmarkCTScalarOps measures the secret-dependent scalar operations in
+// generateProofWithNonce for secret keys of very different magnitudes and
+// Hamming weights. The timings of the sub-benchmarks should be close.
+func BenchmarkCTScalarOps(b *testing.B) {
+	c := mustHashUint256s(b, big.NewInt(42))
+	nonce := sub(secp256k1.GroupOrder, big.NewInt(12345))
+	secretKeys := []struct {
+		name string
+		key  *big.Int
+	}{
+		{"one", one},
+		{"small", big.NewInt(0xff)},
+		{"half", div(secp256k1.GroupOrder, two)},
+		{"orderMinusOne", sub(secp256k1.GroupOrder, one)},
+		{"random", mod(randomWord256(b), secp256k1.GroupOrder)},
+	}
+	for _, sk := range secretKeys {
+		sk := sk
+		b.Run(sk.name, func(b *testing.B) {
+			for j := 0; j < b.N; j++ {
+				ctSubModOrder(nonce, ctMulModOrder(c, sk.key))
+			}
+		})
+	}
+}
+
+func mustHashUint256s(t testing.TB, xs ...*big.Int) *big.Int {
+	h, err := HashUint256s(xs...)
+	require.NoError(t, err)
+	return h
+}
@@ -0,0 +1,86 @@
+package vrf
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
+)
+
+// CurveParams contains the secp256k1 parameters which the arithmetic in this
+// package relies on. The values returned by Params are copies, so they can be
+// modified freely, e.g. for cross-implementation testing.
+type CurveParams struct {
+	FieldSize            *big.Int // P, the number of elements in the base field
+	GroupOrder           *big.Int // Number of points on the curve
+	EulersCriterionPower *big.Int // (P-1)/2
+	SqrtPower            *big.Int // (P+1)/4
+}
+
+// FieldSize returns the number of elements in secp256k1's base field
+func FieldSize() *big.Int { return i().Set(fieldSize) }
+
+// GroupOrder returns the number of points on secp256k1
+func GroupOrder() *big.Int { return i().Set(secp256k1.GroupOrder) }
+
+// EulersCriterionPower returns (FieldSize-1)/2, the exponent used by IsSquare
+func EulersCriterionPower() *big.Int { return i().Set(eulersCriterionPower) }
+
+// SqrtPower returns (FieldSize+1)/4, the exponent used by SquareRoot
+func SqrtPower() *big.Int { return i().Set(sqrtPower) }
+
+// Params returns a copy of the curve parameters used by this package
+func Params() CurveParams {
+	return CurveParams{
+		FieldSize:            FieldSize(),
+		GroupOrder:           GroupOrder(),
+		EulersCriterionPower: EulersCriterionPower(),
+		SqrtPower:            SqrtPower(),
+	}
+}
+
+// primalityRounds is the number of Miller-Rabin rounds used by Validate
+const primalityRounds = 20
+
+// Validate returns an error if any of the invariants this package relies on
+// fails to hold for c
+func (c CurveParams) Validate() error {
+	if c.FieldSize == nil || c.GroupOrder == nil ||
+		c.EulersCriterionPower == nil || c.SqrtPower == nil {
+		return fmt.Errorf("curve parameters must all be set: %+v", c)
+	}
+	if !c.FieldSize.ProbablyPrime(primalityRounds) {
+		return fmt.Errorf("field size %x is not prime", c.FieldSize)
+	}
+	// SquareRoot computes x^((P+1)/4), which is only a square root if P≡3 mod 4
+	if !equal(mod(c.FieldSize, four), three) {
+		return fmt.Errorf("field size %x is not 3 mod 4", c.FieldSize)
+	}
+	if !equal(c.EulersCriterionPower, div(sub(c.FieldSize, one), two)) {
+		return fmt.Errorf("euler's criterion power %x is not (P-1)/2",
+			c.EulersCriterionPower)
+	}
+	if !equal(c.SqrtPower, div(add(c.FieldSize, one), four)) {
+		return fmt.Errorf("sqrt power %x is not (P+1)/4", c.SqrtPower)
+	}
+	if !equal(c.GroupOrder, secp256k1.GroupOrder) {
+		return fmt.Errorf("group order %x does not match secp256k1 group order %x",
+			c.GroupOrder, secp256k1.GroupOrder)
+	}
+	if !c.GroupOrder.ProbablyPrime(primalityRounds) {
+		return fmt.Errorf("group order %x is not prime", c.GroupOrder)
+	}
+	// The generator must satisfy y^2=x^3+7 over the given field
+	gx, gy := secp256k1.Coordinates(Generator)
+	rhs := mod(add(exp(gx, three, c.FieldSize), seven), c.FieldSize)
+	if !equal(exp(gy, two, c.FieldSize), rhs) {
+		return fmt.Errorf("generator is not on y^2=x^3+7 over GF(%x)", c.FieldSize)
+	}
+	return nil
+}
+
+// ValidateCurveParams returns an error if the curve parameters used by this
+// package fail to satisfy the invariants the VRF arithmetic relies on
+func ValidateCurveParams() error {
+	return Params().Validate()
+}
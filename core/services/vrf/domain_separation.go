@@ -0,0 +1,66 @@
+package vrf
+
+import "fmt"
+
+// DomainSeparation selects the domain-separation tags mixed into the hashes
+// taken by HashToCurve and ScalarFromCurvePoints.
+//
+// LegacyDomainSeparation, the zero value, uses only the one-word prefixes
+// HASH_TO_CURVE_HASH_PREFIX and SCALAR_FROM_CURVE_POINTS_HASH_PREFIX from
+// VRF.sol, and is the only scheme the deployed solidity verifier accepts. Later
+// versions additionally prepend a versioned tag naming the protocol, so that
+// preimages hashed by this VRF can't collide with those hashed by any other
+// protocol using the same prefixes.
+//
+// Migrating to a tagged version requires an on-chain verifier which prepends
+// the same tag. Until one is deployed, keep generating legacy proofs. Once it
+// is, generate proofs for requests made to it with
+// GenerateProofWithDomainSeparation, and keep serving requests to the legacy
+// verifier with GenerateProof. A proof must always be verified under the
+// scheme it was generated with; the scheme is recorded in
+// Proof.DomainSeparation.
+type DomainSeparation uint8
+
+const (
+	// LegacyDomainSeparation uses only the untagged VRF.sol hash prefixes
+	LegacyDomainSeparation DomainSeparation = iota
+	// DomainSeparationV1 prepends domainTagV1 to the VRF.sol hash prefixes
+	DomainSeparationV1
+)
+
+// domainTagV1 is the versioned domain tag used by DomainSeparationV1
+var domainTagV1 = []byte("Chainlink VRF v1")
+
+// domainTag returns the tag to prepend to hash preimages under d
+func (d DomainSeparation) domainTag() ([]byte, error) {
+	switch d {
+	case LegacyDomainSeparation:
+		return nil, nil
+	case DomainSeparationV1:
+		return domainTagV1, nil
+	default:
+		return nil, fmt.Errorf("unknown VRF domain separation %d", d)
+	}
+}
+
+// prefix returns the tag for d, followed by hashPrefix. The result is always a
+// fresh slice, so it's safe to append to.
+func (d DomainSeparation) prefix(hashPrefix []byte) ([]byte, error) {
+	tag, err := d.domainTag()
+	if err != nil {
+		return nil, err
+	}
+	rv := make([]byte, 0, len(tag)+len(hashPrefix))
+	return append(append(rv, tag...), hashPrefix...), nil
+}
+
+func (d DomainSeparation) String() string {
+	switch d {
+	case LegacyDomainSeparation:
+		return "legacy"
+	case DomainSeparationV1:
+		return "v1"
+	default:
+		return fmt.Sprintf("DomainSeparation(%d)", uint8(d))
+	}
+}
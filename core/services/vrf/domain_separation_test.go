@@ -0,0 +1,99 @@
+package vrf
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVRF_DomainSeparation_TaggedAndUntaggedHashesDiffer(t *testing.T) {
+	pk := secp256k1Curve.Point().Mul(secp256k1.IntToScalar(big.NewInt(0x1337)), nil)
+	seed := big.NewInt(42)
+
+	legacy, err := HashToCurve(pk, seed, func(*big.Int) {})
+	require.NoError(t, err)
+	explicitLegacy, err := HashToCurveWithDomainSeparation(
+		LegacyDomainSeparation, pk, seed, func(*big.Int) {})
+	require.NoError(t, err)
+	tagged, err := HashToCurveWithDomainSeparation(
+		DomainSeparationV1, pk, seed, func(*big.Int) {})
+	require.NoError(t, err)
+	assert.True(t, legacy.Equal(explicitLegacy))
+	assert.False(t, legacy.Equal(tagged))
+	assert.True(t, secp256k1.ValidPublicKey(tagged))
+
+	var uWitness [20]byte
+	legacyScalar := ScalarFromCurvePoints(legacy, pk, legacy, uWitness, pk)
+	taggedScalar := ScalarFromCurvePointsWithDomainSeparation(
+		DomainSeparationV1, legacy, pk, legacy, uWitness, pk)
+	assert.Equal(t, legacyScalar, ScalarFromCurvePointsWithDomainSeparation(
+		LegacyDomainSeparation, legacy, pk, legacy, uWitness, pk))
+	assert.NotEqual(t, legacyScalar, taggedScalar)
+
+	_, err = HashToCurveWithDomainSeparation(
+		DomainSeparation(255), pk, seed, func(*big.Int) {})
+	assert.Error(t, err)
+	assert.Panics(t, func() {
+		ScalarFromCurvePointsWithDomainSeparation(
+			DomainSeparation(255), legacy, pk, legacy, uWitness, pk)
+	})
+}
+
+func TestVRF_DomainSeparation_ProofsVerifyOnlyUnderTheirOwnScheme(t *testing.T) {
+	secretKey, seed := big.NewInt(0x1337), big.NewInt(42)
+	legacy, err := generateProofWithNonce(secretKey, seed, one)
+	require.NoError(t, err)
+	tagged, err := generateProofWithNonceAndDomainSeparation(
+		DomainSeparationV1, secretKey, seed, one)
+	require.NoError(t, err)
+
+	assert.Equal(t, LegacyDomainSeparation, legacy.DomainSeparation)
+	assert.Equal(t, DomainSeparationV1, tagged.DomainSeparation)
+	assert.False(t, legacy.Gamma.Equal(tagged.Gamma))
+	assert.NotEqual(t, legacy.C, tagged.C)
+	assert.NotEqual(t, legacy.Output, tagged.Output)
+
+	for _, proof := range []*Proof{legacy, tagged} {
+		valid, err := proof.VerifyVRFProof()
+		require.NoError(t, err)
+		assert.True(t, valid, "%s", proof)
+
+		mislabeled := *proof
+		if proof.DomainSeparation == LegacyDomainSeparation {
+			mislabeled.DomainSeparation = DomainSeparationV1
+		} else {
+			mislabeled.DomainSeparation = LegacyDomainSeparation
+		}
+		valid, err = mislabeled.VerifyVRFProof()
+		require.NoError(t, err)
+		assert.False(t, valid, "%s", &mislabeled)
+	}
+
+	_, err = GenerateProofWithDomainSeparation(DomainSeparation(255),
+		common.BigToHash(secretKey), common.BigToHash(seed))
+	assert.Error(t, err)
+	randomTagged, err := GenerateProofWithDomainSeparation(DomainSeparationV1,
+		common.BigToHash(secretKey), common.BigToHash(seed))
+	require.NoError(t, err)
+	valid, err := randomTagged.VerifyVRFProof()
+	require.NoError(t, err)
+	assert.True(t, valid)
+	// The output depends only on the key, seed and scheme, not the nonce
+	assert.Equal(t, tagged.Output, randomTagged.Output)
+}
+
+func TestVRF_DomainSeparation_TaggedProofsCantBeMarshaledForLegacyFormats(t *testing.T) {
+	tagged, err := generateProofWithNonceAndDomainSeparation(
+		DomainSeparationV1, big.NewInt(0x1337), big.NewInt(42), one)
+	require.NoError(t, err)
+
+	_, err = tagged.MarshalForSolidityVerifier()
+	assert.Error(t, err)
+	_, err = tagged.MarshalBinary()
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+package vrf
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Field is a prime field GF(P), with P≡3 mod 4, over which the curve
+// y^2=x^3+7 is defined. The package-level IsSquare, SquareRoot, YSquared and
+// IsCurveXOrdinate functions use secp256k1's base field; a Field over a small
+// prime makes the same arithmetic tractable to test exhaustively.
+type Field struct {
+	modulus              *big.Int // P
+	eulersCriterionPower *big.Int // (P-1)/2
+	sqrtPower            *big.Int // (P+1)/4
+}
+
+// secp256k1Field is secp256k1's base field, GF(fieldSize)
+var secp256k1Field = &Field{fieldSize, eulersCriterionPower, sqrtPower}
+
+// NewField returns GF(p), or an error if p is not a prime congruent to 3 mod
+// 4, which SquareRoot relies on
+func NewField(p *big.Int) (*Field, error) {
+	if p == nil || !p.ProbablyPrime(primalityRounds) {
+		return nil, fmt.Errorf("field size %v is not prime", p)
+	}
+	if !equal(mod(p, four), three) {
+		return nil, fmt.Errorf("field size %x is not 3 mod 4", p)
+	}
+	return &Field{
+		modulus:              i().Set(p),
+		eulersCriterionPower: div(sub(p, one), two),
+		sqrtPower:            div(add(p, one), four),
+	}, nil
+}
+
+// Modulus returns a copy of P, the number of elements in the field
+func (f *Field) Modulus() *big.Int { return i().Set(f.modulus) }
+
+// IsSquare returns true iff x = y^2 for some non-zero y in GF(P)
+func (f *Field) IsSquare(x *big.Int) bool {
+	return equal(one, exp(x, f.eulersCriterionPower, f.modulus))
+}
+
+// SquareRoot returns a s.t. a^2=x, as long as x is a square
+func (f *Field) SquareRoot(x *big.Int) *big.Int {
+	return exp(x, f.sqrtPower, f.modulus)
+}
+
+// YSquared returns x^3+7 mod P, the right-hand side of the curve equation
+func (f *Field) YSquared(x *big.Int) *big.Int {
+	return mod(add(exp(x, three, f.modulus), seven), f.modulus)
+}
+
+// IsCurveXOrdinate returns true iff there is y s.t. y^2=x^3+7
+func (f *Field) IsCurveXOrdinate(x *big.Int) bool {
+	return f.IsSquare(f.YSquared(x))
+}
@@ -0,0 +1,78 @@
+package vrf
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// toyFieldSize is small enough to enumerate GF(toyFieldSize), and is 3 mod 4
+const toyFieldSize = 31
+
+func toyField(t *testing.T) *Field {
+	f, err := NewField(big.NewInt(toyFieldSize))
+	require.NoError(t, err)
+	return f
+}
+
+// toySquares returns the non-zero squares in GF(toyFieldSize), by brute force
+func toySquares() map[int64]bool {
+	squares := make(map[int64]bool)
+	for y := int64(1); y < toyFieldSize; y++ {
+		squares[y*y%toyFieldSize] = true
+	}
+	return squares
+}
+
+func TestVRF_NewField_RejectsUnsuitableModuli(t *testing.T) {
+	for _, p := range []*big.Int{nil, big.NewInt(33), big.NewInt(29), big.NewInt(1)} {
+		_, err := NewField(p)
+		assert.Error(t, err, "modulus %v", p)
+	}
+	f := toyField(t)
+	assert.Equal(t, big.NewInt(toyFieldSize), f.Modulus())
+	f.Modulus().SetInt64(7)
+	assert.Equal(t, big.NewInt(toyFieldSize), f.Modulus())
+}
+
+func TestVRF_Field_IsSquareExhaustive(t *testing.T) {
+	f := toyField(t)
+	squares := toySquares()
+	require.Len(t, squares, (toyFieldSize-1)/2)
+	// Include unreduced inputs, which must be treated as their residues
+	for x := int64(0); x < 2*toyFieldSize; x++ {
+		assert.Equal(t, squares[x%toyFieldSize], f.IsSquare(big.NewInt(x)), "x=%d", x)
+	}
+}
+
+func TestVRF_Field_SquareRootExhaustive(t *testing.T) {
+	f := toyField(t)
+	for x := range toySquares() {
+		root := f.SquareRoot(big.NewInt(x))
+		assert.True(t, root.Sign() >= 0 && root.Cmp(f.Modulus()) < 0, "x=%d", x)
+		assert.Equal(t, big.NewInt(x), mod(mul(root, root), f.Modulus()), "x=%d", x)
+	}
+}
+
+func TestVRF_Field_IsCurveXOrdinateExhaustive(t *testing.T) {
+	f := toyField(t)
+	squares := toySquares()
+	for x := int64(0); x < toyFieldSize; x++ {
+		ySquared := (x*x*x + 7) % toyFieldSize
+		assert.Equal(t, big.NewInt(ySquared), f.YSquared(big.NewInt(x)), "x=%d", x)
+		assert.Equal(t, squares[ySquared], f.IsCurveXOrdinate(big.NewInt(x)), "x=%d", x)
+	}
+}
+
+func TestVRF_Secp256k1FieldMatchesCurveParams(t *testing.T) {
+	f, err := NewField(FieldSize())
+	require.NoError(t, err)
+	assert.Equal(t, *secp256k1Field, *f)
+	for _, x := range []*big.Int{one, two, four, big.NewInt(5), sub(fieldSize, one)} {
+		assert.Equal(t, IsSquare(x), f.IsSquare(x))
+		assert.Equal(t, YSquared(x), f.YSquared(x))
+		assert.Equal(t, IsCurveXOrdinate(x), f.IsCurveXOrdinate(x))
+	}
+}
@@ -0,0 +1,74 @@
+package vrf
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"go.dedis.ch/kyber/v3"
+
+	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
+)
+
+// ProofFromHexFields returns the LegacyDomainSeparation proof with the given
+// fields, as 0x-hex, or an error if any of them is malformed or the proof
+// isn't WellFormed. publicKey and gamma may be in secp256k1.LongMarshal form
+// or compressed, and c, s, seed and output are big-endian uint256s, with or
+// without leading zeros. It's intended for tables of test vectors.
+func ProofFromHexFields(publicKey, gamma, c, s, seed, output string) (*Proof, error) {
+	var p Proof
+	var err error
+	if p.PublicKey, err = pointFromHex(publicKey); err != nil {
+		return nil, errors.Wrap(err, "public key")
+	}
+	if p.Gamma, err = pointFromHex(gamma); err != nil {
+		return nil, errors.Wrap(err, "gamma")
+	}
+	for _, field := range []struct {
+		name  string
+		hex   string
+		value **big.Int
+	}{
+		{"c", c, &p.C},
+		{"s", s, &p.S},
+		{"seed", seed, &p.Seed},
+		{"output", output, &p.Output},
+	} {
+		if *field.value, err = uint256FromHex(field.hex); err != nil {
+			return nil, errors.Wrap(err, field.name)
+		}
+	}
+	if !p.WellFormed() {
+		return nil, fmt.Errorf("badly-formatted proof %s", &p)
+	}
+	return &p, nil
+}
+
+// pointFromHex returns the secp256k1 point represented by h, in either long or
+// compressed form
+func pointFromHex(h string) (kyber.Point, error) {
+	raw, err := hexutil.Decode(h)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 64 {
+		return secp256k1.LongUnmarshal(raw)
+	}
+	p := secp256k1Curve.Point()
+	if err := p.UnmarshalBinary(raw); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func uint256FromHex(h string) (*big.Int, error) {
+	raw, err := hexutil.Decode(h)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > 32 {
+		return nil, fmt.Errorf("0x%x is longer than 32 bytes", raw)
+	}
+	return i().SetBytes(raw), nil
+}
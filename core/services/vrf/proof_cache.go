@@ -0,0 +1,197 @@
+package vrf
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// SeedPredictor returns the seeds which requests are expected to be made with
+// soon, most likely first
+type SeedPredictor func() []common.Hash
+
+// ProofCache pre-generates the proofs for the seeds its predictor expects, in
+// the background, so that they can be served as soon as the requests for them
+// arrive. Proofs for any other seeds are generated on demand. At most size
+// proofs are kept, and the least recently generated or served is evicted to
+// make room for a new one.
+type ProofCache struct {
+	signer    Signer
+	size      int
+	predictor SeedPredictor
+	interval  time.Duration
+
+	mutex  sync.Mutex
+	order  *list.List // of cachedProof, most recently used first
+	proofs map[common.Hash]*list.Element
+
+	startOnce, stopOnce sync.Once
+	chStop, chDone      chan struct{}
+}
+
+type cachedProof struct {
+	seed  common.Hash
+	proof *Proof
+}
+
+// NewProofCache returns a ProofCache holding at most size proofs generated by
+// signer, which once started asks predictor for the upcoming seeds every
+// interval
+func NewProofCache(signer Signer, size int, predictor SeedPredictor,
+	interval time.Duration) (*ProofCache, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("proof cache needs a signer")
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("proof cache size must be positive, got %d", size)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("proof cache interval must be positive, got %s", interval)
+	}
+	return &ProofCache{
+		signer:    signer,
+		size:      size,
+		predictor: predictor,
+		interval:  interval,
+		order:     list.New(),
+		proofs:    make(map[common.Hash]*list.Element),
+		chStop:    make(chan struct{}),
+		chDone:    make(chan struct{}),
+	}, nil
+}
+
+// Start begins pre-generating proofs for the predicted seeds
+func (c *ProofCache) Start() {
+	c.startOnce.Do(func() { go c.run() })
+}
+
+// Stop halts pre-generation, and waits for any proof being generated. The
+// cached proofs are still served.
+func (c *ProofCache) Stop() {
+	c.stopOnce.Do(func() { close(c.chStop) })
+	started := true
+	c.startOnce.Do(func() { started = false })
+	if started {
+		<-c.chDone
+	}
+}
+
+func (c *ProofCache) run() {
+	defer close(c.chDone)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.Precompute(c.predictor()...)
+		select {
+		case <-ticker.C:
+		case <-c.chStop:
+			return
+		}
+	}
+}
+
+// Precompute generates and caches the proofs for the given seeds which aren't
+// cached yet, stopping early if the cache is stopped. Only the first size
+// seeds are considered, so that the proofs for later, less likely seeds don't
+// evict those for the earlier ones.
+func (c *ProofCache) Precompute(seeds ...common.Hash) {
+	for i, seed := range seeds {
+		if i >= c.size {
+			return
+		}
+		select {
+		case <-c.chStop:
+			return
+		default:
+		}
+		if c.cached(seed) {
+			continue
+		}
+		proof, err := c.signer.GenerateProof(seed.Big())
+		if err != nil {
+			logger.Errorw("ProofCache unable to pre-generate VRF proof",
+				"seed", seed.Hex(),
+				"error", err,
+			)
+			continue
+		}
+		c.add(seed, proof)
+	}
+}
+
+// Proof returns the proof for seed, from the cache if it's there, generating
+// it otherwise. hit reports whether it was served from the cache. The returned
+// Proof is a copy, so its fields can be reassigned without affecting the cache.
+func (c *ProofCache) Proof(seed common.Hash) (proof *Proof, hit bool, err error) {
+	if proof, ok := c.take(seed); ok {
+		return proof, true, nil
+	}
+	proof, err = c.signer.GenerateProof(seed.Big())
+	if err != nil {
+		return nil, false, err
+	}
+	c.add(seed, proof)
+	rv := *proof
+	return &rv, false, nil
+}
+
+// Len returns the number of cached proofs
+func (c *ProofCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
+
+func (c *ProofCache) cached(seed common.Hash) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	_, ok := c.proofs[seed]
+	return ok
+}
+
+// take returns a copy of the cached proof for seed, marking it most recently
+// used, if it's cached and really is for seed
+func (c *ProofCache) take(seed common.Hash) (*Proof, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.proofs[seed]
+	if !ok {
+		return nil, false
+	}
+	proof := element.Value.(cachedProof).proof
+	if proof.Seed == nil || proof.Seed.Cmp(seed.Big()) != 0 {
+		logger.Errorw("ProofCache discarding VRF proof cached under the wrong seed",
+			"seed", seed.Hex(),
+			"proofSeed", proof.Seed,
+		)
+		c.order.Remove(element)
+		delete(c.proofs, seed)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	rv := *proof
+	return &rv, true
+}
+
+// add caches proof under seed as the most recently used, evicting the least
+// recently used proof if the cache is full
+func (c *ProofCache) add(seed common.Hash, proof *Proof) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.proofs[seed]; ok {
+		element.Value = cachedProof{seed, proof}
+		c.order.MoveToFront(element)
+		return
+	}
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.proofs, oldest.Value.(cachedProof).seed)
+	}
+	c.proofs[seed] = c.order.PushFront(cachedProof{seed, proof})
+}
@@ -0,0 +1,119 @@
+package vrf
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requireValidProofForSeed(t *testing.T, proof *Proof, seed common.Hash) {
+	t.Helper()
+	assert.Equal(t, seed.Big(), proof.Seed)
+	valid, err := proof.VerifyVRFProof()
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func newTestSigner(t *testing.T) Signer {
+	t.Helper()
+	signer, err := NewInMemorySigner(big.NewInt(0x1337))
+	require.NoError(t, err)
+	return signer
+}
+
+func TestProofCache_ServesPredictedSeedsFromCache(t *testing.T) {
+	predicted := []common.Hash{common.BigToHash(big.NewInt(1)), common.BigToHash(big.NewInt(2))}
+	cache, err := NewProofCache(newTestSigner(t), 10, func() []common.Hash { return predicted }, time.Hour)
+	require.NoError(t, err)
+	cache.Start()
+	defer cache.Stop()
+
+	require.Eventually(t, func() bool { return cache.Len() == len(predicted) }, 10*time.Second, 10*time.Millisecond)
+	for _, seed := range predicted {
+		proof, hit, err := cache.Proof(seed)
+		require.NoError(t, err)
+		assert.True(t, hit)
+		requireValidProofForSeed(t, proof, seed)
+	}
+}
+
+func TestProofCache_GeneratesUnpredictedSeedsOnDemand(t *testing.T) {
+	cache, err := NewProofCache(newTestSigner(t), 10,
+		func() []common.Hash { return nil }, time.Hour)
+	require.NoError(t, err)
+
+	seed := common.BigToHash(big.NewInt(42))
+	proof, hit, err := cache.Proof(seed)
+	require.NoError(t, err)
+	assert.False(t, hit)
+	requireValidProofForSeed(t, proof, seed)
+
+	// The cached proof isn't affected by changes to the one served
+	proof.Seed = big.NewInt(43)
+	again, hit, err := cache.Proof(seed)
+	require.NoError(t, err)
+	assert.True(t, hit)
+	requireValidProofForSeed(t, again, seed)
+}
+
+func TestProofCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache, err := NewProofCache(newTestSigner(t), 2,
+		func() []common.Hash { return nil }, time.Hour)
+	require.NoError(t, err)
+
+	seed1, seed2, seed3 := common.BigToHash(big.NewInt(1)),
+		common.BigToHash(big.NewInt(2)), common.BigToHash(big.NewInt(3))
+	// Only the first size seeds are precomputed
+	cache.Precompute(seed1, seed2, seed3)
+	require.Equal(t, 2, cache.Len())
+	assert.False(t, cache.cached(seed3))
+
+	// Using seed1 leaves seed2 least recently used, so it's evicted for seed3
+	_, hit, err := cache.Proof(seed1)
+	require.NoError(t, err)
+	require.True(t, hit)
+	_, hit, err = cache.Proof(seed3)
+	require.NoError(t, err)
+	require.False(t, hit)
+	assert.Equal(t, 2, cache.Len())
+	assert.True(t, cache.cached(seed1))
+	assert.False(t, cache.cached(seed2))
+	assert.True(t, cache.cached(seed3))
+}
+
+func TestProofCache_RejectsProofCachedUnderWrongSeed(t *testing.T) {
+	cache, err := NewProofCache(newTestSigner(t), 2,
+		func() []common.Hash { return nil }, time.Hour)
+	require.NoError(t, err)
+
+	seed, other := common.BigToHash(big.NewInt(1)), common.BigToHash(big.NewInt(2))
+	cache.Precompute(other)
+	otherProof, hit, err := cache.Proof(other)
+	require.NoError(t, err)
+	require.True(t, hit)
+	cache.add(seed, otherProof)
+
+	proof, hit, err := cache.Proof(seed)
+	require.NoError(t, err)
+	assert.False(t, hit)
+	requireValidProofForSeed(t, proof, seed)
+}
+
+func TestNewProofCache_ValidatesParameters(t *testing.T) {
+	predictor := func() []common.Hash { return nil }
+	_, err := NewProofCache(nil, 1, predictor, time.Second)
+	assert.Error(t, err)
+	_, err = NewProofCache(newTestSigner(t), 0, predictor, time.Second)
+	assert.Error(t, err)
+	_, err = NewProofCache(newTestSigner(t), 1, predictor, 0)
+	assert.Error(t, err)
+
+	// Stopping a cache which was never started doesn't block
+	cache, err := NewProofCache(newTestSigner(t), 1, predictor, time.Second)
+	require.NoError(t, err)
+	cache.Stop()
+}
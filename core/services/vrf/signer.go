@@ -0,0 +1,49 @@
+package vrf
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// Signer generates VRF proofs under a secret key which it doesn't expose, so
+// that the key can be held by an external keystore, e.g. one backed by an
+// HSM, which performs the scalar multiplications involving it.
+type Signer interface {
+	// GenerateProof returns a proof, verifiable under the signer's public key,
+	// of the VRF output for seed, which must be a uint256
+	GenerateProof(seed *big.Int) (*Proof, error)
+}
+
+// inMemorySigner is a Signer holding its secret key in process memory
+type inMemorySigner struct {
+	secretKey common.Hash
+}
+
+var _ Signer = inMemorySigner{}
+
+// NewInMemorySigner returns a Signer which generates its proofs under
+// secretKey, kept in process memory. secretKey must be in {1, ..., #secp256k1
+// - 1}.
+func NewInMemorySigner(secretKey *big.Int) (Signer, error) {
+	if secretKey == nil || secretKey.Sign() <= 0 || secretKey.Cmp(secp256k1.GroupOrder) >= 0 {
+		return nil, fmt.Errorf("secret key must be in {1, ..., #secp256k1 - 1}")
+	}
+	return inMemorySigner{common.BigToHash(secretKey)}, nil
+}
+
+// GenerateProof returns the proof of the VRF output for seed under s's secret
+// key
+func (s inMemorySigner) GenerateProof(seed *big.Int) (*Proof, error) {
+	if seed == nil {
+		return nil, fmt.Errorf("seed must be a uint256, got nil")
+	}
+	if err := utils.CheckUint256(seed); err != nil {
+		return nil, err
+	}
+	return GenerateProof(s.secretKey, common.BigToHash(seed))
+}
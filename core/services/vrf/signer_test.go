@@ -0,0 +1,41 @@
+package vrf
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
+)
+
+func TestInMemorySigner_GeneratesVerifiableProofs(t *testing.T) {
+	secretKey := big.NewInt(0x1337)
+	signer, err := NewInMemorySigner(secretKey)
+	require.NoError(t, err)
+	publicKey := secp256k1.ScalarToPublicPoint(secp256k1.IntToScalar(secretKey))
+
+	for _, seed := range []*big.Int{big.NewInt(1), big.NewInt(0xdeadbeef)} {
+		proof, err := signer.GenerateProof(seed)
+		require.NoError(t, err)
+		requireValidProofForSeed(t, proof, common.BigToHash(seed))
+		assert.True(t, publicKey.Equal(proof.PublicKey), "proof must be under the signer's key")
+	}
+}
+
+func TestInMemorySigner_RejectsInvalidInputs(t *testing.T) {
+	for _, secretKey := range []*big.Int{nil, big.NewInt(0), secp256k1.GroupOrder} {
+		_, err := NewInMemorySigner(secretKey)
+		assert.Error(t, err, "secret key %v", secretKey)
+	}
+
+	signer, err := NewInMemorySigner(big.NewInt(0x1337))
+	require.NoError(t, err)
+	tooLarge := new(big.Int).Lsh(big.NewInt(1), 256)
+	for _, seed := range []*big.Int{nil, big.NewInt(-1), tooLarge} {
+		_, err := signer.GenerateProof(seed)
+		assert.Error(t, err, "seed %v", seed)
+	}
+}
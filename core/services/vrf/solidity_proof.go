@@ -37,6 +37,10 @@ func point() kyber.Point {
 // SolidityPrecalculations returns the precomputed values needed by the solidity
 // verifier, or an error on failure.
 func (p *Proof) SolidityPrecalculations() (*SolidityProof, error) {
+	if p.DomainSeparation != LegacyDomainSeparation {
+		return nil, fmt.Errorf("the solidity verifier only accepts proofs with "+
+			"%s domain separation, got %s", LegacyDomainSeparation, p.DomainSeparation)
+	}
 	var rv SolidityProof
 	rv.P = p
 	c := secp256k1.IntToScalar(p.C)
@@ -66,6 +70,11 @@ const ProofLength = 64 + // PublicKey
 	64 + // sHashWitness
 	32 // zInv  (Leave Output out, because that can be efficiently calculated)
 
+// ProofSolidityMarshalLen is the exact length of a proof's calldata for the
+// solidity verifier, as produced by MarshalForSolidityVerifier.
+// UnmarshalSolidityProof rejects input of any other length.
+const ProofSolidityMarshalLen = ProofLength
+
 // MarshaledProof contains a VRF proof for randomValueFromVRFProof.
 //
 // NB: when passing one of these to randomValueFromVRFProof via the geth
@@ -93,7 +102,7 @@ func (p *SolidityProof) MarshalForSolidityVerifier() (proof MarshaledProof) {
 	write(secp256k1.LongMarshal(p.SHashWitness))
 	write(uint256ToBytes32(p.ZInv))
 	if len(cursor) != ProofLength {
-		panic(fmt.Errorf("wrong proof length: %d", len(proof)))
+		panic(fmt.Errorf("wrong proof length: %d", len(cursor)))
 	}
 	return proof
 }
@@ -108,6 +117,36 @@ func (p *Proof) MarshalForSolidityVerifier() (MarshaledProof, error) {
 	return solidityProof.MarshalForSolidityVerifier(), nil
 }
 
+// verificationBaseGas and hashToCurveIterationGas model the gas a transaction
+// calling VRF.sol#randomValueFromVRFProof is charged, including the intrinsic
+// transaction cost: a fixed cost, plus the cost of each candidate x ordinate
+// the contract's hashToCurve tries. They're rounded up from measurements on a
+// simulated backend.
+const (
+	verificationBaseGas     uint64 = 47500
+	hashToCurveIterationGas uint64 = 15000
+)
+
+// EstimatedVerificationGas returns an estimate of the gas the solidity verifier
+// is charged to verify p, which depends on the number of hash-to-curve
+// iterations needed for p.Seed. It's a heuristic, not a bound: the actual cost
+// also varies slightly with the proof's other values, and with the gas schedule
+// of the chain. If p is so malformed that its hash-to-curve point can't be
+// computed, the contract would reject it after a single iteration, so that's
+// what is estimated.
+func (p *Proof) EstimatedVerificationGas() uint64 {
+	var iterations uint64
+	if p.Seed != nil {
+		if _, err := HashToCurve(p.PublicKey, p.Seed, func(*big.Int) { iterations++ }); err != nil {
+			iterations = 0
+		}
+	}
+	if iterations == 0 {
+		iterations = 1
+	}
+	return verificationBaseGas + hashToCurveIterationGas*iterations
+}
+
 func UnmarshalSolidityProof(proof []byte) (rv Proof, err error) {
 	failedProof := Proof{}
 	if len(proof) != ProofLength {
@@ -31,6 +31,7 @@
 package vrf
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
 	"math/big"
@@ -79,26 +80,29 @@ var (
 
 // IsSquare returns true iff x = y^2 for some y in GF(p)
 func IsSquare(x *big.Int) bool {
-	return equal(one, exp(x, eulersCriterionPower, fieldSize))
+	return secp256k1Field.IsSquare(x)
 }
 
 // SquareRoot returns a s.t. a^2=x, as long as x is a square
 func SquareRoot(x *big.Int) *big.Int {
-	return exp(x, sqrtPower, fieldSize)
+	return secp256k1Field.SquareRoot(x)
 }
 
 // YSquared returns x^3+7 mod fieldSize, the right-hand side of the secp256k1
 // curve equation.
 func YSquared(x *big.Int) *big.Int {
-	return mod(add(exp(x, three, fieldSize), seven), fieldSize)
+	return secp256k1Field.YSquared(x)
 }
 
 // IsCurveXOrdinate returns true iff there is y s.t. y^2=x^3+7
 func IsCurveXOrdinate(x *big.Int) bool {
-	return IsSquare(YSquared(x))
+	return secp256k1Field.IsCurveXOrdinate(x)
 }
 
-// packUint256s returns xs serialized as concatenated uint256s, or an error
+// packUint256s returns xs serialized as concatenated uint256s, or an error if
+// any is negative or too big. Like uint256ToBytes32, each is a 32-byte
+// big-endian word, as abi.encodePacked lays uint256s out in Solidity, so that
+// the hashes computed here match the VRF contracts'.
 func packUint256s(xs ...*big.Int) ([]byte, error) {
 	mem := []byte{}
 	for _, x := range xs {
@@ -126,23 +130,40 @@ func HashUint256s(xs ...*big.Int) (*big.Int, error) {
 	return utils.MustHash(string(packed)).Big(), nil
 }
 
+// uint256ToBytes32 returns x as a 32-byte big-endian word, exactly as
+// packUint256s serializes it. It panics if x is negative or too big, so is for
+// values already known to be uint256s.
 func uint256ToBytes32(x *big.Int) []byte {
-	if x.BitLen() > 256 {
-		panic("vrf.uint256ToBytes32: too big to marshal to uint256")
+	word, err := utils.EVMWordBigInt(x)
+	if err != nil {
+		panic(errors.Wrap(err, "vrf.uint256ToBytes32: unable to marshal to uint256"))
 	}
-	return common.LeftPadBytes(x.Bytes(), 32)
+	return word
 }
 
+// maxFieldHashIterations bounds the rehashes fieldHash makes. Each is needed
+// with probability less than 2**-224, so a legitimate hash never gets close.
+const maxFieldHashIterations = 128
+
+// keccak256 is the hash fieldHash iterates. It's a variable so that tests can
+// force the iteration bound.
+var keccak256 = utils.MustHash
+
 // fieldHash hashes xs uniformly into {0, ..., fieldSize-1}. msg is assumed to
-// already be a 256-bit hash
-func fieldHash(msg []byte) *big.Int {
-	rv := utils.MustHash(string(msg)).Big()
+// already be a 256-bit hash. It errors rather than rehashing more than
+// maxFieldHashIterations times.
+func fieldHash(msg []byte) (*big.Int, error) {
+	rv := keccak256(string(msg)).Big()
 	// Hash recursively until rv < q. P(success per iteration) >= 0.5, so
 	// number of extra hashes is geometrically distributed, with mean < 1.
-	for rv.Cmp(fieldSize) >= 0 {
-		rv = utils.MustHash(string(common.BigToHash(rv).Bytes())).Big()
+	for iterations := 0; rv.Cmp(fieldSize) >= 0; iterations++ {
+		if iterations >= maxFieldHashIterations {
+			return nil, fmt.Errorf(
+				"vrf.fieldHash: no hash in field after %d rehashes", maxFieldHashIterations)
+		}
+		rv = keccak256(string(common.BigToHash(rv).Bytes())).Big()
 	}
-	return rv
+	return rv, nil
 }
 
 // hashToCurveHashPrefix is domain-separation tag for initial HashToCurve hash.
@@ -151,16 +172,50 @@ var hashToCurveHashPrefix = common.BigToHash(one).Bytes()
 
 // HashToCurve is a cryptographic hash function which outputs a secp256k1 point,
 // or an error. It passes each candidate x ordinate to ordinates function.
+//
+// It uses LegacyDomainSeparation, matching VRF.sol#hashToCurve.
 func HashToCurve(p kyber.Point, input *big.Int, ordinates func(x *big.Int),
 ) (kyber.Point, error) {
+	return HashToCurveWithDomainSeparation(
+		LegacyDomainSeparation, p, input, ordinates)
+}
+
+// HashToCurveWithDomainSeparation is HashToCurve, with the initial hash
+// preimage tagged as specified by d
+func HashToCurveWithDomainSeparation(d DomainSeparation, p kyber.Point,
+	input *big.Int, ordinates func(x *big.Int)) (kyber.Point, error) {
+	return hashToCurveContext(context.Background(), d, p, input, ordinates)
+}
+
+// hashToCurveContext is HashToCurveWithDomainSeparation, abandoned with ctx's
+// error if ctx is done before one of its iterations
+func hashToCurveContext(ctx context.Context, d DomainSeparation, p kyber.Point,
+	input *big.Int, ordinates func(x *big.Int)) (kyber.Point, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, errors.Wrap(err, "vrf.HashToCurve")
+	}
 	if !(secp256k1.ValidPublicKey(p) && input.BitLen() <= 256 && input.Cmp(zero) >= 0) {
 		return nil, fmt.Errorf("bad input to vrf.HashToCurve")
 	}
-	x := fieldHash(append(hashToCurveHashPrefix, append(secp256k1.LongMarshal(p),
-		uint256ToBytes32(input)...)...))
+	msg, err := d.prefix(hashToCurveHashPrefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "vrf.HashToCurve")
+	}
+	msg = append(msg, secp256k1.LongMarshal(p)...)
+	x, err := fieldHash(append(msg, uint256ToBytes32(input)...))
+	if err != nil {
+		return nil, errors.Wrap(err, "vrf.HashToCurve")
+	}
 	ordinates(x)
 	for !IsCurveXOrdinate(x) { // Hash recursively until x^3+7 is a square
-		x.Set(fieldHash(common.BigToHash(x).Bytes()))
+		if err := ctx.Err(); err != nil {
+			return nil, errors.Wrap(err, "vrf.HashToCurve")
+		}
+		next, err := fieldHash(common.BigToHash(x).Bytes())
+		if err != nil {
+			return nil, errors.Wrap(err, "vrf.HashToCurve")
+		}
+		x.Set(next)
 		ordinates(x)
 	}
 	y := SquareRoot(YSquared(x))
@@ -178,14 +233,27 @@ var scalarFromCurveHashPrefix = common.BigToHash(two).Bytes()
 
 // ScalarFromCurve returns a hash for the curve points. Corresponds to the
 // hash computed in VRF.sol#ScalarFromCurvePoints
+//
+// It uses LegacyDomainSeparation.
 func ScalarFromCurvePoints(
+	hash, pk, gamma kyber.Point, uWitness [20]byte, v kyber.Point) *big.Int {
+	return ScalarFromCurvePointsWithDomainSeparation(
+		LegacyDomainSeparation, hash, pk, gamma, uWitness, v)
+}
+
+// ScalarFromCurvePointsWithDomainSeparation is ScalarFromCurvePoints, with the
+// hash preimage tagged as specified by d
+func ScalarFromCurvePointsWithDomainSeparation(d DomainSeparation,
 	hash, pk, gamma kyber.Point, uWitness [20]byte, v kyber.Point) *big.Int {
 	if !(secp256k1.ValidPublicKey(hash) && secp256k1.ValidPublicKey(pk) &&
 		secp256k1.ValidPublicKey(gamma) && secp256k1.ValidPublicKey(v)) {
 		panic("bad arguments to vrf.ScalarFromCurvePoints")
 	}
-	// msg will contain abi.encodePacked(hash, pk, gamma, v, uWitness)
-	msg := scalarFromCurveHashPrefix
+	// msg will contain tag || abi.encodePacked(prefix, hash, pk, gamma, v, uWitness)
+	msg, err := d.prefix(scalarFromCurveHashPrefix)
+	if err != nil {
+		panic(errors.Wrap(err, "vrf.ScalarFromCurvePoints"))
+	}
 	for _, p := range []kyber.Point{hash, pk, gamma, v} {
 		msg = append(msg, secp256k1.LongMarshal(p)...)
 	}
@@ -214,21 +282,62 @@ type Proof struct {
 	S         *big.Int
 	Seed      *big.Int // Seed input to verifiable random function
 	Output    *big.Int // verifiable random function output;, uniform uint256 sample
+	// Domain-separation scheme the proof was generated under. The zero value,
+	// LegacyDomainSeparation, is the one VRF.sol verifies.
+	DomainSeparation DomainSeparation
 }
 
 func (p *Proof) String() string {
 	return fmt.Sprintf(
-		"vrf.Proof{PublicKey: %s, Gamma: %s, C: %x, S: %x, Seed: %x, Output: %x}",
-		p.PublicKey, p.Gamma, p.C, p.S, p.Seed, p.Output)
+		"vrf.Proof{PublicKey: %s, Gamma: %s, C: %x, S: %x, Seed: %x, Output: %x, DomainSeparation: %s}",
+		p.PublicKey, p.Gamma, p.C, p.S, p.Seed, p.Output, p.DomainSeparation)
 }
 
 // WellFormed is true iff p's attributes satisfy basic domain checks
+//
+// Gamma must lie in the prime-order subgroup, so that it can't leak anything
+// about the secret key via a small-order component. See
+// secp256k1.InPrimeOrderSubgroup.
 func (p *Proof) WellFormed() bool {
 	return (secp256k1.ValidPublicKey(p.PublicKey) &&
-		secp256k1.ValidPublicKey(p.Gamma) && secp256k1.RepresentsScalar(p.C) &&
+		secp256k1.InPrimeOrderSubgroup(p.Gamma) && secp256k1.RepresentsScalar(p.C) &&
 		secp256k1.RepresentsScalar(p.S) && p.Output.BitLen() <= 256)
 }
 
+// OutputInRange maps p.Output to a sample from [0, n), by reducing it mod n.
+//
+// Output is uniform over [0, 2^256), so the result is exactly uniform when n is
+// a power of two. Otherwise, the values below 2^256 mod n are each slightly more
+// likely than the others, but the probability of any value differs from 1/n by
+// less than 1/2^256, which is negligible for any n which fits in 256 bits.
+func (p *Proof) OutputInRange(n *big.Int) (*big.Int, error) {
+	if n == nil || n.Sign() <= 0 {
+		return nil, fmt.Errorf("range size must be positive, got %v", n)
+	}
+	if n.Cmp(lsh(one, 256)) > 0 {
+		return nil, fmt.Errorf("range size %x exceeds the 2^256 possible outputs", n)
+	}
+	if p.Output == nil || p.Output.Sign() < 0 || p.Output.BitLen() > 256 {
+		return nil, fmt.Errorf("proof output %x is not a uint256", p.Output)
+	}
+	return mod(p.Output, n), nil
+}
+
+// PublicKeyToAddress returns the Ethereum address derived from pk, as used to
+// key VRF configuration on-chain. It is an error if pk is not a valid secp256k1
+// public key.
+func PublicKeyToAddress(pk kyber.Point) (common.Address, error) {
+	if pk == nil || !secp256k1.ValidPublicKey(pk) {
+		return common.Address{}, fmt.Errorf("%s is not a valid secp256k1 public key", pk)
+	}
+	return common.Address(secp256k1.EthereumAddress(pk)), nil
+}
+
+// PublicKeyAddress returns the Ethereum address of the key p was generated with
+func (p *Proof) PublicKeyAddress() (common.Address, error) {
+	return PublicKeyToAddress(p.PublicKey)
+}
+
 var ErrCGammaEqualsSHash = fmt.Errorf(
 	"pick a different nonce; c*gamma = s*hash, with this one")
 
@@ -248,13 +357,27 @@ func checkCGammaNotEqualToSHash(c *big.Int, gamma kyber.Point, s *big.Int,
 // compute the final VRF random output
 var vrfRandomOutputHashPrefix = common.BigToHash(three).Bytes()
 
+var (
+	// ErrZeroSecretKey is returned when asked to generate a proof with a zero
+	// secret key, whose public key is the identity. Such a proof would be
+	// meaningless, since anyone could produce it.
+	ErrZeroSecretKey = errors.New("VRF secret key must not be zero")
+	// ErrIdentityPublicKey is returned when verifying a proof whose public key
+	// is the identity, i.e. the point at infinity
+	ErrIdentityPublicKey = errors.New("VRF public key must not be the identity point")
+)
+
 // VerifyProof is true iff gamma was generated in the mandated way from the
 // given publicKey and seed, and no error was encountered
 func (p *Proof) VerifyVRFProof() (bool, error) {
+	if p.PublicKey != nil && secp256k1.IsIdentity(p.PublicKey) {
+		return false, ErrIdentityPublicKey
+	}
 	if !p.WellFormed() {
 		return false, fmt.Errorf("badly-formatted proof")
 	}
-	h, err := HashToCurve(p.PublicKey, p.Seed, func(*big.Int) {})
+	h, err := HashToCurveWithDomainSeparation(
+		p.DomainSeparation, p.PublicKey, p.Seed, func(*big.Int) {})
 	if err != nil {
 		return false, err
 	}
@@ -268,10 +391,28 @@ func (p *Proof) VerifyVRFProof() (bool, error) {
 	// c*secretKey*h + (m - c*secretKey)*h = m*h = v
 	vPrime := linearCombination(p.C, p.Gamma, p.S, h)
 	uWitness := secp256k1.EthereumAddress(uPrime)
-	cPrime := ScalarFromCurvePoints(h, p.PublicKey, p.Gamma, uWitness, vPrime)
-	output := utils.MustHash(string(append(
-		vrfRandomOutputHashPrefix, secp256k1.LongMarshal(p.Gamma)...)))
-	return equal(p.C, cPrime) && equal(p.Output, output.Big()), nil
+	cPrime := ScalarFromCurvePointsWithDomainSeparation(
+		p.DomainSeparation, h, p.PublicKey, p.Gamma, uWitness, vPrime)
+	return equal(p.C, cPrime) && equal(p.Output, outputFromGamma(p.Gamma)), nil
+}
+
+// outputFromGamma returns the VRF output corresponding to gamma, as computed in
+// VRF.sol#randomValueFromVRFProof
+func outputFromGamma(gamma kyber.Point) *big.Int {
+	return utils.MustHash(string(append(vrfRandomOutputHashPrefix,
+		secp256k1.LongMarshal(gamma)...))).Big()
+}
+
+// OutputMatchesGamma is true iff p.Output is the hash of p.Gamma, as mandated
+// for a valid proof. It skips all of the elliptic-curve arithmetic in
+// VerifyVRFProof, so is a cheap pre-filter for proofs with tampered outputs,
+// but a true result says nothing about whether Gamma itself is valid, or even
+// on the curve.
+func (p *Proof) OutputMatchesGamma() (bool, error) {
+	if p.Gamma == nil || p.Output == nil {
+		return false, fmt.Errorf("proof is missing its gamma or output")
+	}
+	return equal(p.Output, outputFromGamma(p.Gamma)), nil
 }
 
 // generateProofWithNonce allows external nonce generation for testing purposes
@@ -280,12 +421,31 @@ func (p *Proof) VerifyVRFProof() (bool, error) {
 // adversary will leak your secret key! Most people should use GenerateProof
 // instead.
 func generateProofWithNonce(secretKey, seed, nonce *big.Int) (*Proof, error) {
-	if !(secp256k1.RepresentsScalar(secretKey) && seed.BitLen() <= 256) {
+	return generateProofWithNonceAndDomainSeparation(
+		LegacyDomainSeparation, secretKey, seed, nonce)
+}
+
+// generateProofWithNonceAndDomainSeparation is generateProofWithNonce, under
+// the domain-separation scheme d
+func generateProofWithNonceAndDomainSeparation(d DomainSeparation,
+	secretKey, seed, nonce *big.Int) (*Proof, error) {
+	return generateProofWithNonceContext(context.Background(), d, secretKey, seed, nonce)
+}
+
+// generateProofWithNonceContext is generateProofWithNonceAndDomainSeparation,
+// abandoned with ctx's error if ctx is done before the proof is complete
+func generateProofWithNonceContext(ctx context.Context, d DomainSeparation,
+	secretKey, seed, nonce *big.Int) (*Proof, error) {
+	if secretKey.Sign() == 0 {
+		return nil, ErrZeroSecretKey
+	}
+	if !(secretKey.Sign() > 0 && secp256k1.RepresentsScalar(secretKey) &&
+		seed.BitLen() <= 256) {
 		return nil, fmt.Errorf("badly-formatted key or seed")
 	}
 	skAsScalar := secp256k1.IntToScalar(secretKey)
 	publicKey := secp256k1Curve.Point().Mul(skAsScalar, nil)
-	h, err := HashToCurve(publicKey, seed, func(*big.Int) {})
+	h, err := hashToCurveContext(ctx, d, publicKey, seed, func(*big.Int) {})
 	if err != nil {
 		return nil, errors.Wrap(err, "vrf.makeProof#HashToCurve")
 	}
@@ -294,21 +454,26 @@ func generateProofWithNonce(secretKey, seed, nonce *big.Int) (*Proof, error) {
 	u := secp256k1Curve.Point().Mul(sm, Generator)
 	uWitness := secp256k1.EthereumAddress(u)
 	v := secp256k1Curve.Point().Mul(sm, h)
-	c := ScalarFromCurvePoints(h, publicKey, gamma, uWitness, v)
-	// (m - c*secretKey) % GroupOrder
-	s := mod(sub(nonce, mul(c, secretKey)), secp256k1.GroupOrder)
+	c := ScalarFromCurvePointsWithDomainSeparation(
+		d, h, publicKey, gamma, uWitness, v)
+	// (m - c*secretKey) % GroupOrder, avoiding variable-time big.Int arithmetic
+	// on the secret key
+	s := ctSubModOrder(nonce, ctMulModOrder(c, secretKey))
 	if e := checkCGammaNotEqualToSHash(c, gamma, s, h); e != nil {
 		return nil, e
 	}
-	outputHash := utils.MustHash(string(append(vrfRandomOutputHashPrefix,
-		secp256k1.LongMarshal(gamma)...)))
 	rv := Proof{
 		PublicKey: publicKey,
 		Gamma:     gamma,
 		C:         c,
 		S:         s,
 		Seed:      seed,
-		Output:    outputHash.Big(),
+		Output:    outputFromGamma(gamma),
+
+		DomainSeparation: d,
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, errors.Wrap(err, "vrf.makeProof")
 	}
 	valid, err := rv.VerifyVRFProof()
 	if !valid || err != nil {
@@ -323,13 +488,41 @@ func generateProofWithNonce(secretKey, seed, nonce *big.Int) (*Proof, error) {
 // secretKey and seed must be less than secp256k1 group order. (Without this
 // constraint on the seed, the samples and the possible public keys would
 // deviate very slightly from uniform distribution.)
+//
+// The proof uses LegacyDomainSeparation, so that it can be verified by VRF.sol.
 func GenerateProof(secretKey, seed common.Hash) (*Proof, error) {
+	return GenerateProofContext(context.Background(), secretKey, seed)
+}
+
+// GenerateProofContext is GenerateProof, returning ctx's error promptly if ctx
+// is done before the proof is complete, e.g. because the job requesting it is
+// shutting down.
+func GenerateProofContext(ctx context.Context, secretKey, seed common.Hash,
+) (*Proof, error) {
+	return generateProofContext(ctx, LegacyDomainSeparation, secretKey, seed)
+}
+
+// GenerateProofWithDomainSeparation is GenerateProof, under the
+// domain-separation scheme d. See DomainSeparation for when to use this.
+func GenerateProofWithDomainSeparation(d DomainSeparation,
+	secretKey, seed common.Hash) (*Proof, error) {
+	return generateProofContext(context.Background(), d, secretKey, seed)
+}
+
+// generateProofContext is GenerateProofWithDomainSeparation, abandoned with
+// ctx's error if ctx is done before the proof is complete
+func generateProofContext(ctx context.Context, d DomainSeparation,
+	secretKey, seed common.Hash) (*Proof, error) {
+	if _, err := d.domainTag(); err != nil {
+		return nil, err
+	}
 	for {
 		nonce, err := rand.Int(rand.Reader, secp256k1.GroupOrder)
 		if err != nil {
 			return nil, err
 		}
-		proof, err := generateProofWithNonce(secretKey.Big(), seed.Big(), nonce)
+		proof, err := generateProofWithNonceContext(
+			ctx, d, secretKey.Big(), seed.Big(), nonce)
 		switch {
 		case err == ErrCGammaEqualsSHash:
 			// This is cryptographically impossible, but if it were ever to happen, we
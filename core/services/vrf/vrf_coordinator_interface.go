@@ -9,8 +9,11 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
 	"github.com/smartcontractkit/chainlink/core/services/vrf/generated/solidity_vrf_coordinator_interface"
 	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"go.dedis.ch/kyber/v3"
 )
 
 // RawRandomnessRequestLog is used to parse a RandomnessRequest log into types
@@ -28,6 +31,16 @@ type RandomnessRequestLog struct {
 	Raw     RawRandomnessRequestLog
 }
 
+// PublicKeyHash returns the identifier the VRFCoordinator uses for the proving
+// key p, keccak256(abi.encodePacked(p)), as computed by its hashOfKey method.
+// Randomness requests carry this hash as their KeyHash.
+func PublicKeyHash(p kyber.Point) (common.Hash, error) {
+	if !secp256k1.ValidPublicKey(p) {
+		return common.Hash{}, errors.Errorf("invalid VRF public key: %s", p)
+	}
+	return utils.MustHash(string(secp256k1.LongMarshal(p))), nil
+}
+
 var dummyCoordinator, _ = solidity_vrf_coordinator_interface.NewVRFCoordinator(
 	common.Address{}, nil)
 
@@ -80,6 +93,36 @@ func (l *RandomnessRequestLog) RequestID() common.Hash {
 	return utils.MustHash(string(append(l.KeyHash[:], soliditySeed...)))
 }
 
+// NextSeed returns the seed the VRFCoordinator passes to the VRF for a
+// randomness request against keyHash from requesterAddress, with the given
+// user-supplied seed, made while the requester's nonce for keyHash is
+// requesterNonce. When each request in a chain uses the output of the previous
+// one as its user-supplied seed, pass that as previousOutput, to compute the
+// seed the next proof must be made against.
+//
+// This is makeVRFInputSeed in VRFRequestIDBase.sol,
+// keccak256(abi.encode(keyHash, previousOutput, requesterAddress, requesterNonce))
+func NextSeed(keyHash common.Hash, previousOutput, requesterNonce *big.Int,
+	requesterAddress common.Address) (*big.Int, error) {
+	if previousOutput == nil || previousOutput.Sign() < 0 {
+		return nil, errors.Errorf("previous output %v is not a uint256", previousOutput)
+	}
+	if requesterNonce == nil || requesterNonce.Sign() < 0 {
+		return nil, errors.Errorf("requester nonce %v is not a uint256", requesterNonce)
+	}
+	userSeed, err := utils.Uint256ToBytes(previousOutput)
+	if err != nil {
+		return nil, errors.Wrapf(err, "previous output %x is not a uint256", previousOutput)
+	}
+	nonce, err := utils.Uint256ToBytes(requesterNonce)
+	if err != nil {
+		return nil, errors.Wrapf(err, "requester nonce %x is not a uint256", requesterNonce)
+	}
+	seed := utils.MustHash(string(append(append(append(
+		keyHash[:], userSeed...), requesterAddress.Hash().Bytes()...), nonce...)))
+	return seed.Big(), nil
+}
+
 func RawRandomnessRequestLogToRandomnessRequestLog(
 	l *RawRandomnessRequestLog) *RandomnessRequestLog {
 	return &RandomnessRequestLog{
@@ -6,6 +6,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
 	"github.com/smartcontractkit/chainlink/core/services/vrf"
 	"github.com/smartcontractkit/chainlink/core/store/models/vrfkey"
 
@@ -49,3 +50,56 @@ func TestVRFParseRandomnessRequestLog(t *testing.T) {
 		"Round-tripping RandomnessRequestLog through serialization and parsing "+
 			"resulted in a different log.")
 }
+
+func TestVRFPublicKeyHash(t *testing.T) {
+	// Captured from VRFCoordinator.hashOfKey, for the public key of secret key
+	// 0xdeadbeef
+	publicKey, err := vrfkey.NewPublicKeyFromHex(
+		"0x76d2fdf1302d1fa9556f4df94ec84cefba6d482e54f47c6c2a238c1baa560f0e00")
+	require.NoError(t, err)
+	expected := common.HexToHash(
+		"0x9b8cd56a75960e7459051a4de8a78b476ae1403b7fd39b662545ae608aced7c7")
+
+	p, err := publicKey.Point()
+	require.NoError(t, err)
+	actual, err := vrf.PublicKeyHash(p)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, expected, publicKey.MustHash())
+
+	_, err = vrf.PublicKeyHash((&secp256k1.Secp256k1{}).Point().Null())
+	assert.Error(t, err, "the identity is not a valid public key")
+}
+
+func TestVRFNextSeed(t *testing.T) {
+	// Captured from the RandomnessRequest logs of two chained requests to
+	// VRFCoordinator, the second using the first's seed as its user seed
+	keyHash := common.HexToHash(
+		"0xc0a6c424ac7157ae408398df7e5f4552091a69125d5dfcb7b8c2659029395bdf")
+	requester := common.HexToAddress("0x58dB9c8E666D257ba0a6670d1b8D86b1F80012b5")
+	first, ok := new(big.Int).SetString(
+		"dc6e0cae01abe2ccfaf327ebbbee7a627c75bb59aca3d4b4d48678b00b5019c9", 16)
+	require.True(t, ok)
+	second, ok := new(big.Int).SetString(
+		"5ff45cd2a67dfa3d8709ce085f01a64d63a64307c23a8dd302bb943ccf9077c6", 16)
+	require.True(t, ok)
+
+	actual, err := vrf.NextSeed(keyHash, big.NewInt(2), big.NewInt(0), requester)
+	require.NoError(t, err)
+	assert.Equal(t, first, actual)
+	actual, err = vrf.NextSeed(keyHash, first, big.NewInt(1), requester)
+	require.NoError(t, err)
+	assert.Equal(t, second, actual)
+
+	tooBig := new(big.Int).Lsh(big.NewInt(1), 256)
+	for _, args := range [][2]*big.Int{
+		{big.NewInt(-1), big.NewInt(0)},
+		{tooBig, big.NewInt(0)},
+		{big.NewInt(2), tooBig},
+		{nil, big.NewInt(0)},
+		{big.NewInt(2), nil},
+	} {
+		_, err := vrf.NextSeed(keyHash, args[0], args[1], requester)
+		assert.Error(t, err, "%v", args)
+	}
+}
@@ -197,6 +197,32 @@ func requestRandomness(t *testing.T, coordinator coordinator,
 		(*RawRandomnessRequestLog)(log.Event))
 }
 
+func TestNextSeedMatchesCoordinatorForChainedRequests(t *testing.T) {
+	coord := deployCoordinator(t)
+	keyHash_, _, fee := registerProvingKey(t, coord)
+	keyHash := common.BytesToHash(keyHash_[:])
+
+	userSeed := seed
+	for nonce := int64(0); nonce < 3; nonce++ {
+		_, err := coord.consumerContract.RequestRandomness(coord.carol, keyHash, fee, userSeed)
+		require.NoError(t, err, "problem during chained VRF randomness request")
+		coord.backend.Commit()
+		logs, err := coord.rootContract.FilterRandomnessRequest(nil, nil)
+		require.NoError(t, err, "failed to subscribe to RandomnessRequest logs")
+		var log *RandomnessRequestLog
+		for logs.Next() {
+			log = RawRandomnessRequestLogToRandomnessRequestLog((*RawRandomnessRequestLog)(logs.Event))
+		}
+		require.NotNil(t, log)
+
+		expected, err := NextSeed(keyHash, userSeed, big.NewInt(nonce), coord.consumerContractAddress)
+		require.NoError(t, err)
+		assert.True(t, equal(expected, log.Seed), "NextSeed differs from VRFCoordinator's seed for request %d", nonce)
+		// Chain the next request from this one
+		userSeed = log.Seed
+	}
+}
+
 func TestRandomnessRequestLog(t *testing.T) {
 	coord := deployCoordinator(t)
 	keyHash_, jobID_, fee := registerProvingKey(t, coord)
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
@@ -27,4 +28,8 @@ func TestMeasureRandomValueFromVRFProofGasCost(t *testing.T) {
 
 	require.NoError(t, err, "failed to estimate gas cost for VRF verification")
 	require.Less(t, estimate, uint64(100000))
+	assert.LessOrEqual(t, estimate, proof.EstimatedVerificationGas(),
+		"EstimatedVerificationGas underestimates on-chain verification cost")
+	assert.Greater(t, estimate, proof.EstimatedVerificationGas()*49/50,
+		"EstimatedVerificationGas overestimates on-chain verification cost by more than 2%")
 }
@@ -141,7 +141,8 @@ func TestVRF_CompareFieldHash(t *testing.T) {
 		require.NoError(t, err, "failed to randomize intended hash message")
 		actual, err := deployVRFTestHelper(t).FieldHash(nil, msg)
 		require.NoError(t, err, "failed to compute fieldHash on-chain")
-		expected := fieldHash(msg)
+		expected, err := fieldHash(msg)
+		require.NoError(t, err)
 		require.Equal(t, expected, actual,
 			"fieldHash value on-chain differs from off-chain")
 	}
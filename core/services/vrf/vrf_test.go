@@ -1,10 +1,17 @@
 package vrf
 
 import (
+	"context"
+	"encoding/json"
 	"math/big"
 	"testing"
 
+	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestVRF_IsSquare(t *testing.T) {
@@ -25,3 +32,422 @@ func TestVRF_IsCurveXOrdinate(t *testing.T) {
 	assert.True(t, IsCurveXOrdinate(big.NewInt(1)))
 	assert.False(t, IsCurveXOrdinate(big.NewInt(5)))
 }
+
+func TestVRF_ValidateCurveParams(t *testing.T) {
+	require.NoError(t, ValidateCurveParams())
+	assert.Equal(t, fieldSize, FieldSize())
+	assert.Equal(t, secp256k1.GroupOrder, GroupOrder())
+
+	// Accessors must return copies, so callers can't corrupt package state
+	FieldSize().Add(FieldSize(), one)
+	require.NoError(t, ValidateCurveParams())
+}
+
+func TestVRF_CurveParams_ValidateCatchesCorruption(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(*CurveParams)
+	}{
+		{"nil field size", func(c *CurveParams) { c.FieldSize = nil }},
+		{"composite field size", func(c *CurveParams) { c.FieldSize.Add(c.FieldSize, one) }},
+		{"field size 1 mod 4", func(c *CurveParams) { c.FieldSize.SetInt64(13) }},
+		{"wrong euler power", func(c *CurveParams) { c.EulersCriterionPower.Add(c.EulersCriterionPower, one) }},
+		{"wrong sqrt power", func(c *CurveParams) { c.SqrtPower.Sub(c.SqrtPower, one) }},
+		{"wrong group order", func(c *CurveParams) { c.GroupOrder.Sub(c.GroupOrder, two) }},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			params := Params()
+			test.corrupt(&params)
+			assert.Error(t, params.Validate())
+			require.NoError(t, ValidateCurveParams())
+		})
+	}
+}
+
+func TestVRF_OutputInRange(t *testing.T) {
+	secretKey := big.NewInt(0x1337)
+	proofForSeed := func(seed int64) *Proof {
+		proof, err := generateProofWithNonce(secretKey, big.NewInt(seed), one)
+		require.NoError(t, err)
+		return proof
+	}
+	proof := proofForSeed(1)
+
+	t.Run("n=1", func(t *testing.T) {
+		for seed := int64(0); seed < 10; seed++ {
+			out, err := proofForSeed(seed).OutputInRange(one)
+			require.NoError(t, err)
+			assert.Equal(t, "0", out.String())
+		}
+	})
+
+	t.Run("power of two", func(t *testing.T) {
+		n := lsh(one, 64)
+		out, err := proof.OutputInRange(n)
+		require.NoError(t, err)
+		// Reducing mod 2^64 just keeps the bottom 64 bits
+		assert.Equal(t, i().And(proof.Output, sub(n, one)).String(), out.String())
+
+		out, err = proof.OutputInRange(lsh(one, 256))
+		require.NoError(t, err)
+		assert.Equal(t, proof.Output.String(), out.String())
+	})
+
+	t.Run("non-power of two is evenly distributed", func(t *testing.T) {
+		const samples, buckets = 600, 6
+		n := big.NewInt(buckets)
+		counts := make([]int64, buckets)
+		for seed := int64(0); seed < samples; seed++ {
+			out, err := proofForSeed(seed).OutputInRange(n)
+			require.NoError(t, err)
+			require.True(t, out.Sign() >= 0 && out.Cmp(n) < 0, "%s out of range", out)
+			counts[out.Int64()]++
+		}
+		// Pearson's chi-squared statistic, with 5 degrees of freedom. Exceeding
+		// 20.5 has probability 0.001 for a uniform distribution. The seeds are
+		// fixed, so this test is deterministic.
+		expected := float64(samples) / buckets
+		var chiSquared float64
+		for _, count := range counts {
+			chiSquared += (float64(count) - expected) * (float64(count) - expected) / expected
+		}
+		assert.True(t, chiSquared < 20.5, "counts %v are not uniform: χ²=%f", counts, chiSquared)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		for _, n := range []*big.Int{nil, big.NewInt(0), big.NewInt(-3), add(lsh(one, 256), one)} {
+			_, err := proof.OutputInRange(n)
+			assert.Error(t, err, "range %v", n)
+		}
+	})
+}
+
+func TestVRF_Proof_MarshalBinary(t *testing.T) {
+	proof, err := generateProofWithNonce(big.NewInt(0x1337), big.NewInt(42), one)
+	require.NoError(t, err)
+
+	data, err := proof.MarshalBinary()
+	require.NoError(t, err)
+	require.Len(t, data, BinaryProofLength)
+
+	var decoded Proof
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, proof.String(), decoded.String())
+	valid, err := decoded.VerifyVRFProof()
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	jsonProof, err := json.Marshal(proof)
+	require.NoError(t, err)
+	// JSON drops the point coordinates entirely, and is still much longer
+	assert.True(t, 3*len(data) < 2*len(jsonProof),
+		"binary proof is %d bytes, JSON proof is %d bytes", len(data), len(jsonProof))
+	assert.True(t, len(data) < ProofLength)
+
+	t.Run("rejects truncated input", func(t *testing.T) {
+		var truncated Proof
+		assert.Error(t, truncated.UnmarshalBinary(data[:len(data)-1]))
+		assert.Error(t, truncated.UnmarshalBinary(nil))
+		assert.Error(t, truncated.UnmarshalBinary(append(data, 0)))
+	})
+
+	t.Run("rejects invalid points", func(t *testing.T) {
+		corrupted := append([]byte{}, data...)
+		corrupted[32] = 2 // Bad sign byte on public key
+		var invalid Proof
+		assert.Error(t, invalid.UnmarshalBinary(corrupted))
+	})
+}
+
+func TestVRF_RejectsZeroSecretKey(t *testing.T) {
+	_, err := GenerateProof(common.Hash{}, common.BigToHash(big.NewInt(42)))
+	assert.Equal(t, ErrZeroSecretKey, err)
+
+	_, err = generateProofWithNonce(big.NewInt(0), big.NewInt(42), one)
+	assert.Equal(t, ErrZeroSecretKey, err)
+}
+
+func TestVRF_RejectsIdentityPublicKey(t *testing.T) {
+	proof, err := generateProofWithNonce(big.NewInt(0x1337), big.NewInt(42), one)
+	require.NoError(t, err)
+	proof.PublicKey = secp256k1Curve.Point().Null()
+
+	assert.False(t, proof.WellFormed())
+	valid, err := proof.VerifyVRFProof()
+	assert.False(t, valid)
+	assert.Equal(t, ErrIdentityPublicKey, err)
+	assert.Contains(t, err.Error(), "identity")
+}
+
+func TestVRF_Proof_OutputMatchesGamma(t *testing.T) {
+	proof, err := generateProofWithNonce(big.NewInt(0x1337), big.NewInt(42), one)
+	require.NoError(t, err)
+
+	matches, err := proof.OutputMatchesGamma()
+	require.NoError(t, err)
+	assert.True(t, matches)
+
+	tampered := *proof
+	tampered.Output = add(proof.Output, one)
+	matches, err = tampered.OutputMatchesGamma()
+	require.NoError(t, err)
+	assert.False(t, matches)
+	valid, err := tampered.VerifyVRFProof()
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	tampered.Output = nil
+	_, err = tampered.OutputMatchesGamma()
+	assert.Error(t, err)
+}
+
+func TestVRF_PublicKeyToAddress(t *testing.T) {
+	// The address of secret key 1, i.e. of the generator, is a well-known value
+	generator := secp256k1Curve.Point().Base()
+	address, err := PublicKeyToAddress(generator)
+	require.NoError(t, err)
+	assert.Equal(t,
+		common.HexToAddress("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"), address)
+
+	proof, err := generateProofWithNonce(big.NewInt(1), big.NewInt(42), one)
+	require.NoError(t, err)
+	proofAddress, err := proof.PublicKeyAddress()
+	require.NoError(t, err)
+	assert.Equal(t, address, proofAddress)
+
+	_, err = PublicKeyToAddress(secp256k1Curve.Point().Null())
+	assert.Error(t, err)
+	_, err = PublicKeyToAddress(nil)
+	assert.Error(t, err)
+	proof.PublicKey = nil
+	_, err = proof.PublicKeyAddress()
+	assert.Error(t, err)
+}
+
+// TestVRF_GenerateMarshalVerify runs a fixed key and seed through the whole
+// pipeline a VRF request goes through: deriving the public key, generating the
+// proof, marshaling it for the solidity verifier, and parsing and verifying it
+// again, checking each intermediate artifact along the way.
+func TestVRF_GenerateMarshalVerify(t *testing.T) {
+	secretKey := common.HexToHash("0x1337")
+	seed := common.HexToHash("0x2a")
+
+	// Key
+	publicKey := secp256k1Curve.Point().Mul(secp256k1.IntToScalar(secretKey.Big()), nil)
+	require.True(t, secp256k1.ValidPublicKey(publicKey))
+	address, err := PublicKeyToAddress(publicKey)
+	require.NoError(t, err)
+
+	// Proof
+	proof, err := GenerateProof(secretKey, seed)
+	require.NoError(t, err)
+	assert.True(t, proof.PublicKey.Equal(publicKey))
+	assert.Equal(t, seed.Big(), proof.Seed)
+	assert.Equal(t, LegacyDomainSeparation, proof.DomainSeparation)
+	require.True(t, proof.WellFormed(), proof.String())
+	valid, err := proof.VerifyVRFProof()
+	require.NoError(t, err)
+	require.True(t, valid, proof.String())
+	matches, err := proof.OutputMatchesGamma()
+	require.NoError(t, err)
+	assert.True(t, matches)
+	proofAddress, err := proof.PublicKeyAddress()
+	require.NoError(t, err)
+	assert.Equal(t, address, proofAddress)
+
+	// Solidity precalculations
+	solidityProof, err := proof.SolidityPrecalculations()
+	require.NoError(t, err)
+	assert.Equal(t, proof, solidityProof.P)
+	c, s := secp256k1.IntToScalar(proof.C), secp256k1.IntToScalar(proof.S)
+	u := point().Add(point().Mul(c, publicKey), point().Mul(s, Generator))
+	assert.Equal(t, common.Address(secp256k1.EthereumAddress(u)), solidityProof.UWitness)
+	assert.True(t, solidityProof.CGammaWitness.Equal(point().Mul(c, proof.Gamma)))
+	hash, err := HashToCurve(publicKey, proof.Seed, func(*big.Int) {})
+	require.NoError(t, err)
+	assert.True(t, solidityProof.SHashWitness.Equal(point().Mul(s, hash)))
+	_, _, z := ProjectiveECAdd(solidityProof.CGammaWitness, solidityProof.SHashWitness)
+	assert.Equal(t, one, mod(mul(z, solidityProof.ZInv), fieldSize))
+
+	// Marshaled proof, laid out as VRF.sol's randomValueFromVRFProof expects
+	marshaled, err := proof.MarshalForSolidityVerifier()
+	require.NoError(t, err)
+	require.Len(t, marshaled, ProofLength)
+	assert.Equal(t, 416, ProofLength)
+	assert.Equal(t, secp256k1.LongMarshal(publicKey), marshaled[0:64])
+	assert.Equal(t, secp256k1.LongMarshal(proof.Gamma), marshaled[64:128])
+	assert.Equal(t, common.BigToHash(proof.C).Bytes(), marshaled[128:160])
+	assert.Equal(t, common.BigToHash(proof.S).Bytes(), marshaled[160:192])
+	assert.Equal(t, seed.Bytes(), marshaled[192:224])
+	assert.Equal(t, make([]byte, 12), marshaled[224:236])
+	assert.Equal(t, solidityProof.UWitness.Bytes(), marshaled[236:256])
+	assert.Equal(t, secp256k1.LongMarshal(solidityProof.CGammaWitness), marshaled[256:320])
+	assert.Equal(t, secp256k1.LongMarshal(solidityProof.SHashWitness), marshaled[320:384])
+	assert.Equal(t, common.BigToHash(solidityProof.ZInv).Bytes(), marshaled[384:416])
+
+	// Parsed and re-verified proof
+	parsed, err := UnmarshalSolidityProof(marshaled[:])
+	require.NoError(t, err)
+	assert.True(t, parsed.PublicKey.Equal(proof.PublicKey))
+	assert.True(t, parsed.Gamma.Equal(proof.Gamma))
+	assert.Equal(t, proof.C, parsed.C)
+	assert.Equal(t, proof.S, parsed.S)
+	assert.Equal(t, proof.Seed, parsed.Seed)
+	assert.Equal(t, proof.Output, parsed.Output)
+	assert.Equal(t, LegacyDomainSeparation, parsed.DomainSeparation)
+	valid, err = parsed.VerifyVRFProof()
+	require.NoError(t, err)
+	assert.True(t, valid, parsed.String())
+
+	// A corrupted proof parses, but doesn't verify
+	marshaled[128] ^= 1
+	corrupted, err := UnmarshalSolidityProof(marshaled[:])
+	require.NoError(t, err)
+	valid, err = corrupted.VerifyVRFProof()
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
+// setKeccak256 replaces the hash fieldHash iterates, and returns a function
+// restoring it. It must not be used from parallel tests.
+func setKeccak256(hash func(string) common.Hash) (restore func()) {
+	previous := keccak256
+	keccak256 = hash
+	return func() { keccak256 = previous }
+}
+
+func TestVRF_GenerateProofContext(t *testing.T) {
+	secretKey, seed := common.BigToHash(big.NewInt(1)), common.BigToHash(big.NewInt(2))
+	proof, err := GenerateProofContext(context.Background(), secretKey, seed)
+	require.NoError(t, err)
+	valid, err := proof.VerifyVRFProof()
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = GenerateProofContext(ctx, secretKey, seed)
+	require.Error(t, err)
+	assert.Equal(t, context.Canceled, errors.Cause(err))
+
+	_, err = hashToCurveContext(ctx, LegacyDomainSeparation, Generator, big.NewInt(1), func(*big.Int) {})
+	assert.Equal(t, context.Canceled, errors.Cause(err))
+}
+
+func TestVRF_FieldHash_RehashesUntilInField(t *testing.T) {
+	outOfField := common.BigToHash(fieldSize)
+	calls := 0
+	defer setKeccak256(func(string) common.Hash {
+		calls++
+		if calls <= 3 {
+			return outOfField
+		}
+		return common.BigToHash(big.NewInt(42))
+	})()
+
+	x, err := fieldHash([]byte("input"))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), x)
+	assert.Equal(t, 4, calls)
+}
+
+func TestVRF_FieldHash_BoundsRehashes(t *testing.T) {
+	calls := 0
+	defer setKeccak256(func(string) common.Hash {
+		calls++
+		return common.BigToHash(fieldSize)
+	})()
+
+	_, err := fieldHash([]byte("input"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rehashes")
+	assert.Equal(t, maxFieldHashIterations+1, calls)
+
+	_, err = HashToCurve(Generator, big.NewInt(42), func(*big.Int) {})
+	require.Error(t, err)
+}
+
+func TestVRF_Proof_EstimatedVerificationGas(t *testing.T) {
+	secretKey := big.NewInt(0x1337)
+	publicKey := secp256k1Curve.Point().Mul(secp256k1.IntToScalar(secretKey), Generator)
+	// Find seeds whose hash-to-curve points take one and several iterations
+	seedWithIterations := func(want func(iterations int) bool) *big.Int {
+		for seed := int64(0); ; seed++ {
+			iterations := 0
+			_, err := HashToCurve(publicKey, big.NewInt(seed), func(*big.Int) { iterations++ })
+			require.NoError(t, err)
+			if want(iterations) {
+				return big.NewInt(seed)
+			}
+		}
+	}
+	easySeed := seedWithIterations(func(iterations int) bool { return iterations == 1 })
+	hardSeed := seedWithIterations(func(iterations int) bool { return iterations > 2 })
+
+	easy, err := generateProofWithNonce(secretKey, easySeed, one)
+	require.NoError(t, err)
+	hard, err := generateProofWithNonce(secretKey, hardSeed, one)
+	require.NoError(t, err)
+	assert.Greater(t, hard.EstimatedVerificationGas(), easy.EstimatedVerificationGas())
+
+	// A malformed proof is estimated as needing a single iteration
+	malformed := *hard
+	malformed.Seed = nil
+	assert.Equal(t, easy.EstimatedVerificationGas(), malformed.EstimatedVerificationGas())
+}
+
+func TestVRF_PackUint256s_MatchesUint256ToBytes32(t *testing.T) {
+	values := []*big.Int{zero, one, two, big.NewInt(255), big.NewInt(256),
+		secp256k1.GroupOrder, fieldSize, maxWord256}
+	for i := 0; i < 100; i++ {
+		values = append(values, randomWord256(t))
+	}
+	for _, x := range values {
+		packed, err := packUint256s(x)
+		require.NoError(t, err)
+		assert.Equal(t, uint256ToBytes32(x), packed, "%x", x)
+		assert.Len(t, packed, 32)
+		// Big-endian: the last byte is the least significant
+		assert.Equal(t, byte(x.Uint64()), packed[31], "%x", x)
+		assert.Equal(t, 0, x.Cmp(i().SetBytes(packed)), "%x", x)
+	}
+
+	multiple, err := packUint256s(one, maxWord256)
+	require.NoError(t, err)
+	assert.Equal(t, append(uint256ToBytes32(one), uint256ToBytes32(maxWord256)...), multiple)
+
+	for _, invalid := range []*big.Int{big.NewInt(-1), add(maxWord256, one)} {
+		_, err := packUint256s(invalid)
+		assert.Error(t, err, "%x", invalid)
+		assert.Panics(t, func() { uint256ToBytes32(invalid) }, "%x", invalid)
+	}
+}
+
+func TestVRF_Proof_MarshaledLengthsMatchConstants(t *testing.T) {
+	assert.Equal(t, 194, ProofMarshalLen)
+	assert.Equal(t, 416, ProofSolidityMarshalLen)
+
+	for n := int64(1); n <= 20; n++ {
+		sk := i().Add(big.NewInt(0x1337), big.NewInt(n*0x1001))
+		proof, err := generateProofWithNonce(sk, big.NewInt(n), big.NewInt(n))
+		require.NoError(t, err)
+
+		binary, err := proof.MarshalBinary()
+		require.NoError(t, err)
+		require.Len(t, binary, ProofMarshalLen)
+		solidity, err := proof.MarshalForSolidityVerifier()
+		require.NoError(t, err)
+		require.Len(t, solidity[:], ProofSolidityMarshalLen)
+
+		for _, length := range []int{0, ProofMarshalLen - 1, ProofMarshalLen + 1} {
+			var decoded Proof
+			assert.Error(t, decoded.UnmarshalBinary(make([]byte, length)), "length %d", length)
+		}
+		for _, length := range []int{0, ProofSolidityMarshalLen - 1, ProofSolidityMarshalLen + 1} {
+			_, err := UnmarshalSolidityProof(make([]byte, length))
+			assert.Error(t, err, "length %d", length)
+		}
+	}
+}
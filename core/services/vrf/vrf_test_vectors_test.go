@@ -0,0 +1,123 @@
+package vrf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
+)
+
+// proofTestVector is a VRF proof which was generated by generateProofWithNonce
+// and accepted by the solidity verifier at the time it was recorded. Points are
+// in secp256k1.LongMarshal form, and scalars are 32-byte big-endian words.
+type proofTestVector struct {
+	SecretKey     hexutil.Bytes `json:"secretKey"`
+	Seed          hexutil.Bytes `json:"seed"`
+	Nonce         hexutil.Bytes `json:"nonce"`
+	PublicKey     hexutil.Bytes `json:"publicKey"`
+	Gamma         hexutil.Bytes `json:"gamma"`
+	C             hexutil.Bytes `json:"c"`
+	S             hexutil.Bytes `json:"s"`
+	Output        hexutil.Bytes `json:"output"`
+	SolidityProof hexutil.Bytes `json:"solidityProof"`
+}
+
+func loadProofTestVectors(t *testing.T) []proofTestVector {
+	raw, err := ioutil.ReadFile("testdata/proof_vectors.json")
+	require.NoError(t, err)
+	var vectors []proofTestVector
+	require.NoError(t, json.Unmarshal(raw, &vectors))
+	require.NotEmpty(t, vectors)
+	return vectors
+}
+
+// TestVRF_ProofTestVectors regenerates the recorded proofs, so that any change
+// to the hashing or packing of proofs shows up as a mismatch here
+func TestVRF_ProofTestVectors(t *testing.T) {
+	for j, vector := range loadProofTestVectors(t) {
+		vector := vector
+		t.Run(fmt.Sprintf("vector %d, seed %x", j, i().SetBytes(vector.Seed)), func(t *testing.T) {
+			proof, err := generateProofWithNonce(i().SetBytes(vector.SecretKey),
+				i().SetBytes(vector.Seed), i().SetBytes(vector.Nonce))
+			require.NoError(t, err)
+
+			assert.Equal(t, vector.PublicKey, hexutil.Bytes(secp256k1.LongMarshal(proof.PublicKey)), "public key")
+			assert.Equal(t, vector.Gamma, hexutil.Bytes(secp256k1.LongMarshal(proof.Gamma)), "gamma")
+			assert.Equal(t, vector.C, hexutil.Bytes(uint256ToBytes32(proof.C)), "c")
+			assert.Equal(t, vector.S, hexutil.Bytes(uint256ToBytes32(proof.S)), "s")
+			assert.Equal(t, vector.Output, hexutil.Bytes(uint256ToBytes32(proof.Output)), "output")
+
+			marshaled, err := proof.MarshalForSolidityVerifier()
+			require.NoError(t, err)
+			assert.Equal(t, vector.SolidityProof, hexutil.Bytes(marshaled[:]), "solidity proof")
+		})
+	}
+}
+
+// TestVRF_ProofTestVectorsAgainstVerifier checks that the solidity verifier
+// still accepts the recorded proofs, and computes the recorded outputs
+func TestVRF_ProofTestVectorsAgainstVerifier(t *testing.T) {
+	verifier := deployVRFTestHelper(t)
+	for j, vector := range loadProofTestVectors(t) {
+		output, err := verifier.RandomValueFromVRFProof(nil, vector.SolidityProof)
+		require.NoError(t, err, "verifier rejected vector %d", j)
+		assert.Equal(t, vector.Output, hexutil.Bytes(uint256ToBytes32(output)),
+			"verifier output differs for vector %d", j)
+	}
+}
+
+func TestVRF_ProofFromHexFields(t *testing.T) {
+	for j, vector := range loadProofTestVectors(t) {
+		proof, err := ProofFromHexFields(vector.PublicKey.String(), vector.Gamma.String(),
+			vector.C.String(), vector.S.String(), vector.Seed.String(), vector.Output.String())
+		require.NoError(t, err, "vector %d", j)
+		valid, err := proof.VerifyVRFProof()
+		require.NoError(t, err)
+		assert.True(t, valid, "vector %d", j)
+
+		// Compressed points are accepted too
+		compressedGamma, err := secp256k1.ExportPointHex(proof.Gamma)
+		require.NoError(t, err)
+		fromCompressed, err := ProofFromHexFields(vector.PublicKey.String(), compressedGamma,
+			vector.C.String(), vector.S.String(), vector.Seed.String(), vector.Output.String())
+		require.NoError(t, err)
+		assert.Equal(t, proof, fromCompressed)
+	}
+}
+
+func TestVRF_ProofFromHexFields_RejectsMalformedFields(t *testing.T) {
+	vector := loadProofTestVectors(t)[0]
+	fields := func() []string {
+		return []string{vector.PublicKey.String(), vector.Gamma.String(),
+			vector.C.String(), vector.S.String(), vector.Seed.String(), vector.Output.String()}
+	}
+	parse := func(f []string) error {
+		_, err := ProofFromHexFields(f[0], f[1], f[2], f[3], f[4], f[5])
+		return err
+	}
+	require.NoError(t, parse(fields()))
+
+	tests := []struct {
+		name  string
+		field int
+		value string
+	}{
+		{"public key not hex", 0, "not hex"},
+		{"public key off the curve", 0, hexutil.Encode(make([]byte, 64))},
+		{"gamma of the wrong length", 1, "0x1234"},
+		{"c longer than a uint256", 2, hexutil.Encode(make([]byte, 33))},
+		{"s not a scalar", 3, hexutil.Encode(uint256ToBytes32(secp256k1.GroupOrder))},
+		{"seed missing 0x prefix", 4, "1234"},
+	}
+	for _, test := range tests {
+		f := fields()
+		f[test.field] = test.value
+		assert.Error(t, parse(f), test.name)
+	}
+}
@@ -42,6 +42,12 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1587027516"
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1587580235"
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1587975059"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1588088353"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1588293486"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1588385384"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1588469451"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1588557854"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1588630583"
 	
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
@@ -215,6 +221,30 @@ func MigrateTo(db *gorm.DB, migrationID string) error {
 			ID: "1587975059",
 			Migrate: migration1587975059.Migrate,
 		},
+		{
+			ID:      "1588088353",
+			Migrate: migration1588088353.Migrate,
+		},
+		{
+			ID:      "1588293486",
+			Migrate: migration1588293486.Migrate,
+		},
+		{
+			ID:      "1588385384",
+			Migrate: migration1588385384.Migrate,
+		},
+		{
+			ID:      "1588469451",
+			Migrate: migration1588469451.Migrate,
+		},
+		{
+			ID:      "1588557854",
+			Migrate: migration1588557854.Migrate,
+		},
+		{
+			ID:      "1588630583",
+			Migrate: migration1588630583.Migrate,
+		},
 	}
 
 	m := gormigrate.New(db, &options, migrations)
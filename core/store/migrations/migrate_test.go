@@ -357,6 +357,41 @@ func TestMigrate_Migration1586369235(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMigrate_Migration1588385384(t *testing.T) {
+	orm, cleanup := bootstrapORM(t)
+	defer cleanup()
+
+	err := orm.RawDB(func(db *gorm.DB) error {
+		require.NoError(t, migrations.MigrateTo(db, "1588293486"))
+
+		blockHash := cltest.NewHash()
+		consumerID := models.NewID()
+		require.NoError(t, db.Exec(`
+			INSERT INTO log_consumptions (id, block_hash, log_index, consumer_type, consumer_id, created_at)
+			VALUES (?, ?, 0, ?, ?, NOW())`,
+			models.NewID(), blockHash, models.LogConsumerTypeJob, consumerID).Error)
+
+		require.NoError(t, migrations.MigrateTo(db, "1588385384"))
+
+		// The chain an existing consumption was recorded on isn't known, so
+		// it's migrated to chain id zero, and still suppresses the log on
+		// every chain
+		for _, chainID := range []int64{1, 42} {
+			exists, err := orm.LogConsumptionExists(&models.LogConsumption{
+				BlockHash:    blockHash,
+				LogIndex:     0,
+				ConsumerType: models.LogConsumerTypeJob,
+				ConsumerID:   consumerID,
+				ChainID:      utils.NewBig(big.NewInt(chainID)),
+			})
+			require.NoError(t, err)
+			assert.True(t, exists, "chain %d", chainID)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
 func TestMigrate_NewerVersionGuard(t *testing.T) {
 	orm, cleanup := bootstrapORM(t)
 	defer cleanup()
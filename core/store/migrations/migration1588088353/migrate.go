@@ -0,0 +1,13 @@
+package migration1588088353
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the addresses column to initiators, so that a flux monitor
+// initiator can submit to several aggregator contracts
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+	ALTER TABLE initiators ADD COLUMN addresses text NOT NULL DEFAULT '';
+	`).Error
+}
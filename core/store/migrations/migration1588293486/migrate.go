@@ -0,0 +1,13 @@
+package migration1588293486
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the absolute_threshold column to initiators, so that a flux
+// monitor initiator can submit on an absolute change in the answer
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+	ALTER TABLE initiators ADD COLUMN "absolute_threshold" float;
+	`).Error
+}
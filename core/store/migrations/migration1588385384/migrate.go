@@ -0,0 +1,17 @@
+package migration1588385384
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the chain_id column to log_consumptions, and includes it in
+// their uniqueness, so that a log consumed on one chain isn't taken to have
+// been consumed on another.  Existing consumptions get chain id zero, as the
+// chain they were recorded on isn't known.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+	ALTER TABLE log_consumptions ADD COLUMN "chain_id" numeric(78, 0) NOT NULL DEFAULT 0;
+	DROP INDEX idx_unique_log_consumption;
+	CREATE UNIQUE INDEX idx_unique_log_consumption ON log_consumptions (block_hash, consumer_type, consumer_id, log_index, chain_id);
+	`).Error
+}
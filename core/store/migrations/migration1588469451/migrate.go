@@ -0,0 +1,13 @@
+package migration1588469451
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the submission_method column to initiators, so that a flux
+// monitor initiator can name the aggregator method it submits answers with
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+	ALTER TABLE initiators ADD COLUMN submission_method text NOT NULL DEFAULT '';
+	`).Error
+}
@@ -0,0 +1,13 @@
+package migration1588557854
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the answer_transforms column to initiators, holding the
+// transforms a flux monitor initiator applies to its polled answers
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+	ALTER TABLE initiators ADD COLUMN answer_transforms text;
+	`).Error
+}
@@ -0,0 +1,14 @@
+package migration1588630583
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the flags_address column to initiators, holding the Flags
+// contract a flux monitor initiator watches for its aggregators being flagged.
+// Existing initiators get the zero address, which watches no contract.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+	ALTER TABLE initiators ADD COLUMN flags_address bytea NOT NULL DEFAULT '\x0000000000000000000000000000000000000000';
+	`).Error
+}
@@ -210,18 +210,31 @@ type InitiatorParams struct {
 	Ran        bool              `json:"ran,omitempty"`
 	Address    common.Address    `json:"address,omitempty" gorm:"index"`
 	Requesters AddressCollection `json:"requesters,omitempty" gorm:"type:text"`
+	Addresses  AddressCollection `json:"addresses,omitempty" gorm:"type:text"`
 	Name       string            `json:"name,omitempty"`
 	Body       *JSON             `json:"body,omitempty" gorm:"column:params"`
 	FromBlock  *utils.Big        `json:"fromBlock,omitempty" gorm:"type:varchar(255)"`
 	ToBlock    *utils.Big        `json:"toBlock,omitempty" gorm:"type:varchar(255)"`
 	Topics     Topics            `json:"topics,omitempty"`
 
-	RequestData     JSON     `json:"requestData,omitempty" gorm:"type:text"`
-	IdleThreshold   Duration `json:"idleThreshold,omitempty"`
-	Feeds           Feeds    `json:"feeds,omitempty" gorm:"type:text"`
-	Threshold       float32  `json:"threshold,omitempty" gorm:"type:float"`
-	Precision       int32    `json:"precision,omitempty" gorm:"type:smallint"`
-	PollingInterval Duration `json:"pollingInterval,omitempty"`
+	RequestData       JSON     `json:"requestData,omitempty" gorm:"type:text"`
+	IdleThreshold     Duration `json:"idleThreshold,omitempty"`
+	Feeds             Feeds    `json:"feeds,omitempty" gorm:"type:text"`
+	Threshold         float32  `json:"threshold,omitempty" gorm:"type:float"`
+	AbsoluteThreshold float32  `json:"absoluteThreshold,omitempty" gorm:"type:float"`
+	Precision         int32    `json:"precision,omitempty" gorm:"type:smallint"`
+	PollingInterval   Duration `json:"pollingInterval,omitempty"`
+	// SubmissionMethod is the name of the aggregator method a Flux Monitor
+	// job submits its answers with, for aggregator versions which don't name
+	// it submit.  If empty, submit is used.
+	SubmissionMethod string `json:"submissionMethod,omitempty"`
+	// AnswerTransforms are applied, in order, to a Flux Monitor job's polled
+	// answer before it's compared with the aggregator's and submitted
+	AnswerTransforms AnswerTransforms `json:"answerTransforms,omitempty" gorm:"type:text"`
+	// FlagsAddress is the Flags contract a Flux Monitor job watches.  While
+	// the flag of one of its aggregators is raised, the job doesn't submit to
+	// it.  If zero, no Flags contract is watched.
+	FlagsAddress common.Address `json:"flagsAddress,omitempty"`
 }
 
 // defaults represents a default value for an initiator parameter. Value should
@@ -255,6 +268,21 @@ func (i *InitiatorParams) SetDefaultValues(typ string) {
 	}
 }
 
+// AggregatorAddresses returns the contract addresses a Flux Monitor initiator
+// submits to: Address, followed by any further distinct entries in Addresses.
+func (i InitiatorParams) AggregatorAddresses() []common.Address {
+	addresses := []common.Address{i.Address}
+	seen := map[common.Address]struct{}{i.Address: {}}
+	for _, address := range i.Addresses {
+		if _, exists := seen[address]; exists {
+			continue
+		}
+		seen[address] = struct{}{}
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
 // Topics handle the serialization of ethereum log topics to and from the data store.
 type Topics [][]common.Hash
 
@@ -315,6 +343,10 @@ func (i Initiator) IsLogInitiated() bool {
 // URL strings and/or objects containing the names of bridges
 type Feeds = JSON
 
+// AnswerTransforms holds the json of the answerTransforms parameter in the job
+// spec. It is an array of objects naming the transforms to apply.
+type AnswerTransforms = JSON
+
 // TaskSpec is the definition of work to be carried out. The
 // Type will be an adapter, and the Params will contain any
 // additional information that adapter would need to operate.
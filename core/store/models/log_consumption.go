@@ -1,17 +1,24 @@
 package models
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/chainlink/core/eth"
+	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
-// LogConsumerTypeJob - LogConsumptions with this type were consumed by a job
-const LogConsumerTypeJob = "job"
+const (
+	// LogConsumerTypeJob - LogConsumptions with this type were consumed by a job
+	LogConsumerTypeJob = "job"
+	// LogConsumerTypeService - LogConsumptions with this type were consumed by a
+	// service, such as an infrastructure component, rather than by a job
+	LogConsumerTypeService = "service"
+)
 
 // LogConsumerTypes holds the list of valid consumer types
-var LogConsumerTypes = [1]string{LogConsumerTypeJob}
+var LogConsumerTypes = [2]string{LogConsumerTypeJob, LogConsumerTypeService}
 
 // A LogConsumption is a unique record indicating that a particular consumer has
 // already consumed a particular log. This record can be used to prevent consumers
@@ -22,10 +29,17 @@ type LogConsumption struct {
 	LogIndex     uint
 	ConsumerType string
 	ConsumerID   *ID
-	CreatedAt    time.Time
+	// ChainID is the id of the chain the log was consumed on.  Consumptions
+	// recorded before they were scoped by chain, or by a consumer which doesn't
+	// know its chain, have chain id zero, and count as consumptions on every
+	// chain.
+	ChainID   *utils.Big `gorm:"default:0"`
+	CreatedAt time.Time
 }
 
-// A LogConsumer has a type and ID, and uniquely identifies a LogListener
+// A LogConsumer has a type and ID, and uniquely identifies a LogListener.
+// Consumers of different types may share an ID: their consumptions are tracked
+// independently.
 type LogConsumer struct {
 	Type string
 	ID   *ID
@@ -48,3 +62,13 @@ func NewLogConsumption(log eth.RawLog, consumer LogConsumer) LogConsumption {
 	lc.ConsumerID = consumer.ID
 	return lc
 }
+
+// NewLogConsumptionOnChain creates a new LogConsumption, scoped to the chain
+// with the given id
+func NewLogConsumptionOnChain(log eth.RawLog, consumer LogConsumer, chainID *big.Int) LogConsumption {
+	lc := NewLogConsumption(log, consumer)
+	if chainID != nil {
+		lc.ChainID = utils.NewBig(chainID)
+	}
+	return lc
+}
@@ -52,10 +52,18 @@ func newPrivateKey(rawKey *big.Int) (*PrivateKey, error) {
 	return sk, nil
 }
 
+// k.GenerateProof(seed) is a VRF proof of randomness using k and seed. It makes
+// k a vrf.Signer.
+func (k *PrivateKey) GenerateProof(seed *big.Int) (*vrf.Proof, error) {
+	return vrf.GenerateProof(secp256k1.ScalarToHash(k.k), common.BigToHash(seed))
+}
+
+var _ vrf.Signer = (*PrivateKey)(nil)
+
 // k.MarshaledProof(seed) is a VRF proof of randomness using k and seed, in the
 // form required by VRF.sol's randomValueFromVRFProof
 func (k *PrivateKey) MarshaledProof(seed *big.Int) (vrf.MarshaledProof, error) {
-	proof, err := vrf.GenerateProof(secp256k1.ScalarToHash(k.k), common.BigToHash(seed))
+	proof, err := k.GenerateProof(seed)
 	if err != nil {
 		return vrf.MarshaledProof{}, err
 	}
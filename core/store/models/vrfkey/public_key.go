@@ -8,7 +8,7 @@ import (
 	"go.dedis.ch/kyber/v3"
 
 	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
-	"github.com/smartcontractkit/chainlink/core/utils"
+	"github.com/smartcontractkit/chainlink/core/services/vrf"
 )
 
 // PublicKey is a secp256k1 point in compressed format
@@ -91,7 +91,7 @@ func (k *PublicKey) Hash() (common.Hash, error) {
 	if err != nil {
 		return common.Hash{}, err
 	}
-	return utils.MustHash(string(secp256k1.LongMarshal(p))), nil
+	return vrf.PublicKeyHash(p)
 }
 
 // MusthHash is like Hash, but panics on error. Useful for testing.
@@ -188,6 +188,50 @@ func (c Config) FeatureFluxMonitor() bool {
 	return c.viper.GetBool(EnvVarName("FeatureFluxMonitor"))
 }
 
+// FluxMonitorBreakerCooldown is how long a Flux Monitor job halted by
+// its circuit breaker waits before reading the aggregator's round state again.
+func (c Config) FluxMonitorBreakerCooldown() models.Duration {
+	return c.getDuration("FluxMonitorBreakerCooldown")
+}
+
+// FluxMonitorBreakerThreshold is the number of consecutive failures to
+// read an aggregator's round state after which a Flux Monitor job halts, until
+// FluxMonitorBreakerCooldown has passed.  Zero never halts a job.
+func (c Config) FluxMonitorBreakerThreshold() uint32 {
+	return c.viper.GetUint32(EnvVarName("FluxMonitorBreakerThreshold"))
+}
+
+// FluxMonitorLogDebounce is how long a Flux Monitor job waits after receiving
+// a log before processing it, so that the logs received meanwhile, e.g. while
+// catching up, are processed together, and a burst of NewRound logs is
+// answered with a single evaluation of the newest round.  Zero processes each
+// log as soon as it's received.
+func (c Config) FluxMonitorLogDebounce() models.Duration {
+	return c.getDuration("FluxMonitorLogDebounce")
+}
+
+// FluxMonitorMaxSubmissions is the maximum number of submissions the Flux
+// Monitor hands to the run manager at once, across all jobs.  Zero means
+// unlimited.
+func (c Config) FluxMonitorMaxSubmissions() uint32 {
+	return c.viper.GetUint32(EnvVarName("FluxMonitorMaxSubmissions"))
+}
+
+// FluxMonitorMinimumEthBalanceWei is the minimum balance in wei the node's
+// account must hold for the Flux Monitor to submit an answer, so that it
+// doesn't submit transactions it can't pay the gas for.  Zero disables the
+// check.
+func (c Config) FluxMonitorMinimumEthBalanceWei() *big.Int {
+	return c.getWithFallback("FluxMonitorMinimumEthBalanceWei", parseBigInt).(*big.Int)
+}
+
+// FluxMonitorShutdownTimeout is how long the Flux Monitor waits on shutdown
+// for in-flight submissions to be recorded and its checkers to stop.  Zero
+// means it waits indefinitely.
+func (c Config) FluxMonitorShutdownTimeout() models.Duration {
+	return c.getDuration("FluxMonitorShutdownTimeout")
+}
+
 // MaxRPCCallsPerSecond returns the rate at which RPC calls can be fired
 func (c Config) MaxRPCCallsPerSecond() uint64 {
 	return c.viper.GetUint64(EnvVarName("MaxRPCCallsPerSecond"))
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding"
 	"fmt"
+	"math/big"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -1186,14 +1187,24 @@ func (orm *ORM) HasConsumedLog(rawLog eth.RawLog, consumer models.LogConsumer) (
 	return orm.LogConsumptionExists(&lc)
 }
 
-// LogConsumptionExists reports whether a given LogConsumption record already exists
+// LogConsumptionExists reports whether a given LogConsumption record already
+// exists, on lc's chain or unscoped by chain
 func (orm *ORM) LogConsumptionExists(lc *models.LogConsumption) (bool, error) {
 	query := "SELECT id FROM log_consumptions " +
 		"WHERE block_hash=$1 " +
 		"AND log_index=$2 " +
 		"AND consumer_type=$3 " +
-		"AND consumer_id=$4"
-	return orm.rowExists(query, lc.BlockHash, lc.LogIndex, lc.ConsumerType, lc.ConsumerID)
+		"AND consumer_id=$4 " +
+		"AND chain_id IN (0, $5)"
+	return orm.rowExists(query, lc.BlockHash, lc.LogIndex, lc.ConsumerType, lc.ConsumerID, logConsumptionChainID(lc))
+}
+
+// logConsumptionChainID returns lc's chain id, which is zero if it's unscoped
+func logConsumptionChainID(lc *models.LogConsumption) *utils.Big {
+	if lc.ChainID == nil {
+		return utils.NewBig(big.NewInt(0))
+	}
+	return lc.ChainID
 }
 
 // CreateLogConsumption creates a new LogConsumption record
@@ -1202,17 +1213,103 @@ func (orm *ORM) CreateLogConsumption(lc *models.LogConsumption) error {
 	return orm.db.Create(lc).Error
 }
 
+// ClaimLogConsumption records lc, unless a consumption of the same log by the
+// same consumer on the same chain, or unscoped by chain, has already been
+// recorded, and reports whether it did.  The
+// check and the insert are a single statement, guarded by the uniqueness of
+// the log and consumer, so that when several workers sharing the database
+// race to claim a log, exactly one of them succeeds.
+func (orm *ORM) ClaimLogConsumption(lc models.LogConsumption) (claimed bool, err error) {
+	err = orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		inserted, err := insertLogConsumptionUnlessExists(dbtx, &lc)
+		if err != nil {
+			return errors.Wrap(err, "unable to claim log consumption")
+		}
+		claimed = inserted
+		return nil
+	})
+	return claimed, err
+}
+
+// insertLogConsumptionUnlessExists records lc, unless a consumption of the
+// same log by the same consumer on the same chain, or unscoped by chain, has
+// already been recorded, and reports whether it did.
+func insertLogConsumptionUnlessExists(dbtx *gorm.DB, lc *models.LogConsumption) (bool, error) {
+	if lc.ID == nil {
+		lc.ID = models.NewID()
+	}
+	if lc.CreatedAt.IsZero() {
+		lc.CreatedAt = time.Now()
+	}
+	result := dbtx.Exec(`
+		INSERT INTO log_consumptions (id, block_hash, log_index, consumer_type, consumer_id, chain_id, created_at)
+		SELECT ?, ?, ?, ?, ?, ?, ?
+		WHERE NOT EXISTS (
+			SELECT 1 FROM log_consumptions
+			WHERE block_hash = ? AND log_index = ? AND consumer_type = ? AND consumer_id = ? AND chain_id = 0
+		)
+		ON CONFLICT (block_hash, consumer_type, consumer_id, log_index, chain_id) DO NOTHING`,
+		lc.ID, lc.BlockHash, lc.LogIndex, lc.ConsumerType, lc.ConsumerID, logConsumptionChainID(lc), lc.CreatedAt,
+		lc.BlockHash, lc.LogIndex, lc.ConsumerType, lc.ConsumerID)
+	return result.RowsAffected == 1, result.Error
+}
+
+// DeleteLogConsumptionsForBlockHash deletes the consumptions of every log from
+// the block with the given hash, e.g. because it has been reorged out of the
+// chain, and returns how many were deleted
+func (orm *ORM) DeleteLogConsumptionsForBlockHash(hash common.Hash) (int64, error) {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.db.Where("block_hash = ?", hash).Delete(models.LogConsumption{})
+	return result.RowsAffected, result.Error
+}
+
+// MarkConsumedBatch creates all of the given LogConsumption records in a
+// single transaction.  Records of consumptions which have already been
+// recorded, e.g. of a redelivered log, are skipped, so only a failure to write
+// one rolls back the whole batch.
+func (orm *ORM) MarkConsumedBatch(lcs []models.LogConsumption) error {
+	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		for i := range lcs {
+			if _, err := insertLogConsumptionUnlessExists(dbtx, &lcs[i]); err != nil {
+				return errors.Wrapf(err, "unable to record log consumption %d of %d", i+1, len(lcs))
+			}
+		}
+		return nil
+	})
+}
+
 // FindLogConsumer finds the consumer of a particular LogConsumption record
 func (orm *ORM) FindLogConsumer(lc *models.LogConsumption) (interface{}, error) {
 	orm.MustEnsureAdvisoryLock()
 
-	if lc.ConsumerType == models.LogConsumerTypeJob {
+	switch lc.ConsumerType {
+	case models.LogConsumerTypeJob:
 		return orm.FindJob(lc.ConsumerID)
+	case models.LogConsumerTypeService:
+		// Services aren't persisted, so they are identified by their ID alone
+		return models.LogConsumer{Type: lc.ConsumerType, ID: lc.ConsumerID}, nil
 	}
 
 	return nil, errors.Errorf("Consumer type %s does  not exist", lc.ConsumerType)
 }
 
+// JobSpecForConsumer returns the job spec of the given log consumer, which must
+// be a job. If no such job exists, the cause of the error is
+// gorm.ErrRecordNotFound.
+func (orm *ORM) JobSpecForConsumer(c models.LogConsumer) (models.JobSpec, error) {
+	if c.Type != models.LogConsumerTypeJob {
+		return models.JobSpec{}, errors.Errorf("log consumer of type %s is not a job", c.Type)
+	}
+	if c.ID == nil {
+		return models.JobSpec{}, errors.New("job log consumer has no ID")
+	}
+	job, err := orm.FindJob(c.ID)
+	if err != nil {
+		return models.JobSpec{}, errors.Wrapf(err, "unable to find job %s for log consumer", c.ID)
+	}
+	return job, nil
+}
+
 // ClobberDiskKeyStoreWithDBKeys writes all keys stored in the orm to
 // the keys folder on disk, deleting anything there prior.
 func (orm *ORM) ClobberDiskKeyStoreWithDBKeys(keysDir string) error {
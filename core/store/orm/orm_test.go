@@ -6,12 +6,14 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/adapters"
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/auth"
+	"github.com/smartcontractkit/chainlink/core/eth"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
 	"github.com/smartcontractkit/chainlink/core/services"
@@ -21,6 +23,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/guregu/null.v3"
@@ -1496,3 +1499,164 @@ func TestJobs_SQLiteBatchSizeIntegrity(t *testing.T) {
 
 	assert.Equal(t, jobNumber, counter)
 }
+
+func TestORM_MarkConsumedBatch(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJob()
+	require.NoError(t, store.CreateJob(&job))
+	consumer := models.LogConsumer{Type: models.LogConsumerTypeJob, ID: job.ID}
+	newConsumption := func(index uint) models.LogConsumption {
+		return models.NewLogConsumption(&eth.Log{BlockHash: cltest.NewHash(), Index: index}, consumer)
+	}
+
+	t.Run("records every consumption", func(t *testing.T) {
+		batch := []models.LogConsumption{newConsumption(0), newConsumption(1), newConsumption(2)}
+		require.NoError(t, store.MarkConsumedBatch(batch))
+
+		for _, lc := range batch {
+			exists, err := store.LogConsumptionExists(&lc)
+			require.NoError(t, err)
+			assert.True(t, exists)
+		}
+	})
+
+	t.Run("skips consumptions already recorded", func(t *testing.T) {
+		redelivered := newConsumption(0)
+		require.NoError(t, store.CreateLogConsumption(&redelivered))
+
+		first, second := newConsumption(1), newConsumption(2)
+		duplicate := redelivered
+		duplicate.ID = models.NewID()
+		require.NoError(t, store.MarkConsumedBatch([]models.LogConsumption{first, duplicate, second}))
+
+		for _, lc := range []models.LogConsumption{first, second} {
+			exists, err := store.LogConsumptionExists(&lc)
+			require.NoError(t, err)
+			assert.True(t, exists)
+		}
+	})
+
+	t.Run("rolls back the whole batch on failure", func(t *testing.T) {
+		before, err := store.CountOf(&models.LogConsumption{})
+		require.NoError(t, err)
+
+		first, second := newConsumption(0), newConsumption(1)
+		unwritable := newConsumption(2)
+		unwritable.ConsumerID = nil // Violates the consumer_id NOT NULL constraint
+		require.Error(t, store.MarkConsumedBatch([]models.LogConsumption{first, second, unwritable}))
+
+		after, err := store.CountOf(&models.LogConsumption{})
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+		exists, err := store.LogConsumptionExists(&first)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestORM_ClaimLogConsumption(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJob()
+	require.NoError(t, store.CreateJob(&job))
+	consumer := models.LogConsumer{Type: models.LogConsumerTypeJob, ID: job.ID}
+	log := &eth.Log{BlockHash: cltest.NewHash(), Index: 3}
+
+	const workers = 20
+	var wg sync.WaitGroup
+	results := make(chan bool, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claimed, err := store.ClaimLogConsumption(models.NewLogConsumption(log, consumer))
+			assert.NoError(t, err)
+			results <- claimed
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	var claims int
+	for claimed := range results {
+		if claimed {
+			claims++
+		}
+	}
+	assert.Equal(t, 1, claims)
+	consumed, err := store.HasConsumedLog(log, consumer)
+	require.NoError(t, err)
+	assert.True(t, consumed)
+
+	// Other consumers, and other logs, are claimed independently
+	other := models.LogConsumer{Type: models.LogConsumerTypeService, ID: job.ID}
+	claimed, err := store.ClaimLogConsumption(models.NewLogConsumption(log, other))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	claimed, err = store.ClaimLogConsumption(models.NewLogConsumption(&eth.Log{BlockHash: log.BlockHash, Index: 4}, consumer))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
+
+func TestORM_DeleteLogConsumptionsForBlockHash(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJob()
+	require.NoError(t, store.CreateJob(&job))
+	consumer := models.LogConsumer{Type: models.LogConsumerTypeJob, ID: job.ID}
+	orphanedHash := cltest.NewHash()
+	orphaned := []*eth.Log{
+		{BlockHash: orphanedHash, Index: 0},
+		{BlockHash: orphanedHash, Index: 1},
+	}
+	kept := &eth.Log{BlockHash: cltest.NewHash(), Index: 0}
+	for _, log := range append(orphaned, kept) {
+		lc := models.NewLogConsumption(log, consumer)
+		require.NoError(t, store.CreateLogConsumption(&lc))
+	}
+
+	deleted, err := store.DeleteLogConsumptionsForBlockHash(orphanedHash)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	for _, log := range orphaned {
+		consumed, err := store.HasConsumedLog(log, consumer)
+		require.NoError(t, err)
+		assert.False(t, consumed)
+	}
+	consumed, err := store.HasConsumedLog(kept, consumer)
+	require.NoError(t, err)
+	assert.True(t, consumed)
+
+	deleted, err = store.DeleteLogConsumptionsForBlockHash(orphanedHash)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+}
+
+func TestORM_JobSpecForConsumer(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+
+	found, err := store.JobSpecForConsumer(models.LogConsumer{Type: models.LogConsumerTypeJob, ID: job.ID})
+	require.NoError(t, err)
+	assert.Equal(t, job.ID, found.ID)
+	require.Len(t, found.Initiators, 1)
+	assert.Equal(t, job.Initiators[0].Type, found.Initiators[0].Type)
+
+	_, err = store.JobSpecForConsumer(models.LogConsumer{Type: models.LogConsumerTypeJob, ID: models.NewID()})
+	require.Error(t, err)
+	assert.Equal(t, gorm.ErrRecordNotFound, errors.Cause(err))
+
+	_, err = store.JobSpecForConsumer(models.LogConsumer{Type: models.LogConsumerTypeService, ID: job.ID})
+	assert.Error(t, err)
+}
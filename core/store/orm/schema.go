@@ -26,6 +26,12 @@ type ConfigSchema struct {
 	EnableExperimentalAdapters      bool            `env:"ENABLE_EXPERIMENTAL_ADAPTERS" default:"false"`
 	FeatureExternalInitiators       bool            `env:"FEATURE_EXTERNAL_INITIATORS" default:"false"`
 	FeatureFluxMonitor              bool            `env:"FEATURE_FLUX_MONITOR" default:"false"`
+	FluxMonitorBreakerCooldown      models.Duration `env:"FLUX_MONITOR_BREAKER_COOLDOWN" default:"5m"`
+	FluxMonitorBreakerThreshold     uint32          `env:"FLUX_MONITOR_BREAKER_THRESHOLD" default:"0"`
+	FluxMonitorLogDebounce          models.Duration `env:"FLUX_MONITOR_LOG_DEBOUNCE" default:"0s"`
+	FluxMonitorMaxSubmissions       uint32          `env:"FLUX_MONITOR_MAX_CONCURRENT_SUBMISSIONS" default:"0"`
+	FluxMonitorMinimumEthBalanceWei big.Int         `env:"FLUX_MONITOR_MINIMUM_ETH_BALANCE_WEI" default:"0"`
+	FluxMonitorShutdownTimeout      models.Duration `env:"FLUX_MONITOR_SHUTDOWN_TIMEOUT" default:"30s"`
 	MaximumServiceDuration          models.Duration `env:"MAXIMUM_SERVICE_DURATION" default:"8760h" `
 	MinimumServiceDuration          models.Duration `env:"MINIMUM_SERVICE_DURATION" default:"0s" `
 	EthGasBumpThreshold             uint64          `env:"ETH_GAS_BUMP_THRESHOLD" default:"12" `
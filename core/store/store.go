@@ -96,6 +96,19 @@ func (wrapper *lazyRPCWrapper) Call(result interface{}, method string, args ...i
 	return wrapper.client.Call(result, method, args...)
 }
 
+func (wrapper *lazyRPCWrapper) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	err := wrapper.lazyDialInitializer()
+	if err != nil {
+		return err
+	}
+
+	if err := wrapper.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	return wrapper.client.CallContext(ctx, result, method, args...)
+}
+
 func (wrapper *lazyRPCWrapper) Subscribe(ctx context.Context, channel interface{}, args ...interface{}) (eth.Subscription, error) {
 	err := wrapper.lazyDialInitializer()
 	if err != nil {
@@ -174,7 +174,7 @@ func (txm *EthTxManager) Connect(bn *models.Head) error {
 		logger.Infof("Rebroadcasting tx %v", attempt.Hash.Hex())
 
 		_, err = txm.SendRawTx(attempt.SignedRawTx)
-		if err != nil && !isNonceTooLowError(err) {
+		if err != nil && !IsNonceTooLowError(err) {
 			logger.Warnf("Failed to rebroadcast tx %v: %v", attempt.Hash.Hex(), err)
 		}
 	}
@@ -264,7 +264,7 @@ func (txm *EthTxManager) createTx(
 			return tx, nil
 		}
 
-		if !isNonceTooLowError(err) {
+		if !IsNonceTooLowError(err) {
 			return nil, errors.Wrap(err, "TxManager#retryInitialTx sendInitialTx")
 		}
 
@@ -353,8 +353,10 @@ var (
 	replacementTransactionUnderpricedRegex = regexp.MustCompile("replacement transaction underpriced")
 )
 
+// IsNonceTooLowError reports whether err is an ethereum node's rejection of a
+// transaction because its nonce has already been used.
 // FIXME: There are probably other types of errors here that are symptomatic of a nonce that is too low
-func isNonceTooLowError(err error) bool {
+func IsNonceTooLowError(err error) bool {
 	return err != nil && nonceTooLowRegex.MatchString(err.Error())
 }
 
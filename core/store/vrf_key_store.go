@@ -18,10 +18,15 @@ import (
 // Similar to the way geth's KeyStore exposes signing capability, VRFKeyStore
 // exposes VRF proof generation without the caller needing explicit knowledge of
 // the secret key.
+//
+// Proofs for a key can also be delegated to an external vrf.Signer, e.g. one
+// backed by an HSM, with RegisterSigner, in which case the secret key never
+// needs to be unlocked here at all.
 type VRFKeyStore struct {
-	lock  sync.RWMutex
-	keys  InMemoryKeyStore
-	store *Store
+	lock    sync.RWMutex
+	keys    InMemoryKeyStore
+	signers map[vrfkey.PublicKey]vrf.Signer
+	store   *Store
 }
 
 type InMemoryKeyStore = map[vrfkey.PublicKey]vrfkey.PrivateKey
@@ -29,9 +34,10 @@ type InMemoryKeyStore = map[vrfkey.PublicKey]vrfkey.PrivateKey
 // NewVRFKeyStore returns an empty VRFKeyStore
 func NewVRFKeyStore(store *Store) *VRFKeyStore {
 	return &VRFKeyStore{
-		lock:  sync.RWMutex{},
-		keys:  make(InMemoryKeyStore),
-		store: store,
+		lock:    sync.RWMutex{},
+		keys:    make(InMemoryKeyStore),
+		signers: make(map[vrfkey.PublicKey]vrf.Signer),
+		store:   store,
 	}
 }
 
@@ -39,16 +45,48 @@ func NewVRFKeyStore(store *Store) *VRFKeyStore {
 // VRF input seed.
 //
 // k must have already been unlocked in ks, as constructing the VRF proof
-// requires the secret key.
+// requires the secret key, unless a signer has been registered for it.
 func (ks *VRFKeyStore) GenerateProof(k *vrfkey.PublicKey, seed *big.Int) (
 	vrf.MarshaledProof, error) {
 	ks.lock.RLock()
 	defer ks.lock.RUnlock()
-	privateKey, found := ks.keys[*k]
+	signer, found := ks.signers[*k]
 	if !found {
-		return vrf.MarshaledProof{}, fmt.Errorf("key %s has not been unlocked", k)
+		privateKey, unlocked := ks.keys[*k]
+		if !unlocked {
+			return vrf.MarshaledProof{}, fmt.Errorf("key %s has not been unlocked", k)
+		}
+		signer = &privateKey
+	}
+	proof, err := signer.GenerateProof(seed)
+	if err != nil {
+		return vrf.MarshaledProof{}, err
+	}
+	if err := checkProofKey(proof, k); err != nil {
+		return vrf.MarshaledProof{}, err
+	}
+	return proof.MarshalForSolidityVerifier()
+}
+
+// RegisterSigner delegates the generation of proofs for k to signer, in place
+// of any unlocked secret key for k
+func (ks *VRFKeyStore) RegisterSigner(k vrfkey.PublicKey, signer vrf.Signer) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	ks.signers[k] = signer
+}
+
+// checkProofKey errors unless proof was generated under k, so that a
+// misconfigured signer can't produce proofs which would fail on-chain
+func checkProofKey(proof *vrf.Proof, k *vrfkey.PublicKey) error {
+	point, err := k.Point()
+	if err != nil {
+		return errors.Wrapf(err, "while parsing public key %s", k)
+	}
+	if proof.PublicKey == nil || !proof.PublicKey.Equal(point) {
+		return fmt.Errorf("signer for key %s generated a proof under a different key", k)
 	}
-	return privateKey.MarshaledProof(seed)
+	return nil
 }
 
 // Unlock tries to unlock each vrf key in the db, using the given pass phrase,
@@ -16,6 +16,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/services/signatures/secp256k1"
+	"github.com/smartcontractkit/chainlink/core/services/vrf"
 	"github.com/smartcontractkit/chainlink/core/services/vrf/generated/solidity_verifier_wrapper"
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models/vrfkey"
@@ -108,3 +109,28 @@ func TestKeyStoreEndToEnd(t *testing.T) {
 	_, err = ks.GenerateProof(key, big.NewInt(10))
 	require.NoError(t, err, "should be able to generate proof with unlocked key")
 }
+
+func TestVRFKeyStore_GenerateProofWithRegisteredSigner(t *testing.T) {
+	ks := strpkg.NewVRFKeyStore(nil)
+	key := vrfkey.NewPrivateKeyXXXTestingOnly(big.NewInt(0x1337))
+	seed := big.NewInt(0xdeadbeef)
+
+	_, err := ks.GenerateProof(&key.PublicKey, seed)
+	require.Error(t, err, "key is neither unlocked nor has a signer")
+
+	ks.RegisterSigner(key.PublicKey, key)
+	marshaledProof, err := ks.GenerateProof(&key.PublicKey, seed)
+	require.NoError(t, err)
+	proof, err := vrf.UnmarshalSolidityProof(marshaledProof[:])
+	require.NoError(t, err)
+	valid, err := proof.VerifyVRFProof()
+	require.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, seed, proof.Seed)
+
+	// A signer holding the wrong key is caught before its proof is used
+	otherKey := vrfkey.NewPrivateKeyXXXTestingOnly(big.NewInt(0x1338))
+	ks.RegisterSigner(key.PublicKey, otherKey)
+	_, err = ks.GenerateProof(&key.PublicKey, seed)
+	assert.Error(t, err)
+}
@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -555,11 +556,13 @@ type DependentAwaiter interface {
 	AwaitDependents() <-chan struct{}
 	AddDependents(n int)
 	DependentReady()
+	PendingDependents() int
 }
 
 type dependentAwaiter struct {
-	wg *sync.WaitGroup
-	ch <-chan struct{}
+	wg      *sync.WaitGroup
+	ch      <-chan struct{}
+	pending int64
 }
 
 func NewDependentAwaiter() DependentAwaiter {
@@ -576,13 +579,21 @@ func (da *dependentAwaiter) AwaitDependents() <-chan struct{} {
 }
 
 func (da *dependentAwaiter) AddDependents(n int) {
+	atomic.AddInt64(&da.pending, int64(n))
 	da.wg.Add(n)
 }
 
 func (da *dependentAwaiter) DependentReady() {
+	atomic.AddInt64(&da.pending, -1)
 	da.wg.Done()
 }
 
+// PendingDependents returns the number of dependents which have been added,
+// but are not yet ready
+func (da *dependentAwaiter) PendingDependents() int {
+	return int(atomic.LoadInt64(&da.pending))
+}
+
 // FIFO queue that discards older items when it reaches its capacity.
 type BoundedQueue struct {
 	capacity uint
@@ -346,6 +346,7 @@ func TestWaitGroupChan(t *testing.T) {
 func TestDependentAwaiter(t *testing.T) {
 	da := utils.NewDependentAwaiter()
 	da.AddDependents(2)
+	assert.Equal(t, 2, da.PendingDependents())
 
 	select {
 	case <-da.AwaitDependents():
@@ -354,6 +355,7 @@ func TestDependentAwaiter(t *testing.T) {
 	}
 
 	da.DependentReady()
+	assert.Equal(t, 1, da.PendingDependents())
 
 	select {
 	case <-da.AwaitDependents():
@@ -369,6 +371,7 @@ func TestDependentAwaiter(t *testing.T) {
 	cltest.CallbackOrTimeout(t, "dependents are now ready", func() {
 		<-da.AwaitDependents()
 	}, 5*time.Second)
+	assert.Equal(t, 0, da.PendingDependents())
 }
 
 func TestBoundedQueue(t *testing.T) {
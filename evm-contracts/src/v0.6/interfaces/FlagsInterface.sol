@@ -0,0 +1,8 @@
+pragma solidity ^0.6.0;
+
+interface FlagsInterface {
+  event FlagRaised(address indexed subject);
+  event FlagLowered(address indexed subject);
+
+  function getFlag(address) external view returns (bool);
+}